@@ -177,6 +177,7 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 			tag.Set("uniqueIndex", "idx_user_phone")
 			return tag
 		}),
+		gen.FieldType("emailVerified", "bool"),
 	)
 	// 生成post模型, 数据库表名为"post", 生成的结构体为"PostM"
 	g.GenerateModelAs(
@@ -195,4 +196,123 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 		gen.FieldRename("ptype", "PType"), // 为了符合 Go 命名规范, 将字段名从ptype改为PType
 		gen.FieldIgnore("placeholder"),
 	)
+	// 生成刷新令牌模型, 数据库表名为"refresh_token", 生成的结构体为"RefreshTokenM"
+	g.GenerateModelAs(
+		"refresh_token",
+		"RefreshTokenM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tokenHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_refresh_token_tokenHash")
+			return tag
+		}),
+	)
+	// 生成个人访问令牌模型, 数据库表名为"access_token", 生成的结构体为"AccessTokenM"
+	g.GenerateModelAs(
+		"access_token",
+		"AccessTokenM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tokenID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_access_token_tokenID")
+			return tag
+		}),
+		gen.FieldGORMTag("tokenHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_access_token_tokenHash")
+			return tag
+		}),
+	)
+	// 生成两步验证模型, 数据库表名为"user_totp", 生成的结构体为"UserTOTPM"
+	g.GenerateModelAs(
+		"user_totp",
+		"UserTOTPM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_totp_userID")
+			return tag
+		}),
+	)
+	// 生成恢复码模型, 数据库表名为"recovery_code", 生成的结构体为"RecoveryCodeM"
+	g.GenerateModelAs(
+		"recovery_code",
+		"RecoveryCodeM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("codeHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_recovery_code_codeHash")
+			return tag
+		}),
+	)
+	// 生成电子邮箱验证令牌模型, 数据库表名为"email_verification", 生成的结构体为"EmailVerificationM"
+	g.GenerateModelAs(
+		"email_verification",
+		"EmailVerificationM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tokenHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_email_verification_tokenHash")
+			return tag
+		}),
+	)
+	// 生成登录失败计数模型, 数据库表名为"login_attempt", 生成的结构体为"LoginAttemptM"
+	g.GenerateModelAs(
+		"login_attempt",
+		"LoginAttemptM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("subject", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_login_attempt_subject")
+			return tag
+		}),
+	)
+	// 生成OIDC授权请求模型, 数据库表名为"oidc_login", 生成的结构体为"OIDCLoginM"
+	g.GenerateModelAs(
+		"oidc_login",
+		"OIDCLoginM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("stateHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_oidc_login_stateHash")
+			return tag
+		}),
+	)
+	// 生成外部身份关联模型, 数据库表名为"user_identity", 生成的结构体为"UserIdentityM"
+	g.GenerateModelAs(
+		"user_identity",
+		"UserIdentityM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("provider", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_provider_subject")
+			return tag
+		}),
+		gen.FieldGORMTag("subject", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_provider_subject")
+			return tag
+		}),
+	)
+	// 生成密码重置令牌模型, 数据库表名为"password_reset", 生成的结构体为"PasswordResetM"
+	g.GenerateModelAs(
+		"password_reset",
+		"PasswordResetM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tokenHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_password_reset_tokenHash")
+			return tag
+		}),
+	)
+	// 生成登录会话模型, 数据库表名为"session", 生成的结构体为"SessionM"
+	g.GenerateModelAs(
+		"session",
+		"SessionM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("sessionID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_session_sessionID")
+			return tag
+		}),
+	)
+	// 生成令牌黑名单模型, 数据库表名为"revoked_token", 生成的结构体为"RevokedTokenM"
+	g.GenerateModelAs(
+		"revoked_token",
+		"RevokedTokenM",
+		gen.FieldRename("jti", "JTI"),
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("jti", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_revoked_token_jti")
+			return tag
+		}),
+	)
 }
@@ -0,0 +1,71 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package app
+
+import (
+	"fmt"
+	"miniblog/internal/pkg/known"
+	"miniblog/pkg/auth"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// 创建 explain-authz 子命令, 使用策略文件离线检查授权结果, 不需要连接数据库.
+func newExplainAuthzCommand() *cobra.Command {
+	var policyFile, domain string
+
+	cmd := &cobra.Command{
+		Use:   "explain-authz SUBJECT OBJECT ACTION",
+		Short: "Explain an authorization decision offline against a casbin policy CSV file",
+		Long: `Explain an authorization decision offline against a casbin policy CSV file.
+
+OBJECT and ACTION are the two parts of a permission name declared on an RPC,
+for example "user:list" is checked as OBJECT "user" and ACTION "list".
+The request is checked in the tenant given by --domain.
+The policy file uses the casbin CSV format, for example:
+
+  p, role::user, *, user, list, deny
+  g, user-000001, role::user, default`,
+		Example:      `  mb-apiserver explain-authz --policy-file policy.csv --domain default user-000001 user list`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			authz, err := auth.NewFileAuthz(policyFile)
+			if err != nil {
+				return fmt.Errorf("failed to load policy file: %w", err)
+			}
+
+			explanation, err := authz.Explain(args[0], domain, args[1], args[2])
+			if err != nil {
+				return fmt.Errorf("failed to explain authorization: %w", err)
+			}
+
+			decision := "deny"
+			if explanation.Allowed {
+				decision = "allow"
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "decision: %s\n", decision)
+			fmt.Fprintf(out, "roles: %s\n", strings.Join(explanation.Roles, ", "))
+			if len(explanation.MatchedPolicies) == 0 {
+				fmt.Fprintln(out, "matched policies: none")
+				return nil
+			}
+			fmt.Fprintln(out, "matched policies:")
+			for _, rule := range explanation.MatchedPolicies {
+				fmt.Fprintf(out, "  p, %s\n", strings.Join(rule, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&policyFile, "policy-file", "policy.csv", "Path to the casbin policy CSV file.")
+	cmd.Flags().StringVar(&domain, "domain", known.DefaultTenant, "Tenant in which the request is checked.")
+
+	return cmd
+}
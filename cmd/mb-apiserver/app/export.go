@@ -0,0 +1,97 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"miniblog/internal/apiserver/pkg/export"
+	"miniblog/internal/pkg/known"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// 创建 export-data 子命令, 从运行中的服务器下载个人数据归档并写入本地文件.
+func newExportDataCommand() *cobra.Command {
+	var server, token, userID, format, output string
+
+	cmd := &cobra.Command{
+		Use:   "export-data",
+		Short: "Download a personal data export archive from a running miniblog server",
+		Long: `Download a personal data export archive from a running miniblog server.
+
+The archive contains the user profile, posts, role assignments and sessions.
+Without --user-id the data of the user owning --token is exported.
+With --user-id the data of that user is exported, which requires an administrator token.`,
+		Example: `  mb-apiserver export-data --token $TOKEN --format zip
+  mb-apiserver export-data --token $ADMIN_TOKEN --user-id user-000001 --output user-000001.json`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != known.DataExportFormatJSON && format != known.DataExportFormatZIP {
+				return fmt.Errorf("unsupported format %q, must be json or zip", format)
+			}
+
+			endpoint := strings.TrimSuffix(server, "/") + "/v1/data-export"
+			if userID != "" {
+				endpoint = strings.TrimSuffix(server, "/") + "/v1/user-data-exports/" + url.PathEscape(userID)
+			}
+			req, err := http.NewRequestWithContext(cmd.Context(), http.MethodGet, endpoint+"?format="+format, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create request: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return fmt.Errorf("failed to request data export: %w", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+				return fmt.Errorf("data export failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+			}
+
+			if output == "" {
+				name := userID
+				if name == "" {
+					name = "me"
+				}
+				output = export.Filename(name, format)
+			}
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+
+			n, err := io.Copy(file, resp.Body)
+			if err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote %d bytes to %s\n", n, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&server, "server", "http://127.0.0.1:5555", "Address of the miniblog HTTP server.")
+	cmd.Flags().StringVar(&token, "token", "", "Access token used to authenticate the request.")
+	cmd.Flags().StringVar(&userID, "user-id", "", "Export the data of this user instead of the token owner.")
+	cmd.Flags().StringVar(&format, "format", known.DataExportFormatJSON, "Archive format, json or zip.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path of the archive file, defaults to miniblog-export-<user>.<format>.")
+	_ = cmd.MarkFlagRequired("token")
+
+	return cmd
+}
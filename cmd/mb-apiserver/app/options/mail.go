@@ -0,0 +1,103 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"miniblog/pkg/mail"
+)
+
+// 支持的邮件发送方式.
+var availableMailDrivers = sets.New("stdout", "file", "smtp")
+
+// 邮件配置选项.
+type MailOptions struct {
+	// Driver定义邮件发送方式: stdout和file用于开发环境, smtp通过SMTP服务器发送
+	Driver string `json:"driver" mapstructure:"driver"`
+
+	// From定义发件人地址
+	From string `json:"from" mapstructure:"from"`
+
+	// File定义file方式下邮件追加写入的文件路径
+	File string `json:"file" mapstructure:"file"`
+
+	// SMTPAddr定义SMTP服务器地址, 格式为host:port
+	SMTPAddr string `json:"smtp-addr" mapstructure:"smtp-addr"`
+
+	// SMTPUsername定义SMTP认证用户名, 为空时不进行认证
+	SMTPUsername string `json:"smtp-username" mapstructure:"smtp-username"`
+
+	// SMTPPassword定义SMTP认证密码
+	SMTPPassword string `json:"smtp-password" mapstructure:"smtp-password"`
+
+	// SMTPTimeout定义连接SMTP服务器和发送邮件的超时时间
+	SMTPTimeout time.Duration `json:"smtp-timeout" mapstructure:"smtp-timeout"`
+}
+
+// 创建带有默认值的MailOptions实例.
+func NewMailOptions() *MailOptions {
+	return &MailOptions{
+		Driver:      "stdout",
+		From:        "miniblog <noreply@miniblog.local>",
+		File:        "mail.log",
+		SMTPTimeout: 10 * time.Second,
+	}
+}
+
+// 将MailOptions中的选项绑定到命令行标志.
+func (o *MailOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Driver, "mail.driver", o.Driver, fmt.Sprintf("Mail delivery driver, available options: %v", sets.List(availableMailDrivers)))
+	fs.StringVar(&o.From, "mail.from", o.From, "The sender address of outgoing emails.")
+	fs.StringVar(&o.File, "mail.file", o.File, "The file that emails are appended to when using the file driver.")
+	fs.StringVar(&o.SMTPAddr, "mail.smtp-addr", o.SMTPAddr, "The address (host:port) of the SMTP server.")
+	fs.StringVar(&o.SMTPUsername, "mail.smtp-username", o.SMTPUsername, "The username used to authenticate with the SMTP server.")
+	fs.StringVar(&o.SMTPPassword, "mail.smtp-password", o.SMTPPassword, "The password used to authenticate with the SMTP server.")
+	fs.DurationVar(&o.SMTPTimeout, "mail.smtp-timeout", o.SMTPTimeout, "The timeout for connecting to the SMTP server and sending an email.")
+}
+
+// 检验MailOptions中的选项是否合法.
+func (o *MailOptions) Validate() []error {
+	errs := []error{}
+
+	if !availableMailDrivers.Has(o.Driver) {
+		errs = append(errs, fmt.Errorf("invalid mail driver: must be one of %v", sets.List(availableMailDrivers)))
+	}
+	if o.From == "" {
+		errs = append(errs, errors.New("mail sender address cannot be empty"))
+	}
+	if o.Driver == "file" && o.File == "" {
+		errs = append(errs, errors.New("mail file cannot be empty when using the file driver"))
+	}
+	if o.Driver == "smtp" && o.SMTPAddr == "" {
+		errs = append(errs, errors.New("SMTP address cannot be empty when using the smtp driver"))
+	}
+
+	return errs
+}
+
+// NewMailer 根据配置创建Mailer.
+func (o *MailOptions) NewMailer() (mail.Mailer, error) {
+	switch o.Driver {
+	case "file":
+		return mail.NewFile(o.File, o.From)
+	case "smtp":
+		return mail.NewSMTP(mail.SMTPConfig{
+			Addr:     o.SMTPAddr,
+			Username: o.SMTPUsername,
+			Password: o.SMTPPassword,
+			From:     o.From,
+			Timeout:  o.SMTPTimeout,
+		})
+	default:
+		return mail.NewStdout(o.From), nil
+	}
+}
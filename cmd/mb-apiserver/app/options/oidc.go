@@ -0,0 +1,89 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package options
+
+import (
+	"errors"
+	"fmt"
+
+	"miniblog/pkg/oidc"
+)
+
+// 外部身份提供方配置选项.
+type OIDCProviderOptions struct {
+	// Name定义身份提供方名称, 发起登录时通过名称选择身份提供方
+	Name string `json:"name" mapstructure:"name"`
+
+	// Issuer定义身份提供方的issuer地址, 服务发现文档从该地址获取
+	Issuer string `json:"issuer" mapstructure:"issuer"`
+
+	// ClientID定义在身份提供方注册的客户端ID
+	ClientID string `json:"client-id" mapstructure:"client-id"`
+
+	// ClientSecret定义客户端密钥, 为空时作为公共客户端只依赖PKCE
+	ClientSecret string `json:"client-secret" mapstructure:"client-secret"`
+
+	// RedirectURL定义授权完成后身份提供方重定向的地址, 通常为/oidc/callback
+	RedirectURL string `json:"redirect-url" mapstructure:"redirect-url"`
+
+	// Scopes定义申请的scope, 为空时使用openid, profile和email
+	Scopes []string `json:"scopes" mapstructure:"scopes"`
+
+	// Claims定义ID Token声明到用户字段的映射
+	Claims OIDCClaimOptions `json:"claims" mapstructure:"claims"`
+}
+
+// ID Token声明映射配置选项, 为空的字段使用标准声明.
+type OIDCClaimOptions struct {
+	// Username定义用户名对应的声明, 默认为preferred_username
+	Username string `json:"username" mapstructure:"username"`
+
+	// Email定义电子邮箱对应的声明, 默认为email
+	Email string `json:"email" mapstructure:"email"`
+
+	// EmailVerified定义电子邮箱是否已验证对应的声明, 默认为email_verified
+	EmailVerified string `json:"email-verified" mapstructure:"email-verified"`
+
+	// Nickname定义昵称对应的声明, 默认为name
+	Nickname string `json:"nickname" mapstructure:"nickname"`
+
+	// Phone定义手机号对应的声明, 默认为phone_number
+	Phone string `json:"phone" mapstructure:"phone"`
+}
+
+// IdentityProviders 基于OIDCProviders创建外部身份提供方客户端, 键为身份提供方名称.
+func (o *ServerOptions) IdentityProviders() (map[string]*oidc.Provider, error) {
+	providers := make(map[string]*oidc.Provider, len(o.OIDCProviders))
+	for _, p := range o.OIDCProviders {
+		if p.Name == "" {
+			return nil, errors.New("oidc provider name is required")
+		}
+		if _, ok := providers[p.Name]; ok {
+			return nil, fmt.Errorf("duplicate oidc provider %q", p.Name)
+		}
+
+		provider, err := oidc.NewProvider(oidc.Config{
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+			Claims: oidc.ClaimMapping{
+				Username:      p.Claims.Username,
+				Email:         p.Claims.Email,
+				EmailVerified: p.Claims.EmailVerified,
+				Nickname:      p.Claims.Nickname,
+				Phone:         p.Claims.Phone,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid oidc provider %q: %w", p.Name, err)
+		}
+		providers[p.Name] = provider
+	}
+
+	return providers, nil
+}
@@ -8,6 +8,9 @@ package options
 import (
 	"errors"
 	"fmt"
+	"net"
+	"os"
+	"slices"
 	"time"
 
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
@@ -17,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"miniblog/internal/apiserver" // 控制面依赖数据面
+	"miniblog/pkg/token"
 )
 
 // 支持服务器模式集合.
@@ -37,12 +41,72 @@ type ServerOptions struct {
 	// JWTKey定义JWT密钥
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
 
+	// JWTKeys定义JWT签名密钥环, 用于密钥轮换, 为空时使用JWTKey作为唯一的签名密钥
+	JWTKeys []SigningKeyOptions `json:"jwt-keys" mapstructure:"jwt-keys"`
+
+	// JWTActiveKeyID定义密钥环中用于签发JWT token的密钥ID
+	JWTActiveKeyID string `json:"jwt-active-key-id" mapstructure:"jwt-active-key-id"`
+
 	// Expiration定义JWT token过期时间
 	Expiration time.Duration `json:"expiration" mapstructure:"expiration"`
 
+	// RefreshExpiration定义刷新令牌过期时间
+	RefreshExpiration time.Duration `json:"refresh-expiration" mapstructure:"refresh-expiration"`
+
+	// ImpersonationExpiration定义管理员模拟登录令牌的过期时间
+	ImpersonationExpiration time.Duration `json:"impersonation-expiration" mapstructure:"impersonation-expiration"`
+
+	// JWTIssuer定义JWT token的签发者(iss)
+	JWTIssuer string `json:"jwt-issuer" mapstructure:"jwt-issuer"`
+
+	// JWTAudience定义JWT token的接收方(aud)
+	JWTAudience string `json:"jwt-audience" mapstructure:"jwt-audience"`
+
+	// ClockSkew定义校验JWT token时间类声明时允许的时钟偏差
+	ClockSkew time.Duration `json:"clock-skew" mapstructure:"clock-skew"`
+
 	// EnableMemoryStore 指示是否启用内存数据库(用于测试或开发环境).
 	EnableMemoryStore bool `json:"enable-memory-store" mapstructure:"enable-memory-store"`
 
+	// PasswordResetURL定义密码重置页面地址, 重置邮件中的链接会附加token查询参数
+	PasswordResetURL string `json:"password-reset-url" mapstructure:"password-reset-url"`
+
+	// PasswordResetExpiration定义密码重置令牌过期时间
+	PasswordResetExpiration time.Duration `json:"password-reset-expiration" mapstructure:"password-reset-expiration"`
+
+	// EmailVerificationURL定义邮箱验证页面地址, 验证邮件中的链接会附加token查询参数
+	EmailVerificationURL string `json:"email-verification-url" mapstructure:"email-verification-url"`
+
+	// EmailVerificationExpiration定义邮箱验证令牌过期时间
+	EmailVerificationExpiration time.Duration `json:"email-verification-expiration" mapstructure:"email-verification-expiration"`
+
+	// RequireVerifiedEmail定义是否只允许电子邮箱已验证的用户创建博客
+	RequireVerifiedEmail bool `json:"require-verified-email" mapstructure:"require-verified-email"`
+
+	// OIDCProviders定义可用于登录的外部身份提供方
+	OIDCProviders []OIDCProviderOptions `json:"oidc-providers" mapstructure:"oidc-providers"`
+
+	// OIDCLoginExpiration定义外部身份提供方登录授权请求的过期时间
+	OIDCLoginExpiration time.Duration `json:"oidc-login-expiration" mapstructure:"oidc-login-expiration"`
+
+	// PolicySyncInterval定义多个实例之间同步casbin策略变更的轮询间隔
+	PolicySyncInterval time.Duration `json:"policy-sync-interval" mapstructure:"policy-sync-interval"`
+
+	// PurgeRetention定义已删除的用户和博客的保留时间, 超过保留时间后会被永久删除
+	PurgeRetention time.Duration `json:"purge-retention" mapstructure:"purge-retention"`
+
+	// PurgeInterval定义清理已删除的用户和博客的间隔
+	PurgeInterval time.Duration `json:"purge-interval" mapstructure:"purge-interval"`
+
+	// SignupTenants定义注册用户时除默认租户外允许选择的租户
+	SignupTenants []string `json:"signup-tenants" mapstructure:"signup-tenants"`
+
+	// TrustedProxies定义gin服务器信任的反向代理地址或网段, 只有来自这些地址的请求才会使用X-Forwarded-For中的客户端IP
+	TrustedProxies []string `json:"trusted-proxies" mapstructure:"trusted-proxies"`
+
+	// MailOptions包含邮件配置选项
+	MailOptions *MailOptions `json:"mail" mapstructure:"mail"`
+
 	// HTTPOptions包含http配置选项
 	HTTPOptions *genericoptions.HTTPOptions `json:"http" mapstructure:"http"`
 
@@ -56,17 +120,52 @@ type ServerOptions struct {
 	TLSOptions *genericoptions.TLSOptions `json:"tls" mapstructure:"tls"`
 }
 
+// 签名密钥配置选项.
+type SigningKeyOptions struct {
+	// ID定义密钥ID, 签发token时写入kid头
+	ID string `json:"id" mapstructure:"id"`
+
+	// Algorithm定义签名算法, 可选值为HS256, RS256, ES256和EdDSA, 为空时使用HS256
+	Algorithm string `json:"algorithm" mapstructure:"algorithm"`
+
+	// Secret定义HMAC密钥, 仅用于HS256
+	Secret string `json:"secret" mapstructure:"secret"`
+
+	// PrivateKeyFile定义PEM格式的私钥文件路径, 用于非对称算法签发token
+	PrivateKeyFile string `json:"private-key-file" mapstructure:"private-key-file"`
+
+	// PublicKeyFile定义PEM格式的公钥文件路径, 只配置公钥的密钥只用于校验token
+	PublicKeyFile string `json:"public-key-file" mapstructure:"public-key-file"`
+
+	// RetireAt定义密钥退役时间(RFC3339格式), 为空表示不退役
+	RetireAt string `json:"retire-at" mapstructure:"retire-at"`
+}
+
 // 创建带有默认值的ServerOptions实例.
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
-		ServerMode:        apiserver.GRPCGatewayServerMode,
-		JWTKey:            "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
-		Expiration:        2 * time.Hour,
-		EnableMemoryStore: true,
-		TLSOptions:        genericoptions.NewTLSOptions(),
-		HTTPOptions:       genericoptions.NewHTTPOptions(),
-		GRPCOptions:       genericoptions.NewGRPCOptions(),
-		MySQLOptions:      genericoptions.NewMySQLOptions(),
+		ServerMode:                  apiserver.GRPCGatewayServerMode,
+		JWTKey:                      "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		Expiration:                  2 * time.Hour,
+		RefreshExpiration:           7 * 24 * time.Hour,
+		ImpersonationExpiration:     15 * time.Minute,
+		JWTIssuer:                   "miniblog",
+		JWTAudience:                 "miniblog",
+		ClockSkew:                   30 * time.Second,
+		EnableMemoryStore:           true,
+		PasswordResetURL:            "http://127.0.0.1:5555/reset-password",
+		PasswordResetExpiration:     30 * time.Minute,
+		EmailVerificationURL:        "http://127.0.0.1:5555/verify-email",
+		EmailVerificationExpiration: 24 * time.Hour,
+		OIDCLoginExpiration:         10 * time.Minute,
+		PolicySyncInterval:          time.Second,
+		PurgeRetention:              30 * 24 * time.Hour,
+		PurgeInterval:               time.Hour,
+		MailOptions:                 NewMailOptions(),
+		TLSOptions:                  genericoptions.NewTLSOptions(),
+		HTTPOptions:                 genericoptions.NewHTTPOptions(),
+		GRPCOptions:                 genericoptions.NewGRPCOptions(),
+		MySQLOptions:                genericoptions.NewMySQLOptions(),
 	}
 	opts.HTTPOptions.Addr = ":5555"
 	opts.GRPCOptions.Addr = ":6666"
@@ -81,7 +180,24 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// 绑定 JWT Token 的过期时间选项到命令行标志。
 	// 参数名称为 `--expiration`, 默认值为 o.Expiration
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "The expiration duration of JWT tokens.")
+	fs.DurationVar(&o.RefreshExpiration, "refresh-expiration", o.RefreshExpiration, "The expiration duration of refresh tokens.")
+	fs.DurationVar(&o.ImpersonationExpiration, "impersonation-expiration", o.ImpersonationExpiration, "The expiration duration of impersonation tokens issued to administrators.")
+	fs.StringVar(&o.JWTIssuer, "jwt-issuer", o.JWTIssuer, "The issuer (iss) of JWT tokens.")
+	fs.StringVar(&o.JWTAudience, "jwt-audience", o.JWTAudience, "The audience (aud) of JWT tokens.")
+	fs.DurationVar(&o.ClockSkew, "clock-skew", o.ClockSkew, "The allowed clock skew when validating the time based claims of JWT tokens.")
 	fs.BoolVar(&o.EnableMemoryStore, "enable-memory-store", o.EnableMemoryStore, "Enable in-memory database (useful for testing or development).")
+	fs.StringVar(&o.PasswordResetURL, "password-reset-url", o.PasswordResetURL, "The URL of the password reset page, the reset token is appended as the token query parameter.")
+	fs.DurationVar(&o.PasswordResetExpiration, "password-reset-expiration", o.PasswordResetExpiration, "The expiration duration of password reset tokens.")
+	fs.StringVar(&o.EmailVerificationURL, "email-verification-url", o.EmailVerificationURL, "The URL of the email verification page, the verification token is appended as the token query parameter.")
+	fs.DurationVar(&o.EmailVerificationExpiration, "email-verification-expiration", o.EmailVerificationExpiration, "The expiration duration of email verification tokens.")
+	fs.BoolVar(&o.RequireVerifiedEmail, "require-verified-email", o.RequireVerifiedEmail, "Only allow users with a verified email address to create posts.")
+	fs.DurationVar(&o.OIDCLoginExpiration, "oidc-login-expiration", o.OIDCLoginExpiration, "The expiration duration of OIDC login requests.")
+	fs.DurationVar(&o.PolicySyncInterval, "policy-sync-interval", o.PolicySyncInterval, "The interval of polling the database for casbin policy changes made by other instances.")
+	fs.DurationVar(&o.PurgeRetention, "purge-retention", o.PurgeRetention, "The retention of deleted users and posts, they can be restored within the retention and are permanently removed afterwards.")
+	fs.DurationVar(&o.PurgeInterval, "purge-interval", o.PurgeInterval, "The interval of permanently removing deleted users and posts whose retention has expired.")
+	fs.StringSliceVar(&o.SignupTenants, "signup-tenants", o.SignupTenants, "Tenants that users can choose when signing up, in addition to the default tenant.")
+	fs.StringSliceVar(&o.TrustedProxies, "trusted-proxies", o.TrustedProxies, "IP addresses or CIDRs of reverse proxies trusted to set X-Forwarded-For in gin server mode, no proxy is trusted by default.")
+	o.MailOptions.AddFlags(fs)
 	o.TLSOptions.AddFlags(fs)
 	o.HTTPOptions.AddFlags(fs)
 	o.GRPCOptions.AddFlags(fs)
@@ -102,7 +218,69 @@ func (o *ServerOptions) Validate() error {
 		errs = append(errs, errors.New("JWTKey must be at least 6 characters long"))
 	}
 
+	// 校验签名密钥环
+	if _, err := o.Keyring(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// 刷新令牌的有效期必须长于访问令牌, 否则刷新没有意义
+	if o.RefreshExpiration <= o.Expiration {
+		errs = append(errs, errors.New("RefreshExpiration must be greater than Expiration"))
+	}
+
+	// 模拟登录令牌只用于临时复现问题, 有效期不能长于普通访问令牌
+	if o.ImpersonationExpiration <= 0 || o.ImpersonationExpiration > o.Expiration {
+		errs = append(errs, errors.New("ImpersonationExpiration must be greater than 0 and not greater than Expiration"))
+	}
+
+	// 时钟偏差不能为负数, 且不能超过token的有效期
+	if o.ClockSkew < 0 || o.ClockSkew >= o.Expiration {
+		errs = append(errs, errors.New("ClockSkew must be non-negative and less than Expiration"))
+	}
+
+	// 密码重置令牌和邮箱验证令牌的有效期必须为正数
+	if o.PasswordResetExpiration <= 0 {
+		errs = append(errs, errors.New("PasswordResetExpiration must be greater than 0"))
+	}
+	if o.EmailVerificationExpiration <= 0 {
+		errs = append(errs, errors.New("EmailVerificationExpiration must be greater than 0"))
+	}
+
+	// 校验外部身份提供方
+	if _, err := o.IdentityProviders(); err != nil {
+		errs = append(errs, err)
+	}
+	if o.OIDCLoginExpiration <= 0 {
+		errs = append(errs, errors.New("OIDCLoginExpiration must be greater than 0"))
+	}
+
+	// 策略同步的轮询间隔必须为正数
+	if o.PolicySyncInterval <= 0 {
+		errs = append(errs, errors.New("PolicySyncInterval must be greater than 0"))
+	}
+
+	// 已删除数据的保留时间和清理间隔必须为正数
+	if o.PurgeRetention <= 0 {
+		errs = append(errs, errors.New("PurgeRetention must be greater than 0"))
+	}
+	if o.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("PurgeInterval must be greater than 0"))
+	}
+
+	// 允许注册的租户不能为空字符串
+	if slices.Contains(o.SignupTenants, "") {
+		errs = append(errs, errors.New("SignupTenants cannot contain an empty tenant"))
+	}
+
+	// 可信代理必须是IP地址或网段
+	for _, proxy := range o.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			errs = append(errs, fmt.Errorf("invalid trusted proxy %q", proxy))
+		}
+	}
+
 	// 校验子选项
+	errs = append(errs, o.MailOptions.Validate()...)
 	errs = append(errs, o.TLSOptions.Validate()...)
 	errs = append(errs, o.HTTPOptions.Validate()...)
 	errs = append(errs, o.MySQLOptions.Validate()...)
@@ -116,16 +294,97 @@ func (o *ServerOptions) Validate() error {
 	return utilerrors.NewAggregate(errs)
 }
 
+// Keyring 基于JWTKeys和JWTActiveKeyID创建签名密钥环, 没有配置JWTKeys时返回nil.
+func (o *ServerOptions) Keyring() (*token.Keyring, error) {
+	if len(o.JWTKeys) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]token.Key, 0, len(o.JWTKeys))
+	for _, k := range o.JWTKeys {
+		key, err := k.key()
+		if err != nil {
+			return nil, err
+		}
+		if k.RetireAt != "" {
+			retireAt, err := time.Parse(time.RFC3339, k.RetireAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid retire-at of signing key %q: %w", k.ID, err)
+			}
+			key.RetireAt = retireAt
+		}
+		keys = append(keys, key)
+	}
+
+	return token.NewKeyring(o.JWTActiveKeyID, keys...)
+}
+
+// 基于签名密钥配置创建签名密钥, 非对称算法从PEM文件中加载密钥.
+func (k SigningKeyOptions) key() (token.Key, error) {
+	if k.Algorithm == "" || k.Algorithm == token.AlgorithmHS256 {
+		return token.Key{ID: k.ID, Algorithm: k.Algorithm, Secret: []byte(k.Secret)}, nil
+	}
+
+	var privatePEM, publicPEM []byte
+	var err error
+	if k.PrivateKeyFile != "" {
+		if privatePEM, err = os.ReadFile(k.PrivateKeyFile); err != nil {
+			return token.Key{}, fmt.Errorf("read private key of signing key %q: %w", k.ID, err)
+		}
+	}
+	if k.PublicKeyFile != "" {
+		if publicPEM, err = os.ReadFile(k.PublicKeyFile); err != nil {
+			return token.Key{}, fmt.Errorf("read public key of signing key %q: %w", k.ID, err)
+		}
+	}
+
+	return token.NewKeyFromPEM(k.ID, k.Algorithm, privatePEM, publicPEM)
+}
+
 // Config方法基于ServerOptions创建新的apiserver.Config.
 func (o *ServerOptions) Config() (*apiserver.Config, error) {
+	keyring, err := o.Keyring()
+	if err != nil {
+		return nil, err
+	}
+
+	mailer, err := o.MailOptions.NewMailer()
+	if err != nil {
+		return nil, err
+	}
+
+	oidcProviders, err := o.IdentityProviders()
+	if err != nil {
+		return nil, err
+	}
+
 	return &apiserver.Config{
-		ServerMode:        o.ServerMode,
-		JWTKey:            o.JWTKey,
-		Expiration:        o.Expiration,
-		EnableMemoryStore: o.EnableMemoryStore,
-		TLSOptions:        o.TLSOptions,
-		HTTPOptions:       o.HTTPOptions,
-		GRPCOptions:       o.GRPCOptions,
-		MySQLOptions:      o.MySQLOptions,
+		ServerMode:                  o.ServerMode,
+		JWTKey:                      o.JWTKey,
+		JWTKeyring:                  keyring,
+		Expiration:                  o.Expiration,
+		RefreshExpiration:           o.RefreshExpiration,
+		ImpersonationExpiration:     o.ImpersonationExpiration,
+		JWTIssuer:                   o.JWTIssuer,
+		JWTAudience:                 o.JWTAudience,
+		ClockSkew:                   o.ClockSkew,
+		EnableMemoryStore:           o.EnableMemoryStore,
+		Mailer:                      mailer,
+		PasswordResetURL:            o.PasswordResetURL,
+		PasswordResetExpiration:     o.PasswordResetExpiration,
+		EmailVerificationURL:        o.EmailVerificationURL,
+		EmailVerificationExpiration: o.EmailVerificationExpiration,
+		RequireVerifiedEmail:        o.RequireVerifiedEmail,
+		OIDCProviders:               oidcProviders,
+		OIDCLoginExpiration:         o.OIDCLoginExpiration,
+		PolicySyncInterval:          o.PolicySyncInterval,
+		PurgeRetention:              o.PurgeRetention,
+		PurgeInterval:               o.PurgeInterval,
+		SignupTenants:               o.SignupTenants,
+		TrustedProxies:              o.TrustedProxies,
+		TLSOptions:                  o.TLSOptions,
+		HTTPOptions:                 o.HTTPOptions,
+		GRPCOptions:                 o.GRPCOptions,
+		MySQLOptions:                o.MySQLOptions,
 	}, nil
 }
@@ -9,6 +9,9 @@ import (
 	"fmt"
 	"miniblog/cmd/mb-apiserver/app/options"
 	"miniblog/internal/pkg/log"
+	"miniblog/pkg/token"
+
+	"github.com/fsnotify/fsnotify"
 
 	"github.com/onexstack/onexstack/pkg/core"
 	"github.com/onexstack/onexstack/pkg/version"
@@ -82,6 +85,11 @@ The project features include:
 
 	// 添加 --version标志
 	version.AddFlags(cmd.PersistentFlags())
+
+	// 添加离线检查授权结果的子命令
+	cmd.AddCommand(newExplainAuthzCommand())
+	// 添加下载个人数据归档的子命令
+	cmd.AddCommand(newExportDataCommand())
 	return cmd
 }
 
@@ -118,10 +126,43 @@ func run(opts *options.ServerOptions) error {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
 
+	// 监听配置文件变化, 热加载签名密钥环
+	watchKeyring()
+
 	// 启动服务器
 	return server.Run()
 }
 
+// 监听配置文件的变化, 在不重启服务的情况下重新加载JWT签名密钥环.
+// 只有jwt-keys和jwt-active-key-id支持热加载, 其余配置项修改后仍需重启服务.
+func watchKeyring() {
+	if viper.ConfigFileUsed() == "" {
+		return
+	}
+
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		reloaded := &options.ServerOptions{JWTActiveKeyID: viper.GetString("jwt-active-key-id")}
+		if err := viper.UnmarshalKey("jwt-keys", &reloaded.JWTKeys); err != nil {
+			log.Errorw("Failed to unmarshal signing keys", "file", e.Name, "err", err)
+			return
+		}
+
+		keyring, err := reloaded.Keyring()
+		if err != nil {
+			log.Errorw("Failed to reload signing keyring, keep using the current one", "file", e.Name, "err", err)
+			return
+		}
+		if keyring == nil {
+			log.Warnw("No signing keys configured, keep using the current keyring", "file", e.Name)
+			return
+		}
+
+		token.SetKeyring(keyring)
+		log.Infow("Signing keyring reloaded", "file", e.Name, "active-key-id", reloaded.JWTActiveKeyID, "keys", len(reloaded.JWTKeys))
+	})
+	viper.WatchConfig()
+}
+
 // viper.Get<Type> 中key到名字需要使用.分割, 以跟yaml中保持相同的缩进.
 func logOptions() *log.Options {
 	opts := log.NewOptions()
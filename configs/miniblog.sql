@@ -30,6 +30,74 @@ CREATE DATABASE /*!32312 IF NOT EXISTS*/ `miniblog` /*!40100 DEFAULT CHARACTER S
 
 USE `miniblog`;
 
+--
+-- Table structure for table `access_token`
+--
+
+DROP TABLE IF EXISTS `access_token`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `access_token` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `tokenID` varchar(36) NOT NULL DEFAULT '' COMMENT '访问令牌唯一 ID',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '访问令牌名称',
+  `tokenHash` char(64) NOT NULL DEFAULT '' COMMENT '访问令牌的 SHA-256 摘要',
+  `actions` varchar(255) NOT NULL DEFAULT '' COMMENT '访问令牌允许使用的权限, 以逗号分隔',
+  `expiresAt` datetime DEFAULT NULL COMMENT '访问令牌过期时间, 为空表示永不过期',
+  `lastUsedAt` datetime DEFAULT NULL COMMENT '访问令牌最后使用时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '访问令牌创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '访问令牌最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_access_token_tokenID` (`tokenID`),
+  UNIQUE KEY `idx_access_token_tokenHash` (`tokenHash`),
+  KEY `idx.access_token.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='个人访问令牌表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `casbin_policy_change`
+--
+
+DROP TABLE IF EXISTS `casbin_policy_change`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `casbin_policy_change` (
+  `version` bigint(20) NOT NULL COMMENT '变更后的策略版本',
+  `instance` varchar(36) NOT NULL COMMENT '发布变更的实例 ID',
+  `payload` text NOT NULL COMMENT 'JSON 格式的策略变更',
+  `createdAt` datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '变更时间',
+  PRIMARY KEY (`version`),
+  KEY `idx_casbin_policy_change_createdAt` (`createdAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='casbin 策略变更记录表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `casbin_policy_version`
+--
+
+DROP TABLE IF EXISTS `casbin_policy_version`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `casbin_policy_version` (
+  `id` bigint(20) NOT NULL,
+  `version` bigint(20) NOT NULL DEFAULT 0 COMMENT '最新的策略版本, 每次策略变更时加一',
+  `updatedAt` datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '最后变更时间',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='casbin 策略版本表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `casbin_policy_version`
+--
+
+LOCK TABLES `casbin_policy_version` WRITE;
+/*!40000 ALTER TABLE `casbin_policy_version` DISABLE KEYS */;
+INSERT INTO `casbin_policy_version` VALUES
+(1,0,'2024-12-12 03:55:25.000');
+/*!40000 ALTER TABLE `casbin_policy_version` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `casbin_rule`
 --
@@ -58,15 +126,108 @@ CREATE TABLE `casbin_rule` (
 LOCK TABLES `casbin_rule` WRITE;
 /*!40000 ALTER TABLE `casbin_rule` DISABLE KEYS */;
 INSERT INTO `casbin_rule` VALUES
-(18,'g','user-000000','role::admin',NULL,NULL,'',''),
-(21,'p','role::admin','*','*','allow','',''),
-(7,'p','role::user','/v1.MiniBlog/DeleteUser','CALL','deny','',''),
-(8,'p','role::user','/v1.MiniBlog/ListUser','CALL','deny','',''),
-(9,'p','role::user','/v1/users','GET','deny','',''),
-(10,'p','role::user','/v1/users/*','DELETE','deny','','');
+(18,'g','user-000000','role::admin','*',NULL,'',''),
+(21,'p','role::admin','*','*','*','allow',''),
+(7,'p','role::user','*','user','delete','deny',''),
+(8,'p','role::user','*','user','list','deny',''),
+(9,'p','role::user','*','user','revoke-tokens','deny',''),
+(10,'p','role::user','*','user','unlock','deny',''),
+(11,'p','role::user','*','user','impersonate','deny',''),
+(22,'p','role::user','*','user','update-status','deny',''),
+(23,'p','role::user','*','user','restore','deny',''),
+(24,'p','role::user','*','post','restore','deny',''),
+(12,'p','role::user','*','user-session','*','deny',''),
+(25,'p','role::user','*','user-data-export','*','deny',''),
+(13,'p','role::user','*','policy','*','deny',''),
+(14,'p','role::user','*','grouping-policy','*','deny',''),
+(15,'p','role::user','*','user-role','*','deny','');
 /*!40000 ALTER TABLE `casbin_rule` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `email_verification`
+--
+
+DROP TABLE IF EXISTS `email_verification`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `email_verification` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `email` varchar(256) NOT NULL DEFAULT '' COMMENT '待验证的电子邮箱地址',
+  `tokenHash` char(64) NOT NULL DEFAULT '' COMMENT '验证令牌的 SHA-256 摘要',
+  `expiresAt` datetime NOT NULL COMMENT '验证令牌过期时间',
+  `usedAt` datetime DEFAULT NULL COMMENT '验证令牌被使用的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '验证令牌创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '验证令牌最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_email_verification_tokenHash` (`tokenHash`),
+  KEY `idx.email_verification.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='电子邮箱验证令牌表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `login_attempt`
+--
+
+DROP TABLE IF EXISTS `login_attempt`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `login_attempt` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `subject` varchar(255) NOT NULL DEFAULT '' COMMENT '计数对象, 格式为 username:<用户名> 或 ip:<地址>',
+  `failures` int(11) NOT NULL DEFAULT 0 COMMENT '连续登录失败次数',
+  `lastFailedAt` datetime NOT NULL COMMENT '最近一次登录失败的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '记录创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '记录最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_login_attempt_subject` (`subject`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='登录失败计数表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `oidc_login`
+--
+
+DROP TABLE IF EXISTS `oidc_login`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `oidc_login` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `provider` varchar(64) NOT NULL DEFAULT '' COMMENT '外部身份提供方名称',
+  `stateHash` char(64) NOT NULL DEFAULT '' COMMENT '授权请求 state 参数的 SHA-256 摘要',
+  `nonce` varchar(64) NOT NULL DEFAULT '' COMMENT '授权请求的 nonce 参数, 用于校验 ID Token',
+  `codeVerifier` varchar(128) NOT NULL DEFAULT '' COMMENT 'PKCE 校验码',
+  `expiresAt` datetime NOT NULL COMMENT '授权请求过期时间',
+  `usedAt` datetime DEFAULT NULL COMMENT '授权请求完成回调的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '授权请求创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '授权请求最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_oidc_login_stateHash` (`stateHash`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='OIDC 授权请求表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `password_reset`
+--
+
+DROP TABLE IF EXISTS `password_reset`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `password_reset` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `tokenHash` char(64) NOT NULL DEFAULT '' COMMENT '重置令牌的 SHA-256 摘要',
+  `expiresAt` datetime NOT NULL COMMENT '重置令牌过期时间',
+  `usedAt` datetime DEFAULT NULL COMMENT '重置令牌被使用的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '重置令牌创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '重置令牌最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_password_reset_tokenHash` (`tokenHash`),
+  KEY `idx.password_reset.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='密码重置令牌表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `post`
 --
@@ -77,14 +238,18 @@ DROP TABLE IF EXISTS `post`;
 CREATE TABLE `post` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
   `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `tenantID` varchar(36) NOT NULL DEFAULT 'default' COMMENT '博文所属租户',
   `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
   `title` varchar(256) NOT NULL DEFAULT '' COMMENT '博文标题',
   `content` longtext NOT NULL DEFAULT '' COMMENT '博文内容',
   `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '博文创建时间',
   `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '博文最后修改时间',
+  `deletedAt` datetime DEFAULT NULL COMMENT '博文删除时间, 为空时表示未删除',
   PRIMARY KEY (`id`),
   UNIQUE KEY `post.postID` (`postID`),
-  KEY `idx.post.userID` (`userID`)
+  KEY `idx.post.userID` (`userID`),
+  KEY `idx_post_tenantID` (`tenantID`),
+  KEY `idx_post_deletedAt` (`deletedAt`)
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='博文表';
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -97,6 +262,93 @@ LOCK TABLES `post` WRITE;
 /*!40000 ALTER TABLE `post` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `recovery_code`
+--
+
+DROP TABLE IF EXISTS `recovery_code`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `recovery_code` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `codeHash` char(64) NOT NULL DEFAULT '' COMMENT '恢复码的 SHA-256 摘要',
+  `usedAt` datetime DEFAULT NULL COMMENT '恢复码被使用的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '恢复码创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '恢复码最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_recovery_code_codeHash` (`codeHash`),
+  KEY `idx.recovery_code.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='两步验证恢复码表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `refresh_token`
+--
+
+DROP TABLE IF EXISTS `refresh_token`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `refresh_token` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `familyID` varchar(36) NOT NULL DEFAULT '' COMMENT '令牌族 ID, 同一次登录轮换出的令牌共享',
+  `tokenHash` char(64) NOT NULL DEFAULT '' COMMENT '刷新令牌的 SHA-256 摘要',
+  `expiresAt` datetime NOT NULL COMMENT '刷新令牌过期时间',
+  `revokedAt` datetime DEFAULT NULL COMMENT '刷新令牌被使用或吊销的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '刷新令牌创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '刷新令牌最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_refresh_token_tokenHash` (`tokenHash`),
+  KEY `idx.refresh_token.userID` (`userID`),
+  KEY `idx.refresh_token.familyID` (`familyID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='刷新令牌表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `revoked_token`
+--
+
+DROP TABLE IF EXISTS `revoked_token`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `revoked_token` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `jti` varchar(36) DEFAULT NULL COMMENT '被吊销令牌的唯一标识, 为空表示吊销该用户在吊销时间之前签发的全部令牌',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `expiresAt` datetime NOT NULL COMMENT '记录过期时间, 过期后可以清理',
+  `createdAt` datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '吊销时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_revoked_token_jti` (`jti`),
+  KEY `idx.revoked_token.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='令牌黑名单表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `session`
+--
+
+DROP TABLE IF EXISTS `session`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `session` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `sessionID` varchar(36) NOT NULL DEFAULT '' COMMENT '会话唯一 ID, 与刷新令牌族 ID 相同',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `userAgent` varchar(512) NOT NULL DEFAULT '' COMMENT '登录时客户端的 User-Agent',
+  `ip` varchar(64) NOT NULL DEFAULT '' COMMENT '登录时客户端的 IP 地址',
+  `requestID` varchar(36) NOT NULL DEFAULT '' COMMENT '登录请求的请求 ID',
+  `lastSeenAt` datetime NOT NULL COMMENT '会话最后活跃时间',
+  `expiresAt` datetime NOT NULL COMMENT '会话过期时间, 与最新刷新令牌的过期时间相同',
+  `revokedAt` datetime DEFAULT NULL COMMENT '会话被吊销的时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '会话创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '会话最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_session_sessionID` (`sessionID`),
+  KEY `idx.session.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='登录会话表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `user`
 --
@@ -107,18 +359,28 @@ DROP TABLE IF EXISTS `user`;
 CREATE TABLE `user` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
   `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `tenantID` varchar(36) NOT NULL DEFAULT 'default' COMMENT '用户所属租户',
   `username` varchar(255) NOT NULL DEFAULT '' COMMENT '用户名（唯一）',
   `password` varchar(255) NOT NULL DEFAULT '' COMMENT '用户密码（加密后）',
   `nickname` varchar(30) NOT NULL DEFAULT '' COMMENT '用户昵称',
   `email` varchar(256) NOT NULL DEFAULT '' COMMENT '用户电子邮箱地址',
-  `phone` varchar(16) NOT NULL DEFAULT '' COMMENT '用户手机号',
+  `phone` varchar(16) DEFAULT NULL COMMENT '用户手机号, 通过外部身份提供方创建的用户可以为空',
+  `emailVerified` tinyint(1) NOT NULL DEFAULT 0 COMMENT '电子邮箱是否已验证',
+  `verifiedAt` datetime DEFAULT NULL COMMENT '电子邮箱验证时间',
+  `status` varchar(16) NOT NULL DEFAULT 'active' COMMENT '用户状态, 可选值为 active, disabled 和 banned',
+  `statusReason` varchar(255) NOT NULL DEFAULT '' COMMENT '禁用或封禁用户的原因',
+  `statusUntil` datetime DEFAULT NULL COMMENT '封禁的截止时间, 为空时表示永久封禁',
   `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '用户创建时间',
   `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '用户最后修改时间',
+  `deletedAt` datetime DEFAULT NULL COMMENT '用户删除时间, 为空时表示未删除',
   PRIMARY KEY (`id`),
   UNIQUE KEY `user.userID` (`userID`),
   UNIQUE KEY `user.username` (`username`),
-  UNIQUE KEY `user.phone` (`phone`)
-) ENGINE=MyISAM AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户表';
+  UNIQUE KEY `user.phone` (`phone`),
+  KEY `idx_user_tenantID` (`tenantID`),
+  KEY `idx_user_status` (`status`),
+  KEY `idx_user_deletedAt` (`deletedAt`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户表';
 /*!40101 SET character_set_client = @saved_cs_client */;
 
 --
@@ -128,9 +390,50 @@ CREATE TABLE `user` (
 LOCK TABLES `user` WRITE;
 /*!40000 ALTER TABLE `user` DISABLE KEYS */;
 INSERT INTO `user` VALUES
-(96,'user-000000','root','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','colin404','colin404@foxmail.com','18110000000','2024-12-12 03:55:25','2024-12-12 03:55:25');
+(96,'user-000000','default','root','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','colin404','colin404@foxmail.com','18110000000',1,'2024-12-12 03:55:25','active','',NULL,'2024-12-12 03:55:25','2024-12-12 03:55:25',NULL);
 /*!40000 ALTER TABLE `user` ENABLE KEYS */;
 UNLOCK TABLES;
+
+--
+-- Table structure for table `user_identity`
+--
+
+DROP TABLE IF EXISTS `user_identity`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_identity` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `provider` varchar(64) NOT NULL DEFAULT '' COMMENT '外部身份提供方名称',
+  `subject` varchar(255) NOT NULL DEFAULT '' COMMENT '外部用户在身份提供方中的唯一标识',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '关联创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '关联最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_identity_provider_subject` (`provider`,`subject`),
+  KEY `idx.user_identity.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='外部身份关联表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `user_totp`
+--
+
+DROP TABLE IF EXISTS `user_totp`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_totp` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `secret` varchar(64) NOT NULL DEFAULT '' COMMENT 'TOTP 密钥, base32 编码',
+  `confirmedAt` datetime DEFAULT NULL COMMENT '两步验证启用时间, 为空表示尚未确认',
+  `lastUsedStep` bigint(20) NOT NULL DEFAULT 0 COMMENT '最近一次使用的一次性密码的时间步, 用于防止重放',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT 'TOTP 绑定时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT 'TOTP 最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_totp_userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户两步验证表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
 
 /*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
@@ -77,7 +77,7 @@ func main() {
 		_, _ = client.DeleteUser(ctx, &apiv1.DeleteUserRequest{UserID: createUserResponse.UserID})
 	}()
 
-	refreshTokenResponse, err := client.RefreshToken(ctx, &apiv1.RefreshTokenRequest{})
+	refreshTokenResponse, err := client.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: loginResponse.RefreshToken})
 	if err != nil {
 		log.Printf("Failed to refresh token: %v", err)
 		return
@@ -7,11 +7,14 @@ package biz
 
 // Biz层依赖Store层, 主要用来实现系统中REST资源的各类业务操作, 例如用户资源的增删改查等.
 import (
+	accesstokenv1 "miniblog/internal/apiserver/biz/v1/accesstoken"
+	policyv1 "miniblog/internal/apiserver/biz/v1/policy"
 	postv1 "miniblog/internal/apiserver/biz/v1/post"
 	userv1 "miniblog/internal/apiserver/biz/v1/user"
+	"miniblog/internal/apiserver/pkg/denylist"
+	"miniblog/internal/apiserver/pkg/lockout"
 	"miniblog/internal/apiserver/store"
-
-	"github.com/onexstack/onexstack/pkg/authz"
+	"miniblog/pkg/auth"
 
 	"github.com/google/wire"
 )
@@ -28,23 +31,39 @@ type IBiz interface {
 	UserV1() userv1.UserBiz
 	// 获取帖子业务接口
 	PostV1() postv1.PostBiz
+	// 获取个人访问令牌业务接口
+	AccessTokenV1() accesstokenv1.AccessTokenBiz
+	// 获取授权策略业务接口
+	PolicyV1() policyv1.PolicyBiz
 }
 
 type biz struct {
-	store store.IStore
-	authz *authz.Authz
+	store    store.IStore
+	authz    *auth.Authz
+	denylist denylist.Denylist
+	guard    *lockout.Guard
+	userOpts *userv1.Options
+	postOpts *postv1.Options
 }
 
 var _ IBiz = (*biz)(nil)
 
-func NewBiz(store store.IStore, authz *authz.Authz) *biz {
-	return &biz{store: store, authz: authz}
+func NewBiz(store store.IStore, authz *auth.Authz, denylist denylist.Denylist, guard *lockout.Guard, userOpts *userv1.Options, postOpts *postv1.Options) *biz {
+	return &biz{store: store, authz: authz, denylist: denylist, guard: guard, userOpts: userOpts, postOpts: postOpts}
 }
 
 func (b *biz) UserV1() userv1.UserBiz {
-	return userv1.New(b.store, b.authz)
+	return userv1.New(b.store, b.authz, b.denylist, b.guard, b.userOpts)
 }
 
 func (b *biz) PostV1() postv1.PostBiz {
-	return postv1.New(b.store)
+	return postv1.New(b.store, b.authz, b.postOpts)
+}
+
+func (b *biz) AccessTokenV1() accesstokenv1.AccessTokenBiz {
+	return accesstokenv1.New(b.store)
+}
+
+func (b *biz) PolicyV1() policyv1.PolicyBiz {
+	return policyv1.New(b.store, b.authz)
 }
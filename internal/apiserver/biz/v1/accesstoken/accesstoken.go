@@ -0,0 +1,107 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package accesstoken
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/pkg/conversion"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"strings"
+	"time"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/token"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// AccessTokenBiz 定义了个人访问令牌的业务方法, 用户只能管理自己的访问令牌.
+type AccessTokenBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateAccessTokenRequest) (*apiv1.CreateAccessTokenResponse, error)
+	List(ctx context.Context, rq *apiv1.ListAccessTokenRequest) (*apiv1.ListAccessTokenResponse, error)
+	Revoke(ctx context.Context, rq *apiv1.RevokeAccessTokenRequest) (*apiv1.RevokeAccessTokenResponse, error)
+
+	AccessTokenExpansion
+}
+
+type AccessTokenExpansion interface{}
+
+type accessTokenBiz struct {
+	store store.IStore
+}
+
+var _ AccessTokenBiz = (*accessTokenBiz)(nil)
+
+func New(store store.IStore) *accessTokenBiz {
+	return &accessTokenBiz{store: store}
+}
+
+// 创建个人访问令牌, 数据库中只保存令牌的摘要, 令牌明文只在响应中返回一次.
+func (b *accessTokenBiz) Create(ctx context.Context, rq *apiv1.CreateAccessTokenRequest) (*apiv1.CreateAccessTokenResponse, error) {
+	// 个人访问令牌不能用于签发新的令牌, 否则可以绕过令牌的动作限制
+	if contextx.TokenActions(ctx) != nil {
+		return nil, errno.ErrPermissionDenied.WithMessage("access tokens cannot be created with an access token")
+	}
+
+	tokenStr, err := token.SignPersonal()
+	if err != nil {
+		return nil, errno.ErrSignToken
+	}
+
+	patM := model.AccessTokenM{
+		UserID:    contextx.UserID(ctx),
+		Name:      rq.GetName(),
+		TokenHash: token.HashPersonal(tokenStr),
+		Actions:   strings.Join(rq.GetActions(), ","),
+	}
+	if rq.ExpiresIn != nil {
+		expiresAt := time.Now().Add(time.Duration(rq.GetExpiresIn()) * time.Second)
+		patM.ExpiresAt = &expiresAt
+	}
+
+	if err := b.store.AccessToken().Create(ctx, &patM); err != nil {
+		log.W(ctx).Errorw("Failed to create access token", "err", err)
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.CreateAccessTokenResponse{
+		AccessToken: conversion.AccessTokenModelToAccessTokenV1(&patM),
+		Token:       tokenStr,
+	}, nil
+}
+
+// 列出当前用户的全部个人访问令牌.
+func (b *accessTokenBiz) List(ctx context.Context, rq *apiv1.ListAccessTokenRequest) (*apiv1.ListAccessTokenResponse, error) {
+	count, tokenList, err := b.store.AccessToken().List(ctx, where.T(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*apiv1.AccessToken, 0, len(tokenList))
+	for _, item := range tokenList {
+		tokens = append(tokens, conversion.AccessTokenModelToAccessTokenV1(item))
+	}
+
+	return &apiv1.ListAccessTokenResponse{TotalCount: count, AccessTokens: tokens}, nil
+}
+
+// 吊销当前用户的个人访问令牌, 吊销后令牌记录被删除, 令牌立即失效.
+func (b *accessTokenBiz) Revoke(ctx context.Context, rq *apiv1.RevokeAccessTokenRequest) (*apiv1.RevokeAccessTokenResponse, error) {
+	whr := where.T(ctx).F("tokenID", rq.GetTokenID())
+	if _, err := b.store.AccessToken().Get(ctx, whr); err != nil {
+		return nil, errno.ErrAccessTokenNotFound
+	}
+
+	if err := b.store.AccessToken().Delete(ctx, whr); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.RevokeAccessTokenResponse{}, nil
+}
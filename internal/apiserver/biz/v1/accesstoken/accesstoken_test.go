@@ -0,0 +1,115 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package accesstoken
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/token"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"k8s.io/utils/ptr"
+)
+
+// 创建基于SQLite内存数据库的个人访问令牌业务对象, 各个测试共享同一个数据库, 因此每个测试使用不同的用户.
+func newTestBiz(t *testing.T) (*accessTokenBiz, store.IStore) {
+	where.RegisterTenant("userID", contextx.UserID)
+
+	db, err := gorm.Open(sqlite.Open("file:biz_accesstoken_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+
+	s := store.NewStore(db)
+	require.NoError(t, s.DB(context.Background()).AutoMigrate(&model.AccessTokenM{}))
+
+	return New(s), s
+}
+
+// 创建一个个人访问令牌.
+func createTestToken(t *testing.T, b *accessTokenBiz, userID string, name string) *apiv1.CreateAccessTokenResponse {
+	resp, err := b.Create(contextx.WithUserID(context.Background(), userID), &apiv1.CreateAccessTokenRequest{
+		Name:    name,
+		Actions: []string{"post:list", "post:get"},
+	})
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestCreate(t *testing.T) {
+	b, s := newTestBiz(t)
+	ctx := contextx.WithUserID(context.Background(), "user-create")
+
+	resp, err := b.Create(ctx, &apiv1.CreateAccessTokenRequest{
+		Name:      "ci",
+		Actions:   []string{"post:list", "post:get"},
+		ExpiresIn: ptr.To(int64(3600)),
+	})
+	require.NoError(t, err)
+	assert.True(t, token.IsPersonal(resp.GetToken()))
+	assert.Equal(t, []string{"post:list", "post:get"}, resp.GetAccessToken().GetActions())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), resp.GetAccessToken().GetExpiresAt().AsTime(), time.Minute)
+
+	// 数据库中只保存令牌的摘要
+	patM, err := s.AccessToken().Get(context.Background(), where.F("tokenID", resp.GetAccessToken().GetTokenID()))
+	require.NoError(t, err)
+	assert.Equal(t, token.HashPersonal(resp.GetToken()), patM.TokenHash)
+	assert.Equal(t, "post:list,post:get", patM.Actions)
+
+	// 没有指定有效期时永不过期
+	resp = createTestToken(t, b, "user-create", "forever")
+	assert.Nil(t, resp.GetAccessToken().GetExpiresAt())
+
+	// 不能使用个人访问令牌创建新的令牌
+	_, err = b.Create(contextx.WithTokenActions(ctx, []string{"accesstoken:create"}), &apiv1.CreateAccessTokenRequest{Name: "nested"})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+}
+
+func TestList(t *testing.T) {
+	b, _ := newTestBiz(t)
+	first := createTestToken(t, b, "user-list", "first")
+	second := createTestToken(t, b, "user-list", "second")
+	createTestToken(t, b, "user-list-other", "other")
+
+	// 只能看到自己的令牌, 列表中不包含令牌明文
+	resp, err := b.List(contextx.WithUserID(context.Background(), "user-list"), &apiv1.ListAccessTokenRequest{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.GetTotalCount())
+
+	var tokenIDs []string
+	for _, accessToken := range resp.GetAccessTokens() {
+		tokenIDs = append(tokenIDs, accessToken.GetTokenID())
+	}
+	assert.ElementsMatch(t, []string{first.GetAccessToken().GetTokenID(), second.GetAccessToken().GetTokenID()}, tokenIDs)
+}
+
+func TestRevoke(t *testing.T) {
+	b, s := newTestBiz(t)
+	resp := createTestToken(t, b, "user-revoke", "ci")
+	tokenID := resp.GetAccessToken().GetTokenID()
+
+	// 不能吊销其他用户的令牌
+	_, err := b.Revoke(contextx.WithUserID(context.Background(), "user-revoke-other"), &apiv1.RevokeAccessTokenRequest{TokenID: tokenID})
+	assert.ErrorIs(t, err, errno.ErrAccessTokenNotFound)
+
+	ctx := contextx.WithUserID(context.Background(), "user-revoke")
+	_, err = b.Revoke(ctx, &apiv1.RevokeAccessTokenRequest{TokenID: tokenID})
+	require.NoError(t, err)
+	_, err = s.AccessToken().Get(context.Background(), where.F("tokenHash", token.HashPersonal(resp.GetToken())))
+	assert.Error(t, err)
+
+	_, err = b.Revoke(ctx, &apiv1.RevokeAccessTokenRequest{TokenID: tokenID})
+	assert.ErrorIs(t, err, errno.ErrAccessTokenNotFound)
+}
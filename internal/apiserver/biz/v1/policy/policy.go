@@ -0,0 +1,370 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package policy
+
+import (
+	"context"
+	"miniblog/internal/apiserver/pkg/permission"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	"strings"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// PolicyBiz 定义了授权策略和用户角色的管理方法, 只有管理员可以调用.
+// 所有修改都通过授权器完成, 修改后立即在本实例生效, 并通过授权器的watcher通知其他实例.
+// 策略和角色都属于某个租户, 管理其他租户(包括全部租户*)的策略需要在该租户中被allow策略显式允许, 例如全局管理员.
+type PolicyBiz interface {
+	ListPolicies(ctx context.Context, rq *apiv1.ListPoliciesRequest) (*apiv1.ListPoliciesResponse, error)
+	AddPolicy(ctx context.Context, rq *apiv1.AddPolicyRequest) (*apiv1.AddPolicyResponse, error)
+	RemovePolicy(ctx context.Context, rq *apiv1.RemovePolicyRequest) (*apiv1.RemovePolicyResponse, error)
+	ListGroupingPolicies(ctx context.Context, rq *apiv1.ListGroupingPoliciesRequest) (*apiv1.ListGroupingPoliciesResponse, error)
+	AddGroupingPolicy(ctx context.Context, rq *apiv1.AddGroupingPolicyRequest) (*apiv1.AddGroupingPolicyResponse, error)
+	RemoveGroupingPolicy(ctx context.Context, rq *apiv1.RemoveGroupingPolicyRequest) (*apiv1.RemoveGroupingPolicyResponse, error)
+	ListUserRoles(ctx context.Context, rq *apiv1.ListUserRolesRequest) (*apiv1.ListUserRolesResponse, error)
+	AssignRole(ctx context.Context, rq *apiv1.AssignRoleRequest) (*apiv1.AssignRoleResponse, error)
+	RevokeRole(ctx context.Context, rq *apiv1.RevokeRoleRequest) (*apiv1.RevokeRoleResponse, error)
+	ExplainAuthorization(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) (*apiv1.ExplainAuthorizationResponse, error)
+
+	PolicyExpansion
+}
+
+type PolicyExpansion interface{}
+
+type policyBiz struct {
+	store store.IStore
+	authz *auth.Authz
+}
+
+var _ PolicyBiz = (*policyBiz)(nil)
+
+func New(store store.IStore, authz *auth.Authz) *policyBiz {
+	return &policyBiz{store: store, authz: authz}
+}
+
+// ListPolicies 列出租户中的授权策略, subject为空时返回租户中的全部策略.
+func (b *policyBiz) ListPolicies(ctx context.Context, rq *apiv1.ListPoliciesRequest) (*apiv1.ListPoliciesResponse, error) {
+	domain := domainOrCurrent(ctx, rq.GetDomain())
+	if err := b.authorizeDomain(ctx, domain, "policy", "list"); err != nil {
+		return nil, err
+	}
+
+	rules, err := b.authz.GetFilteredPolicy(0, rq.GetSubject(), domain)
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	policies := make([]*apiv1.Policy, 0, len(rules))
+	for _, rule := range rules {
+		policies = append(policies, ruleToPolicy(rule))
+	}
+
+	return &apiv1.ListPoliciesResponse{TotalCount: int64(len(policies)), Policies: policies}, nil
+}
+
+// AddPolicy 添加一条授权策略, 策略需要符合授权模型的定义.
+func (b *policyBiz) AddPolicy(ctx context.Context, rq *apiv1.AddPolicyRequest) (*apiv1.AddPolicyResponse, error) {
+	rule := policyToRule(ctx, rq.GetPolicy())
+	if err := b.authz.ValidatePolicy(rule); err != nil {
+		return nil, errno.ErrPolicyInvalid.WithMessage("%s", err.Error())
+	}
+	if err := b.authorizeDomain(ctx, rule[1], "policy", "add"); err != nil {
+		return nil, err
+	}
+	// 授权时使用权限中的资源和动作, 按 URL 路径或 gRPC 方法编写的策略永远不会生效
+	if permission.IsLegacyRule(rule) {
+		return nil, errno.ErrPolicyInvalid.WithMessage("object must be the resource of a permission such as user, not a path or gRPC method")
+	}
+
+	// casbin添加已存在的策略时同样返回true, 需要先判断策略是否存在
+	exists, err := b.authz.HasPolicy(rule)
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if exists {
+		return nil, errno.ErrPolicyAlreadyExists
+	}
+
+	if _, err := b.authz.AddPolicy(rule); err != nil {
+		log.W(ctx).Errorw("Failed to add policy", "policy", rule, "err", err)
+		return nil, errno.ErrDBWrite
+	}
+
+	log.W(ctx).Infow("Policy has been added", "policy", rule)
+	return &apiv1.AddPolicyResponse{}, nil
+}
+
+// RemovePolicy 删除一条授权策略.
+func (b *policyBiz) RemovePolicy(ctx context.Context, rq *apiv1.RemovePolicyRequest) (*apiv1.RemovePolicyResponse, error) {
+	rule := policyToRule(ctx, rq.GetPolicy())
+	if err := b.authz.ValidatePolicy(rule); err != nil {
+		return nil, errno.ErrPolicyInvalid.WithMessage("%s", err.Error())
+	}
+	if err := b.authorizeDomain(ctx, rule[1], "policy", "remove"); err != nil {
+		return nil, err
+	}
+
+	removed, err := b.authz.RemovePolicy(rule)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove policy", "policy", rule, "err", err)
+		return nil, errno.ErrDBWrite
+	}
+	if !removed {
+		return nil, errno.ErrPolicyNotFound
+	}
+
+	log.W(ctx).Infow("Policy has been removed", "policy", rule)
+	return &apiv1.RemovePolicyResponse{}, nil
+}
+
+// ListGroupingPolicies 列出租户中的角色继承规则, 可以按主体和角色过滤.
+func (b *policyBiz) ListGroupingPolicies(ctx context.Context, rq *apiv1.ListGroupingPoliciesRequest) (*apiv1.ListGroupingPoliciesResponse, error) {
+	domain := domainOrCurrent(ctx, rq.GetDomain())
+	if err := b.authorizeDomain(ctx, domain, "grouping-policy", "list"); err != nil {
+		return nil, err
+	}
+
+	rules, err := b.authz.GetFilteredGroupingPolicy(0, rq.GetSubject(), rq.GetRole(), domain)
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	groupingPolicies := make([]*apiv1.GroupingPolicy, 0, len(rules))
+	for _, rule := range rules {
+		groupingPolicies = append(groupingPolicies, &apiv1.GroupingPolicy{Subject: rule[0], Role: rule[1], Domain: rule[2]})
+	}
+
+	return &apiv1.ListGroupingPoliciesResponse{TotalCount: int64(len(groupingPolicies)), GroupingPolicies: groupingPolicies}, nil
+}
+
+// AddGroupingPolicy 添加一条角色继承规则, 被继承的必须是角色.
+func (b *policyBiz) AddGroupingPolicy(ctx context.Context, rq *apiv1.AddGroupingPolicyRequest) (*apiv1.AddGroupingPolicyResponse, error) {
+	groupingPolicy := rq.GetGroupingPolicy()
+	domain := domainOrCurrent(ctx, groupingPolicy.GetDomain())
+	if err := b.authorizeDomain(ctx, domain, "grouping-policy", "add"); err != nil {
+		return nil, err
+	}
+
+	if err := b.addGroupingPolicy(ctx, groupingPolicy.GetSubject(), groupingPolicy.GetRole(), domain); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.AddGroupingPolicyResponse{}, nil
+}
+
+// RemoveGroupingPolicy 删除一条角色继承规则.
+func (b *policyBiz) RemoveGroupingPolicy(ctx context.Context, rq *apiv1.RemoveGroupingPolicyRequest) (*apiv1.RemoveGroupingPolicyResponse, error) {
+	groupingPolicy := rq.GetGroupingPolicy()
+	domain := domainOrCurrent(ctx, groupingPolicy.GetDomain())
+	if err := b.authorizeDomain(ctx, domain, "grouping-policy", "remove"); err != nil {
+		return nil, err
+	}
+
+	if err := b.removeGroupingPolicy(ctx, groupingPolicy.GetSubject(), groupingPolicy.GetRole(), domain); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.RemoveGroupingPolicyResponse{}, nil
+}
+
+// ListUserRoles 列出用户在所属租户中直接拥有的角色, 以及通过角色继承间接拥有的全部角色.
+func (b *policyBiz) ListUserRoles(ctx context.Context, rq *apiv1.ListUserRolesRequest) (*apiv1.ListUserRolesResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	roles, err := b.authz.GetRolesForUser(rq.GetUserID(), userM.TenantID)
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	implicitRoles, err := b.authz.GetImplicitRolesForUser(rq.GetUserID(), userM.TenantID)
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	return &apiv1.ListUserRolesResponse{Roles: roles, ImplicitRoles: implicitRoles}, nil
+}
+
+// AssignRole 在用户所属的租户中为用户分配角色, 用户必须存在.
+// 存储层只能查询到当前租户中的用户, 在全部租户*中被授权分配角色的全局管理员可以为其他租户中的用户分配角色.
+func (b *policyBiz) AssignRole(ctx context.Context, rq *apiv1.AssignRoleRequest) (*apiv1.AssignRoleResponse, error) {
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), auth.AllDomains, "user-role", "assign")
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if allowed {
+		ctx = store.WithoutTenant(ctx)
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	if err := b.addGroupingPolicy(ctx, rq.GetUserID(), rq.GetRole(), userM.TenantID); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.AssignRoleResponse{}, nil
+}
+
+// RevokeRole 收回用户在当前租户中的角色.
+func (b *policyBiz) RevokeRole(ctx context.Context, rq *apiv1.RevokeRoleRequest) (*apiv1.RevokeRoleResponse, error) {
+	if err := b.removeGroupingPolicy(ctx, rq.GetUserID(), rq.GetRole(), contextx.TenantID(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.RevokeRoleResponse{}, nil
+}
+
+// ExplainAuthorization 使用当前的策略检查主体在租户中能否对资源执行操作, 返回决定结果的策略和主体在该租户中拥有的角色.
+func (b *policyBiz) ExplainAuthorization(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) (*apiv1.ExplainAuthorizationResponse, error) {
+	domain := domainOrCurrent(ctx, rq.GetDomain())
+	if err := b.authorizeDomain(ctx, domain, "policy", "explain"); err != nil {
+		return nil, err
+	}
+
+	explanation, err := b.authz.Explain(rq.GetSubject(), domain, rq.GetObject(), rq.GetAction())
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	matchedPolicies := make([]*apiv1.Policy, 0, len(explanation.MatchedPolicies))
+	for _, rule := range explanation.MatchedPolicies {
+		matchedPolicies = append(matchedPolicies, ruleToPolicy(rule))
+	}
+
+	return &apiv1.ExplainAuthorizationResponse{
+		Allowed:         explanation.Allowed,
+		MatchedPolicies: matchedPolicies,
+		Roles:           explanation.Roles,
+	}, nil
+}
+
+// 校验并添加租户domain中的角色继承规则.
+func (b *policyBiz) addGroupingPolicy(ctx context.Context, subject string, role string, domain string) error {
+	rule := []string{subject, role, domain}
+	if err := b.authz.ValidateGroupingPolicy(rule); err != nil {
+		return errno.ErrPolicyInvalid.WithMessage("%s", err.Error())
+	}
+	if !strings.HasPrefix(role, known.RolePrefix) {
+		return errno.ErrPolicyInvalid.WithMessage("role must start with %q", known.RolePrefix)
+	}
+	// 角色继承自身会形成环, casbin在解析角色时不会报错, 但没有意义
+	if subject == role {
+		return errno.ErrPolicyInvalid.WithMessage("role cannot inherit from itself")
+	}
+
+	exists, err := b.authz.HasGroupingPolicy(rule)
+	if err != nil {
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if exists {
+		return errno.ErrPolicyAlreadyExists
+	}
+
+	if _, err := b.authz.AddGroupingPolicy(rule); err != nil {
+		log.W(ctx).Errorw("Failed to add grouping policy", "rule", rule, "err", err)
+		return errno.ErrAddRole.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("Grouping policy has been added", "rule", rule)
+	return nil
+}
+
+// 校验并删除租户domain中的角色继承规则, 管理员不能收回自己直接或间接拥有的管理员角色, 防止系统失去最后一个管理员.
+func (b *policyBiz) removeGroupingPolicy(ctx context.Context, subject string, role string, domain string) error {
+	rule := []string{subject, role, domain}
+	if err := b.authz.ValidateGroupingPolicy(rule); err != nil {
+		return errno.ErrPolicyInvalid.WithMessage("%s", err.Error())
+	}
+	if err := b.checkRevokeOwnAdmin(ctx, rule); err != nil {
+		return err
+	}
+
+	removed, err := b.authz.RemoveGroupingPolicy(rule)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove grouping policy", "rule", rule, "err", err)
+		return errno.ErrDBWrite
+	}
+	if !removed {
+		return errno.ErrPolicyNotFound
+	}
+
+	log.W(ctx).Infow("Grouping policy has been removed", "rule", rule)
+	return nil
+}
+
+// 判断删除角色继承规则后当前用户是否会失去管理员角色, 包括直接分配的和通过其他角色继承的管理员角色.
+// 删除的规则属于其他租户时, 同时检查当前用户在该租户中的管理员角色.
+func (b *policyBiz) checkRevokeOwnAdmin(ctx context.Context, rule []string) error {
+	userID := contextx.UserID(ctx)
+	for _, domain := range []string{contextx.TenantID(ctx), rule[2]} {
+		before, err := b.authz.HasRoleWithout(userID, known.RoleAdmin, domain, nil)
+		if err != nil {
+			return errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+		if !before {
+			continue
+		}
+
+		after, err := b.authz.HasRoleWithout(userID, known.RoleAdmin, domain, rule)
+		if err != nil {
+			return errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+		if !after {
+			return errno.ErrPermissionDenied.WithMessage("cannot revoke the admin role from yourself")
+		}
+	}
+
+	return nil
+}
+
+// 判断当前用户能否管理租户domain中的策略.
+// 当前用户所在租户中的策略已经通过了授权中间件的检查, 其他租户中的策略需要当前用户在该租户中被allow策略显式允许.
+func (b *policyBiz) authorizeDomain(ctx context.Context, domain string, obj string, act string) error {
+	if domain == contextx.TenantID(ctx) {
+		return nil
+	}
+
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), domain, obj, act)
+	if err != nil {
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` cannot %s %s in domain `%s`", contextx.UserID(ctx), act, obj, domain)
+	}
+
+	return nil
+}
+
+// 返回请求中指定的租户, 没有指定时为当前用户所在的租户.
+func domainOrCurrent(ctx context.Context, domain string) string {
+	if domain == "" {
+		return contextx.TenantID(ctx)
+	}
+	return domain
+}
+
+// 将策略转换为casbin规则, 字段顺序与授权模型中p的定义一致, 没有指定租户时使用当前用户所在的租户.
+func policyToRule(ctx context.Context, policy *apiv1.Policy) []string {
+	return []string{policy.GetSubject(), domainOrCurrent(ctx, policy.GetDomain()), policy.GetObject(), policy.GetAction(), policy.GetEffect()}
+}
+
+// 将casbin规则转换为策略.
+func ruleToPolicy(rule []string) *apiv1.Policy {
+	fields := make([]string, 5)
+	copy(fields, rule)
+	return &apiv1.Policy{Subject: fields[0], Domain: fields[1], Object: fields[2], Action: fields[3], Effect: fields[4]}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package policy
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 创建基于SQLite内存数据库的策略业务对象, store.NewStore只会初始化一次, 各个测试共享同一个数据库, 因此测试数据不能重复.
+// 与服务器相同, 按租户隔离数据, 管理员角色被允许执行所有操作.
+func newTestBiz(t *testing.T) (*policyBiz, store.IStore) {
+	store.RegisterTenant("tenantID", contextx.TenantID)
+
+	db, err := gorm.Open(sqlite.Open("file:biz_policy_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+
+	s := store.NewStore(db)
+	db = s.DB(context.Background())
+	require.NoError(t, db.AutoMigrate(&model.UserM{}, &model.CasbinRuleM{}))
+
+	authz, err := auth.NewAuthz(db)
+	require.NoError(t, err)
+	_, err = authz.AddPolicy(known.RoleAdmin, auth.AllDomains, "*", "*", "allow")
+	require.NoError(t, err)
+
+	return New(s, authz), s
+}
+
+// 返回用户在默认租户中发起请求的上下文.
+func userContext(userID string) context.Context {
+	ctx := contextx.WithUserID(context.Background(), userID)
+	return contextx.WithTenantID(ctx, known.DefaultTenant)
+}
+
+// 在默认租户中添加角色继承规则.
+func addTestGroupingPolicy(t *testing.T, b *policyBiz, subject string, role string) {
+	_, err := b.authz.AddGroupingPolicy(subject, role, known.DefaultTenant)
+	require.NoError(t, err)
+}
+
+// 在当前租户中删除角色继承规则.
+func removeTestGroupingPolicy(ctx context.Context, b *policyBiz, subject string, role string) error {
+	_, err := b.RemoveGroupingPolicy(ctx, &apiv1.RemoveGroupingPolicyRequest{
+		GroupingPolicy: &apiv1.GroupingPolicy{Subject: subject, Role: role},
+	})
+	return err
+}
+
+func TestAddGroupingPolicy(t *testing.T) {
+	b, _ := newTestBiz(t)
+	ctx := userContext("user-policy-add")
+	add := func(subject string, role string) error {
+		_, err := b.AddGroupingPolicy(ctx, &apiv1.AddGroupingPolicyRequest{
+			GroupingPolicy: &apiv1.GroupingPolicy{Subject: subject, Role: role},
+		})
+		return err
+	}
+
+	// 被继承的必须是角色, 不能继承用户
+	assert.ErrorIs(t, add("user-policy-add", "user-policy-other"), errno.ErrPolicyInvalid)
+	assert.ErrorIs(t, add("role::policy-add", "admin"), errno.ErrPolicyInvalid)
+
+	// 角色不能继承自身
+	assert.ErrorIs(t, add("role::policy-add", "role::policy-add"), errno.ErrPolicyInvalid)
+
+	require.NoError(t, add("role::policy-add", known.RoleUser))
+	assert.ErrorIs(t, add("role::policy-add", known.RoleUser), errno.ErrPolicyAlreadyExists)
+}
+
+func TestRemoveOwnAdmin(t *testing.T) {
+	b, _ := newTestBiz(t)
+
+	// 不能收回自己直接拥有的管理员角色, 可以收回其他管理员的
+	addTestGroupingPolicy(t, b, "user-direct-admin", known.RoleAdmin)
+	addTestGroupingPolicy(t, b, "user-other-admin", known.RoleAdmin)
+	ctx := userContext("user-direct-admin")
+	assert.ErrorIs(t, removeTestGroupingPolicy(ctx, b, "user-direct-admin", known.RoleAdmin), errno.ErrPermissionDenied)
+	_, err := b.RevokeRole(ctx, &apiv1.RevokeRoleRequest{UserID: "user-direct-admin", Role: known.RoleAdmin})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	assert.NoError(t, removeTestGroupingPolicy(ctx, b, "user-other-admin", known.RoleAdmin))
+
+	// 通过其他角色继承的管理员角色同样不能收回, 无论删除的是继承链上的哪一条规则
+	addTestGroupingPolicy(t, b, "user-inherited-admin", "role::policy-ops")
+	addTestGroupingPolicy(t, b, "role::policy-ops", known.RoleAdmin)
+	ctx = userContext("user-inherited-admin")
+	assert.ErrorIs(t, removeTestGroupingPolicy(ctx, b, "user-inherited-admin", "role::policy-ops"), errno.ErrPermissionDenied)
+	assert.ErrorIs(t, removeTestGroupingPolicy(ctx, b, "role::policy-ops", known.RoleAdmin), errno.ErrPermissionDenied)
+
+	// 仍然通过其他规则拥有管理员角色时可以删除
+	addTestGroupingPolicy(t, b, "user-inherited-admin", known.RoleAdmin)
+	assert.NoError(t, removeTestGroupingPolicy(ctx, b, "user-inherited-admin", "role::policy-ops"))
+}
+
+// 在租户中创建一个用户.
+func createTestUser(t *testing.T, s store.IStore, username string, tenantID string) string {
+	userM := &model.UserM{Username: username, Password: "miniblog1234", Email: username + "@miniblog.test", TenantID: tenantID}
+	require.NoError(t, s.User().Create(context.Background(), userM))
+
+	return userM.UserID
+}
+
+func TestAssignRoleCrossTenant(t *testing.T) {
+	b, s := newTestBiz(t)
+	userID := createTestUser(t, s, "assign_target", "globex")
+	globalAdmin := createTestUser(t, s, "assign_global", known.DefaultTenant)
+	_, err := b.authz.AddGroupingPolicy(globalAdmin, known.RoleAdmin, auth.AllDomains)
+	require.NoError(t, err)
+	tenantAdmin := createTestUser(t, s, "assign_tenant", known.DefaultTenant)
+	addTestGroupingPolicy(t, b, tenantAdmin, known.RoleAdmin)
+
+	// 只在当前租户中拥有管理员角色的租户管理员查询不到其他租户中的用户
+	rq := &apiv1.AssignRoleRequest{UserID: userID, Role: known.RoleUser}
+	_, err = b.AssignRole(userContext(tenantAdmin), rq)
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+
+	// 全局管理员可以为其他租户中的用户分配角色, 角色属于用户所在的租户
+	_, err = b.AssignRole(userContext(globalAdmin), rq)
+	require.NoError(t, err)
+	has, err := b.authz.HasGroupingPolicy(userID, known.RoleUser, "globex")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
@@ -7,15 +7,19 @@ package post
 
 import (
 	"context"
+	"errors"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/apiserver/pkg/conversion"
 	"miniblog/internal/apiserver/store"
 	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
 
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
 
 	"github.com/jinzhu/copier"
 	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
 )
 
 type PostBiz interface {
@@ -28,23 +32,46 @@ type PostBiz interface {
 	PostExpansion
 }
 
-type PostExpansion interface{}
+// 扩展接口实现了恢复已删除的博客.
+type PostExpansion interface {
+	Restore(ctx context.Context, rq *apiv1.RestorePostRequest) (*apiv1.RestorePostResponse, error)
+}
 
 type postBiz struct {
 	store store.IStore
+	authz *auth.Authz
+	opts  *Options
+}
+
+// Options 定义了博客业务的配置.
+type Options struct {
+	// 是否只允许电子邮箱已验证的用户创建博客
+	RequireVerifiedEmail bool
 }
 
 var _ PostBiz = (*postBiz)(nil)
 
-func New(store store.IStore) *postBiz {
-	return &postBiz{store: store}
+func New(store store.IStore, authz *auth.Authz, opts *Options) *postBiz {
+	return &postBiz{store: store, authz: authz, opts: opts}
 }
 
 func (b *postBiz) Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*apiv1.CreatePostResponse, error) {
+	if b.opts.RequireVerifiedEmail {
+		userM, err := b.store.User().Get(ctx, where.F("userID", contextx.UserID(ctx)))
+		if err != nil {
+			return nil, errno.ErrUserNotFound
+		}
+		if !userM.EmailVerified {
+			return nil, errno.ErrEmailNotVerified
+		}
+	}
+
 	var postM model.PostM
 	_ = copier.Copy(&postM, rq)
 
 	postM.UserID = contextx.UserID(ctx)
+	// 博客属于作者所在的租户
+	postM.TenantID = contextx.TenantID(ctx)
 
 	if err := b.store.Post().Create(ctx, &postM); err != nil {
 		return nil, err
@@ -55,13 +82,16 @@ func (b *postBiz) Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*api
 
 func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error) {
 	// 1. 构建查询条件
-	whr := where.T(ctx).F("postID", rq.GetPostID())
+	whr := where.F("postID", rq.GetPostID())
 
 	// 2. 调用store层的postModel的Get方法, 传入查询条件获取对应的postM结构体
 	postM, err := b.store.Post().Get(ctx, whr)
 	if err != nil {
 		return nil, err
 	}
+	if err := b.authorizePost(ctx, postM, "update"); err != nil {
+		return nil, err
+	}
 
 	if rq.Title != nil {
 		postM.Title = rq.GetTitle()
@@ -80,8 +110,18 @@ func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*api
 }
 
 func (b *postBiz) Delete(ctx context.Context, rq *apiv1.DeletePostRequest) (*apiv1.DeletePostResponse, error) {
-	whr := where.T(ctx).F("postID", rq.GetPostIDs())
+	// 每篇博客都需要有删除权限, 任意一篇没有权限时都不删除
+	_, postList, err := b.store.Post().List(ctx, where.F("postID", rq.GetPostIDs()))
+	if err != nil {
+		return nil, err
+	}
+	for _, postM := range postList {
+		if err := b.authorizePost(ctx, postM, "delete"); err != nil {
+			return nil, err
+		}
+	}
 
+	whr := where.F("postID", rq.GetPostIDs())
 	if err := b.store.Post().Delete(ctx, whr); err != nil {
 		return nil, err
 	}
@@ -89,19 +129,61 @@ func (b *postBiz) Delete(ctx context.Context, rq *apiv1.DeletePostRequest) (*api
 	return &apiv1.DeletePostResponse{}, nil
 }
 
+// Restore 恢复已删除的博客, 只有被授权管理所有博客的管理员可以恢复, 全局管理员还可以恢复其他租户中的博客.
+// 作者被删除时博客不能单独恢复, 需要先恢复作者.
+func (b *postBiz) Restore(ctx context.Context, rq *apiv1.RestorePostRequest) (*apiv1.RestorePostResponse, error) {
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), contextx.TenantID(ctx), "post", "restore")
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return nil, errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` cannot restore post `%s`", contextx.UserID(ctx), rq.GetPostID())
+	}
+
+	// 与恢复用户相同, 在全部租户*中被授权恢复博客的全局管理员可以恢复其他租户中的博客
+	allowed, err = b.authz.AuthorizeAny(contextx.UserID(ctx), auth.AllDomains, "post", "restore")
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if allowed {
+		ctx = store.WithoutTenant(ctx)
+	}
+
+	if err := b.store.Post().Restore(ctx, rq.GetPostID()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.RestorePostResponse{}, nil
+}
+
 func (b *postBiz) Get(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error) {
-	whr := where.T(ctx).F("postID", rq.GetPostID())
+	whr := where.F("postID", rq.GetPostID())
 
 	postM, err := b.store.Post().Get(ctx, whr)
 	if err != nil {
 		return nil, err
 	}
+	if err := b.authorizePost(ctx, postM, "get"); err != nil {
+		return nil, err
+	}
 
 	return &apiv1.GetPostResponse{Post: conversion.PostModelToPostV1(postM)}, nil
 }
 
 func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error) {
-	whr := where.T(ctx).P(int(rq.GetOffset()), int(rq.GetLimit()))
+	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
+
+	// 普通用户只能查询到自己的博客, 被授权管理所有博客的管理员可以查询全部博客
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), contextx.TenantID(ctx), "post", "list")
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		whr.T(ctx)
+	}
 
 	count, postList, err := b.store.Post().List(ctx, whr)
 	if err != nil {
@@ -116,3 +198,16 @@ func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.L
 
 	return &apiv1.ListPostResponse{TotalCount: count, Posts: posts}, nil
 }
+
+// 判断当前用户能否对博客执行操作, 只有博客作者和被授权管理当前租户所有博客的管理员可以操作.
+func (b *postBiz) authorizePost(ctx context.Context, postM *model.PostM, act string) error {
+	allowed, err := b.authz.AuthorizeOwner(contextx.UserID(ctx), contextx.TenantID(ctx), postM.UserID, "post", act)
+	if err != nil {
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` cannot %s post `%s`", contextx.UserID(ctx), act, postM.PostID)
+	}
+
+	return nil
+}
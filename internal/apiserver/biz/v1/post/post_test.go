@@ -0,0 +1,134 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package post
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
+	"testing"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"k8s.io/utils/ptr"
+)
+
+// 创建基于SQLite内存数据库的博客业务对象, store.NewStore只会初始化一次, 各个测试共享同一个数据库, 因此测试数据不能重复.
+// 与服务器相同, 按用户和租户隔离数据, 管理员角色被允许执行所有操作.
+func newTestBiz(t *testing.T, opts *Options) (*postBiz, store.IStore) {
+	where.RegisterTenant("userID", contextx.UserID)
+	store.RegisterTenant("tenantID", contextx.TenantID)
+
+	db, err := gorm.Open(sqlite.Open("file:biz_post_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+
+	s := store.NewStore(db)
+	db = s.DB(context.Background())
+	require.NoError(t, db.AutoMigrate(&model.UserM{}, &model.PostM{}, &model.CasbinRuleM{}))
+
+	authz, err := auth.NewAuthz(db)
+	require.NoError(t, err)
+	_, err = authz.AddPolicy(known.RoleAdmin, auth.AllDomains, "*", "*", "allow")
+	require.NoError(t, err)
+
+	return New(s, authz, opts), s
+}
+
+// 返回用户在默认租户中发起请求的上下文.
+func userContext(userID string) context.Context {
+	ctx := contextx.WithUserID(context.Background(), userID)
+	return contextx.WithTenantID(ctx, known.DefaultTenant)
+}
+
+// 在数据库中创建一个用户, 没有指定租户时属于默认租户.
+func createTestUser(t *testing.T, s store.IStore, userM *model.UserM) string {
+	userM.Password = "miniblog1234"
+	userM.Email = userM.Username + "@miniblog.test"
+	if userM.TenantID == "" {
+		userM.TenantID = known.DefaultTenant
+	}
+	require.NoError(t, s.User().Create(context.Background(), userM))
+
+	return userM.UserID
+}
+
+func TestCreateRequireVerifiedEmail(t *testing.T) {
+	b, s := newTestBiz(t, &Options{RequireVerifiedEmail: true})
+	unverified := createTestUser(t, s, &model.UserM{Username: "unverified"})
+	verified := createTestUser(t, s, &model.UserM{Username: "verified", EmailVerified: true})
+
+	_, err := b.Create(userContext(unverified), &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+	assert.ErrorIs(t, err, errno.ErrEmailNotVerified)
+
+	resp, err := b.Create(userContext(verified), &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetPostID())
+
+	// 关闭校验时未验证邮箱的用户同样可以创建博客
+	b.opts = &Options{}
+	_, err = b.Create(userContext(unverified), &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+	assert.NoError(t, err)
+}
+
+func TestNonOwnerPermissionDenied(t *testing.T) {
+	b, s := newTestBiz(t, &Options{})
+	owner := createTestUser(t, s, &model.UserM{Username: "post_owner"})
+	other := createTestUser(t, s, &model.UserM{Username: "post_other"})
+	postM := &model.PostM{UserID: owner, TenantID: known.DefaultTenant, Title: "title", Content: "content"}
+	require.NoError(t, s.Post().Create(context.Background(), postM))
+
+	// 其他用户不能查看, 修改和删除不属于自己的博客
+	ctx := userContext(other)
+	_, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: postM.PostID})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	_, err = b.Update(ctx, &apiv1.UpdatePostRequest{PostID: postM.PostID, Title: ptr.To("changed")})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	_, err = b.Delete(ctx, &apiv1.DeletePostRequest{PostIDs: []string{postM.PostID}})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+
+	// 博客没有被修改或删除, 作者本人可以正常操作
+	ctx = userContext(owner)
+	resp, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: postM.PostID})
+	require.NoError(t, err)
+	assert.Equal(t, "title", resp.GetPost().GetTitle())
+	_, err = b.Update(ctx, &apiv1.UpdatePostRequest{PostID: postM.PostID, Title: ptr.To("changed")})
+	assert.NoError(t, err)
+	_, err = b.Delete(ctx, &apiv1.DeletePostRequest{PostIDs: []string{postM.PostID}})
+	assert.NoError(t, err)
+}
+
+func TestRestoreCrossTenant(t *testing.T) {
+	b, s := newTestBiz(t, &Options{})
+	owner := createTestUser(t, s, &model.UserM{Username: "restore_owner", TenantID: "globex"})
+	globalAdmin := createTestUser(t, s, &model.UserM{Username: "restore_global"})
+	_, err := b.authz.AddGroupingPolicy(globalAdmin, known.RoleAdmin, auth.AllDomains)
+	require.NoError(t, err)
+	tenantAdmin := createTestUser(t, s, &model.UserM{Username: "restore_tenant"})
+	_, err = b.authz.AddGroupingPolicy(tenantAdmin, known.RoleAdmin, known.DefaultTenant)
+	require.NoError(t, err)
+
+	postM := &model.PostM{UserID: owner, TenantID: "globex", Title: "title", Content: "content"}
+	require.NoError(t, s.Post().Create(context.Background(), postM))
+	require.NoError(t, s.Post().Delete(context.Background(), where.F("postID", postM.PostID)))
+
+	// 只在当前租户中拥有管理员角色的租户管理员不能恢复其他租户中的博客
+	_, err = b.Restore(userContext(tenantAdmin), &apiv1.RestorePostRequest{PostID: postM.PostID})
+	assert.ErrorIs(t, err, errno.ErrPostNotFound)
+
+	// 全局管理员可以恢复其他租户中的博客
+	_, err = b.Restore(userContext(globalAdmin), &apiv1.RestorePostRequest{PostID: postM.PostID})
+	require.NoError(t, err)
+	_, err = s.Post().Get(context.Background(), where.F("postID", postM.PostID))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"io"
+	"miniblog/internal/apiserver/pkg/conversion"
+	"miniblog/internal/apiserver/pkg/export"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExportMyData 导出当前用户的个人资料, 博客, 角色和登录会话, 归档直接写入w.
+func (b *userBiz) ExportMyData(ctx context.Context, rq *apiv1.ExportMyDataRequest, w io.Writer) error {
+	return b.exportData(ctx, contextx.UserID(ctx), rq.GetFormat(), w)
+}
+
+// ExportUserData 导出指定用户的个人数据, 供管理员处理用户的数据导出申请.
+// 全局管理员可以导出其他租户中的用户.
+func (b *userBiz) ExportUserData(ctx context.Context, rq *apiv1.ExportUserDataRequest, w io.Writer) error {
+	ctx, err := b.tenantContext(ctx, "user-data-export", "export")
+	if err != nil {
+		return err
+	}
+
+	if err := b.exportData(ctx, rq.GetUserID(), rq.GetFormat(), w); err != nil {
+		return err
+	}
+
+	log.W(ctx).Infow("User data has been exported", "targetUserID", rq.GetUserID())
+	return nil
+}
+
+// 收集用户的个人数据并按format将归档写入w, 未指定格式时导出为JSON.
+// 数据全部查询完成后才开始写入, 查询失败时w中没有任何数据, 调用方仍然可以返回错误响应.
+func (b *userBiz) exportData(ctx context.Context, userID string, format string, w io.Writer) error {
+	if format == "" {
+		format = known.DataExportFormatJSON
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return errno.ErrUserNotFound
+	}
+
+	_, postList, err := b.store.Post().List(ctx, where.F("userID", userID))
+	if err != nil {
+		return errno.ErrDBRead
+	}
+	posts := make([]*apiv1.Post, 0, len(postList))
+	for _, item := range postList {
+		posts = append(posts, conversion.PostModelToPostV1(item))
+	}
+
+	rules, err := b.authz.GetFilteredGroupingPolicy(0, userID)
+	if err != nil {
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	roles := make([]*apiv1.RoleAssignment, 0, len(rules))
+	for _, rule := range rules {
+		roles = append(roles, &apiv1.RoleAssignment{Role: rule[1], Domain: rule[2]})
+	}
+
+	// 已吊销和已过期的会话同样属于用户的个人数据, 一并导出
+	_, sessionList, err := b.store.Session().List(ctx, where.F("userID", userID))
+	if err != nil {
+		return errno.ErrDBRead
+	}
+	sessions := make([]*apiv1.Session, 0, len(sessionList))
+	for _, item := range sessionList {
+		sessions = append(sessions, conversion.SessionModelToSessionV1(item))
+	}
+
+	user := conversion.UserModelToUserV1(userM)
+	user.PostCount = int64(len(posts))
+
+	data := &apiv1.DataExport{
+		Version:    export.Version,
+		ExportedAt: timestamppb.Now(),
+		User:       user,
+		Posts:      posts,
+		Roles:      roles,
+		Sessions:   sessions,
+	}
+
+	if err := export.Write(w, format, data); err != nil {
+		log.W(ctx).Errorw("Failed to write data export", "user", userID, "format", format, "err", err)
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	return nil
+}
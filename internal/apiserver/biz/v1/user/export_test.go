@@ -0,0 +1,64 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/utils/ptr"
+)
+
+func TestExportMyData(t *testing.T) {
+	b, s := newTestBiz(t)
+	username, userID := createNamedTestUser(t, b, "exporter", known.DefaultTenant)
+	createTestPosts(t, s, userID, 2)
+	_, err := b.Login(context.Background(), &apiv1.LoginRequest{Username: username, Password: testPassword})
+	require.NoError(t, err)
+	ctx := userContext(userID, known.DefaultTenant)
+
+	// 未指定格式时导出为JSON, 包含用户资料, 博客, 角色和登录会话
+	var buf bytes.Buffer
+	require.NoError(t, b.ExportMyData(ctx, &apiv1.ExportMyDataRequest{}, &buf))
+	var data apiv1.DataExport
+	require.NoError(t, protojson.Unmarshal(buf.Bytes(), &data))
+	assert.Equal(t, userID, data.GetUser().GetUserID())
+	assert.EqualValues(t, 2, data.GetUser().GetPostCount())
+	assert.Len(t, data.GetPosts(), 2)
+	require.Len(t, data.GetRoles(), 1)
+	assert.Equal(t, known.RoleUser, data.GetRoles()[0].GetRole())
+	assert.Equal(t, known.DefaultTenant, data.GetRoles()[0].GetDomain())
+	assert.Len(t, data.GetSessions(), 1)
+
+	// zip归档中每类数据一个文件
+	buf.Reset()
+	require.NoError(t, b.ExportMyData(ctx, &apiv1.ExportMyDataRequest{Format: ptr.To(known.DataExportFormatZIP)}, &buf))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"manifest.json", "profile.json", "posts.json", "roles.json", "sessions.json"}, names)
+}
+
+func TestExportUserDataNotFound(t *testing.T) {
+	b, _ := newTestBiz(t)
+
+	// 查询失败时不会写入任何数据, 调用方仍然可以返回错误响应
+	var buf bytes.Buffer
+	err := b.ExportUserData(adminContext(), &apiv1.ExportUserDataRequest{UserID: "user-unknown"}, &buf)
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	assert.Zero(t, buf.Len())
+}
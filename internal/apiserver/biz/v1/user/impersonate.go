@@ -0,0 +1,43 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/token"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Impersonate 为管理员签发以指定用户身份访问的短期令牌, 令牌的act声明中记录了实际操作的管理员.
+// 模拟登录的令牌不属于任何会话, 也不能刷新, 过期后需要重新申请.
+func (b *userBiz) Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error) {
+	// 不允许在模拟登录的会话中再次模拟其他用户, 保证act声明中总是真实的管理员
+	if contextx.Impersonated(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+	if rq.GetUserID() == contextx.UserID(ctx) {
+		return nil, errno.ErrInvalidArgument.WithMessage("cannot impersonate yourself")
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	tokenStr, expireAt, err := token.SignImpersonation(userM.UserID, userM.TenantID, contextx.UserID(ctx))
+	if err != nil {
+		return nil, errno.ErrSignToken
+	}
+
+	log.W(ctx).Infow("Started impersonating user", "target", userM.UserID, "expireAt", expireAt)
+	return &apiv1.ImpersonateResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
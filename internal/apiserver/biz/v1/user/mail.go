@@ -0,0 +1,66 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/mail"
+	"net/url"
+	"time"
+)
+
+// 邮件令牌包含的随机字节数.
+const mailTokenBytes = 32
+
+// 发送邮件的超时时间.
+const sendMailTimeout = 30 * time.Second
+
+// 生成一个不透明的随机邮件令牌, 用于密码重置和邮箱验证链接.
+func newMailToken() (string, error) {
+	buf := make([]byte, mailTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// 计算邮件令牌的SHA-256摘要, 服务端只保存摘要.
+func hashMailToken(mailToken string) string {
+	sum := sha256.Sum256([]byte(mailToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// 将邮件令牌作为token查询参数附加到页面地址后.
+func mailLink(baseURL string, mailToken string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL + "?token=" + url.QueryEscape(mailToken)
+	}
+
+	query := u.Query()
+	query.Set("token", mailToken)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// 在后台发送邮件, 发送失败只记录日志.
+// 邮件发送耗时较长且结果不影响请求本身, 后台发送同时避免通过响应耗时推断邮箱是否存在.
+func (b *userBiz) sendMail(ctx context.Context, msg *mail.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sendMailTimeout)
+		defer cancel()
+
+		if err := b.opts.Mailer.Send(ctx, msg); err != nil {
+			log.W(ctx).Errorw("Failed to send email", "subject", msg.Subject, "err", err)
+		}
+	}()
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/oidc"
+	"regexp"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const (
+	// 用户名的最小和最大长度, 与用户名校验规则保持一致.
+	minUsernameLength = 3
+	maxUsernameLength = 20
+	// 用户昵称的最大长度.
+	maxNicknameLength = 29
+	// 手机号的最大长度.
+	maxPhoneLength = 16
+)
+
+// 用户名中不允许出现的字符, 外部用户名中的这些字符会被替换为下划线.
+var invalidUsernameChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// StartOIDCLogin 为指定的身份提供方创建一次授权请求, 返回身份提供方的授权地址.
+// state, nonce和PKCE校验码只在服务端保存, 回调时用于校验授权结果.
+func (b *userBiz) StartOIDCLogin(ctx context.Context, rq *apiv1.StartOIDCLoginRequest) (*apiv1.StartOIDCLoginResponse, error) {
+	provider, ok := b.opts.OIDCProviders[rq.GetProvider()]
+	if !ok {
+		return nil, errno.ErrOIDCProviderNotFound
+	}
+
+	state, err := newMailToken()
+	if err != nil {
+		return nil, errno.ErrInternal
+	}
+	nonce, err := newMailToken()
+	if err != nil {
+		return nil, errno.ErrInternal
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, errno.ErrInternal
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to build oidc authorization url", "provider", rq.GetProvider(), "err", err)
+		return nil, errno.ErrOIDCLoginFailed
+	}
+
+	expiresAt := time.Now().Add(b.opts.OIDCLoginExpiration)
+	loginM := &model.OIDCLoginM{
+		Provider:     rq.GetProvider(),
+		StateHash:    hashMailToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    expiresAt,
+	}
+	if err := b.store.OIDCLogin().Create(ctx, loginM); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.StartOIDCLoginResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+		ExpireAt:         timestamppb.New(expiresAt),
+	}, nil
+}
+
+// OIDCCallback 使用授权码向身份提供方换取并校验ID Token, 然后为外部用户签发令牌.
+// 外部用户首次登录时会自动创建用户, 开启了两步验证的用户同样需要完成两步验证.
+func (b *userBiz) OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.LoginResponse, error) {
+	stateHash := hashMailToken(rq.GetState())
+	loginM, err := b.store.OIDCLogin().Get(ctx, where.F("stateHash", stateHash))
+	if err != nil {
+		return nil, errno.ErrOIDCStateInvalid
+	}
+
+	provider, ok := b.opts.OIDCProviders[loginM.Provider]
+	if !ok {
+		return nil, errno.ErrOIDCProviderNotFound
+	}
+
+	// 先将授权请求标记为已使用, 同一个state只能完成一次登录
+	used, err := b.store.OIDCLogin().MarkUsed(ctx, stateHash, time.Now())
+	if err != nil {
+		return nil, errno.ErrDBWrite
+	}
+	if !used {
+		return nil, errno.ErrOIDCStateInvalid
+	}
+
+	identity, err := provider.Authenticate(ctx, rq.GetCode(), loginM.CodeVerifier, loginM.Nonce)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to authenticate with oidc provider", "provider", loginM.Provider, "err", err)
+		return nil, errno.ErrOIDCLoginFailed
+	}
+
+	userM, err := b.findOrCreateOIDCUser(ctx, loginM.Provider, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := b.totpEnabled(ctx, userM.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return b.issueChallenge(ctx, userM.UserID)
+	}
+
+	log.W(ctx).Infow("User logged in with oidc provider", "user", userM.UserID, "provider", loginM.Provider)
+	return b.issueTokens(ctx, userM)
+}
+
+// 查找外部用户关联的用户, 外部用户首次登录时创建用户并建立关联.
+func (b *userBiz) findOrCreateOIDCUser(ctx context.Context, provider string, identity *oidc.Identity) (*model.UserM, error) {
+	identityM, err := b.store.UserIdentity().Get(ctx, where.F("provider", provider, "subject", identity.Subject))
+	if err == nil {
+		userM, err := b.store.User().Get(ctx, where.F("userID", identityM.UserID))
+		if err != nil {
+			return nil, errno.ErrUserNotFound
+		}
+		return userM, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBRead
+	}
+
+	// 外部用户只能通过身份提供方登录, 随机密码只用于满足用户表的约束
+	password, err := newMailToken()
+	if err != nil {
+		return nil, errno.ErrInternal
+	}
+
+	// 外部用户属于默认租户
+	userM := &model.UserM{
+		TenantID:      known.DefaultTenant,
+		Username:      b.oidcUsername(ctx, provider, identity),
+		Password:      password,
+		Nickname:      truncateRunes(identity.Nickname, maxNicknameLength),
+		Email:         identity.Email,
+		Phone:         b.oidcPhone(ctx, identity),
+		EmailVerified: identity.EmailVerified && identity.Email != "",
+	}
+	if userM.EmailVerified {
+		now := time.Now()
+		userM.VerifiedAt = &now
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.User().Create(ctx, userM); err != nil {
+			return err
+		}
+		return b.store.UserIdentity().Create(ctx, &model.UserIdentityM{
+			UserID:   userM.UserID,
+			Provider: provider,
+			Subject:  identity.Subject,
+		})
+	})
+	if err != nil {
+		log.W(ctx).Errorw("Failed to create user for oidc identity", "provider", provider, "subject", identity.Subject, "err", err)
+		return nil, errno.ErrDBWrite
+	}
+
+	// 给用户添加普通用户role::user角色
+	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser, userM.TenantID); err != nil {
+		log.W(ctx).Errorw("Failed to add grouping policy for user", "user", userM.UserID, "role", known.RoleUser)
+		return nil, errno.ErrAddRole.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("Created user for oidc identity", "user", userM.UserID, "provider", provider)
+	return userM, nil
+}
+
+// 根据外部用户名生成一个合法且未被占用的用户名.
+// 外部用户名不可用时, 使用身份提供方名称和外部用户标识的摘要生成用户名.
+func (b *userBiz) oidcUsername(ctx context.Context, provider string, identity *oidc.Identity) string {
+	username := invalidUsernameChars.ReplaceAllString(identity.Username, "_")
+	if len(username) > maxUsernameLength {
+		username = username[:maxUsernameLength]
+	}
+	if len(username) >= minUsernameLength {
+		if _, err := b.store.User().Get(ctx, where.F("username", username)); errors.Is(err, gorm.ErrRecordNotFound) {
+			return username
+		}
+	}
+
+	sum := sha256.Sum256([]byte(provider + "\x00" + identity.Subject))
+	return "oidc_" + hex.EncodeToString(sum[:])[:maxUsernameLength-len("oidc_")]
+}
+
+// 外部用户的手机号为空, 过长或者已被其他用户使用时, 创建的用户不设置手机号.
+func (b *userBiz) oidcPhone(ctx context.Context, identity *oidc.Identity) *string {
+	if identity.Phone == "" || len(identity.Phone) > maxPhoneLength {
+		return nil
+	}
+	if _, err := b.store.User().Get(ctx, where.F("phone", identity.Phone)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+
+	return &identity.Phone
+}
+
+// 截断字符串, 最多保留n个字符.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/oidc"
+	"miniblog/pkg/oidc/oidctest"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 测试中使用的身份提供方名称.
+const testOIDCProvider = "oidctest"
+
+// 启动进程内的身份提供方, 并注册到业务对象的配置中.
+func newTestOIDCServer(t *testing.T, b *userBiz) *oidctest.Server {
+	server := oidctest.NewServer("miniblog", "secret")
+	t.Cleanup(server.Close)
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Issuer:       server.URL,
+		ClientID:     server.ClientID,
+		ClientSecret: server.ClientSecret,
+		RedirectURL:  "http://127.0.0.1:5555/oidc/callback",
+	})
+	require.NoError(t, err)
+	b.opts.OIDCProviders = map[string]*oidc.Provider{testOIDCProvider: provider}
+	b.opts.OIDCLoginExpiration = time.Hour
+
+	return server
+}
+
+// 发起授权请求, 并按浏览器的行为访问授权地址, 返回身份提供方重定向回来的授权码和state.
+func authorizeTestOIDC(t *testing.T, b *userBiz) (string, string) {
+	start, err := b.StartOIDCLogin(context.Background(), &apiv1.StartOIDCLoginRequest{Provider: testOIDCProvider})
+	require.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(start.GetAuthorizationURL())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, start.GetState(), location.Query().Get("state"))
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+// 以身份提供方当前设置的用户完成一次登录.
+func oidcTestLogin(t *testing.T, b *userBiz) (*apiv1.LoginResponse, error) {
+	code, state := authorizeTestOIDC(t, b)
+	return b.OIDCCallback(context.Background(), &apiv1.OIDCCallbackRequest{Code: code, State: state})
+}
+
+// 返回外部用户关联的用户.
+func getTestOIDCUser(t *testing.T, b *userBiz, subject string) *model.UserM {
+	identityM, err := b.store.UserIdentity().Get(context.Background(), where.F("provider", testOIDCProvider, "subject", subject))
+	require.NoError(t, err)
+
+	return getTestUser(t, b.store, identityM.UserID)
+}
+
+func TestOIDCCallback(t *testing.T) {
+	b, s := newTestBiz(t)
+	server := newTestOIDCServer(t, b)
+	seq := testUserSeq.Add(1)
+	subject := fmt.Sprintf("subject-%d", seq)
+	server.SetClaims(map[string]any{
+		"sub":                subject,
+		"preferred_username": fmt.Sprintf("oidc.user%d", seq),
+		"email":              fmt.Sprintf("oidc%d@miniblog.test", seq),
+		"email_verified":     true,
+		"name":               "OIDC User",
+	})
+
+	// 首次登录时创建用户, 用户名中的非法字符被替换
+	resp, err := oidcTestLogin(t, b)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+	assert.NotEmpty(t, resp.GetRefreshToken())
+
+	userM := getTestOIDCUser(t, b, subject)
+	assert.Equal(t, fmt.Sprintf("oidc_user%d", seq), userM.Username)
+	assert.Equal(t, "OIDC User", userM.Nickname)
+	assert.Equal(t, known.DefaultTenant, userM.TenantID)
+	assert.True(t, userM.EmailVerified)
+	assert.NotNil(t, userM.VerifiedAt)
+	roles, err := b.authz.GetRolesForUser(userM.UserID, known.DefaultTenant)
+	require.NoError(t, err)
+	assert.Equal(t, []string{known.RoleUser}, roles)
+
+	// 再次登录时使用关联的用户, 不会重复创建
+	_, err = oidcTestLogin(t, b)
+	require.NoError(t, err)
+	count, _, err := s.UserIdentity().List(context.Background(), where.F("subject", subject))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	assert.Equal(t, userM.UserID, getTestOIDCUser(t, b, subject).UserID)
+}
+
+func TestOIDCCallbackState(t *testing.T) {
+	b, _ := newTestBiz(t)
+	server := newTestOIDCServer(t, b)
+	server.SetClaims(map[string]any{"sub": fmt.Sprintf("subject-%d", testUserSeq.Add(1))})
+	ctx := context.Background()
+
+	// state只能使用一次
+	code, state := authorizeTestOIDC(t, b)
+	_, err := b.OIDCCallback(ctx, &apiv1.OIDCCallbackRequest{Code: code, State: state})
+	require.NoError(t, err)
+	_, err = b.OIDCCallback(ctx, &apiv1.OIDCCallbackRequest{Code: code, State: state})
+	assert.ErrorIs(t, err, errno.ErrOIDCStateInvalid)
+
+	_, err = b.OIDCCallback(ctx, &apiv1.OIDCCallbackRequest{Code: code, State: "unknown"})
+	assert.ErrorIs(t, err, errno.ErrOIDCStateInvalid)
+
+	// 身份提供方拒绝授权码时登录失败, 同时作废state
+	_, state = authorizeTestOIDC(t, b)
+	_, err = b.OIDCCallback(ctx, &apiv1.OIDCCallbackRequest{Code: "invalid", State: state})
+	assert.ErrorIs(t, err, errno.ErrOIDCLoginFailed)
+	_, err = b.OIDCCallback(ctx, &apiv1.OIDCCallbackRequest{Code: "invalid", State: state})
+	assert.ErrorIs(t, err, errno.ErrOIDCStateInvalid)
+
+	// 授权请求过期后不能完成登录
+	b.opts.OIDCLoginExpiration = -time.Minute
+	code, state = authorizeTestOIDC(t, b)
+	_, err = b.OIDCCallback(ctx, &apiv1.OIDCCallbackRequest{Code: code, State: state})
+	assert.ErrorIs(t, err, errno.ErrOIDCStateInvalid)
+
+	_, err = b.StartOIDCLogin(ctx, &apiv1.StartOIDCLoginRequest{Provider: "unknown"})
+	assert.ErrorIs(t, err, errno.ErrOIDCProviderNotFound)
+}
+
+func TestOIDCCallbackConflicts(t *testing.T) {
+	b, _ := newTestBiz(t)
+	server := newTestOIDCServer(t, b)
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	existing := getTestUser(t, b.store, userID)
+
+	// 外部用户名和手机号已被其他用户使用时, 生成新的用户名且不设置手机号
+	subject := fmt.Sprintf("subject-%d", testUserSeq.Add(1))
+	server.SetClaims(map[string]any{
+		"sub":                subject,
+		"preferred_username": username,
+		"phone_number":       *existing.Phone,
+		"email":              "unverified@miniblog.test",
+	})
+	_, err := oidcTestLogin(t, b)
+	require.NoError(t, err)
+
+	userM := getTestOIDCUser(t, b, subject)
+	assert.NotEqual(t, userID, userM.UserID)
+	assert.Regexp(t, `^oidc_[0-9a-f]{15}$`, userM.Username)
+	assert.Nil(t, userM.Phone)
+	assert.False(t, userM.EmailVerified)
+}
+
+func TestOIDCCallbackTOTP(t *testing.T) {
+	b, _ := newTestBiz(t)
+	server := newTestOIDCServer(t, b)
+	subject := fmt.Sprintf("subject-%d", testUserSeq.Add(1))
+	server.SetClaims(map[string]any{"sub": subject})
+	_, err := oidcTestLogin(t, b)
+	require.NoError(t, err)
+
+	// 开启了两步验证的外部用户同样需要完成两步验证
+	_, codes := enableTestTOTP(t, b, getTestOIDCUser(t, b, subject).UserID)
+	resp, err := oidcTestLogin(t, b)
+	require.NoError(t, err)
+	assert.True(t, resp.GetMfaRequired())
+	assert.Empty(t, resp.GetToken())
+
+	_, err = b.VerifyLogin(context.Background(), &apiv1.VerifyLoginRequest{ChallengeToken: resp.GetChallengeToken(), Code: codes[0]})
+	assert.NoError(t, err)
+}
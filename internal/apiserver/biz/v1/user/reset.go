@@ -0,0 +1,123 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/mail"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/authn"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// RequestPasswordReset 为邮箱对应的用户签发重置令牌, 并通过邮件发送重置链接.
+// 无论邮箱是否存在都返回成功, 防止通过响应内容或耗时枚举邮箱.
+func (b *userBiz) RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error) {
+	// 接口不需要认证, 授权中间件不会检查, 这里拒绝携带模拟登录令牌的请求
+	if contextx.Impersonated(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("email", rq.GetEmail()))
+	if err != nil {
+		// 邮箱属于个人信息, 不写入日志
+		log.W(ctx).Infow("Password reset requested for unknown email")
+		return &apiv1.RequestPasswordResetResponse{}, nil
+	}
+
+	// 签发令牌和发送邮件都在后台完成, 无论邮箱是否存在, 请求都只包含一次查询
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sendMailTimeout)
+		defer cancel()
+
+		if err := b.issuePasswordReset(ctx, userM); err != nil {
+			log.W(ctx).Errorw("Failed to issue password reset", "user", userM.UserID, "err", err)
+		}
+	}()
+
+	return &apiv1.RequestPasswordResetResponse{}, nil
+}
+
+// 为用户签发新的重置令牌, 并发送重置邮件.
+func (b *userBiz) issuePasswordReset(ctx context.Context, userM *model.UserM) error {
+	resetToken, err := newMailToken()
+	if err != nil {
+		return err
+	}
+
+	// 每个用户同一时间只保留最新的重置令牌, 之前发送的链接随之失效
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.PasswordReset().Delete(ctx, where.F("userID", userM.UserID)); err != nil {
+			return err
+		}
+		return b.store.PasswordReset().Create(ctx, &model.PasswordResetM{
+			UserID:    userM.UserID,
+			TokenHash: hashMailToken(resetToken),
+			ExpiresAt: time.Now().Add(b.opts.PasswordResetExpiration),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.opts.Mailer.Send(ctx, &mail.Message{
+		To:      []string{userM.Email},
+		Subject: "Reset your miniblog password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to reset your password. The link expires in %s and can only be used once.\n\n%s\n\nIf you did not request a password reset, you can ignore this email.\n",
+			userM.Username, b.opts.PasswordResetExpiration, mailLink(b.opts.PasswordResetURL, resetToken)),
+	})
+}
+
+// ResetPassword 使用重置令牌设置新密码, 令牌只能使用一次.
+// 重置成功后, 用户已有的令牌全部失效, 登录失败计数同时清零.
+func (b *userBiz) ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error) {
+	tokenHash := hashMailToken(rq.GetToken())
+	resetM, err := b.store.PasswordReset().Get(ctx, where.F("tokenHash", tokenHash))
+	if err != nil {
+		return nil, errno.ErrResetTokenInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", resetM.UserID))
+	if err != nil {
+		return nil, errno.ErrResetTokenInvalid
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		used, err := b.store.PasswordReset().MarkUsed(ctx, tokenHash, time.Now())
+		if err != nil {
+			return errno.ErrDBWrite
+		}
+		if !used {
+			return errno.ErrResetTokenInvalid
+		}
+
+		userM.Password, _ = authn.Encrypt(rq.GetNewPassword())
+		if err := b.store.User().Update(ctx, userM); err != nil {
+			return errno.ErrDBWrite
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.revokeUserTokens(ctx, userM.UserID); err != nil {
+		return nil, err
+	}
+	if err := b.guard.Reset(ctx, userM.Username); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	log.W(ctx).Infow("Password has been reset", "user", userM.UserID)
+	return &apiv1.ResetPasswordResponse{}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 为用户请求密码重置, 返回邮件中的重置令牌.
+func requestTestPasswordReset(t *testing.T, b *userBiz, m *captureMailer, email string) string {
+	_, err := b.RequestPasswordReset(context.Background(), &apiv1.RequestPasswordResetRequest{Email: email})
+	require.NoError(t, err)
+
+	msg, resetToken := m.next(t, "Reset your miniblog password")
+	assert.Equal(t, []string{email}, msg.To)
+
+	return resetToken
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	b, _ := newTestBiz(t)
+	m := captureTestMail(b)
+
+	// 邮箱不存在时同样返回成功, 但不发送邮件
+	_, err := b.RequestPasswordReset(context.Background(), &apiv1.RequestPasswordResetRequest{Email: "nobody@miniblog.test"})
+	require.NoError(t, err)
+	m.assertNoMail(t)
+
+	// 每个用户只保留最新的重置令牌
+	username, _ := createTestUser(t, b, known.DefaultTenant)
+	first := requestTestPasswordReset(t, b, m, username+"@miniblog.test")
+	second := requestTestPasswordReset(t, b, m, username+"@miniblog.test")
+	assert.NotEqual(t, first, second)
+
+	_, err = b.ResetPassword(context.Background(), &apiv1.ResetPasswordRequest{Token: first, NewPassword: "miniblog5678"})
+	assert.ErrorIs(t, err, errno.ErrResetTokenInvalid)
+	_, err = b.ResetPassword(context.Background(), &apiv1.ResetPasswordRequest{Token: second, NewPassword: "miniblog5678"})
+	assert.NoError(t, err)
+}
+
+func TestResetPassword(t *testing.T) {
+	b, _ := newTestBiz(t)
+	m := captureTestMail(b)
+	ctx := context.Background()
+	issuedAt := time.Now().Add(-time.Second)
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	login := loginTestUser(t, b, username)
+	resetToken := requestTestPasswordReset(t, b, m, username+"@miniblog.test")
+
+	_, err := b.ResetPassword(ctx, &apiv1.ResetPasswordRequest{Token: resetToken, NewPassword: "miniblog5678"})
+	require.NoError(t, err)
+
+	// 只能使用新密码登录
+	_, err = b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: testPassword})
+	assert.ErrorIs(t, err, errno.ErrInvalidCredentials)
+	_, err = b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: "miniblog5678"})
+	assert.NoError(t, err)
+
+	// 重置之前签发的令牌全部失效
+	revoked, err := b.denylist.IsRevoked(ctx, userID, "token-before-reset", issuedAt)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+	assert.Error(t, err)
+
+	// 重置令牌只能使用一次
+	_, err = b.ResetPassword(ctx, &apiv1.ResetPasswordRequest{Token: resetToken, NewPassword: "miniblog9012"})
+	assert.ErrorIs(t, err, errno.ErrResetTokenInvalid)
+	_, err = b.ResetPassword(ctx, &apiv1.ResetPasswordRequest{Token: "invalid", NewPassword: "miniblog9012"})
+	assert.ErrorIs(t, err, errno.ErrResetTokenInvalid)
+}
+
+func TestResetPasswordExpired(t *testing.T) {
+	b, s := newTestBiz(t)
+	_, userID := createTestUser(t, b, known.DefaultTenant)
+
+	resetToken, err := newMailToken()
+	require.NoError(t, err)
+	require.NoError(t, s.PasswordReset().Create(context.Background(), &model.PasswordResetM{
+		UserID:    userID,
+		TokenHash: hashMailToken(resetToken),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	_, err = b.ResetPassword(context.Background(), &apiv1.ResetPasswordRequest{Token: resetToken, NewPassword: "miniblog5678"})
+	assert.ErrorIs(t, err, errno.ErrResetTokenInvalid)
+}
+
+func TestRequestPasswordResetImpersonated(t *testing.T) {
+	b, _ := newTestBiz(t)
+	m := captureTestMail(b)
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	m.next(t, verificationSubject)
+
+	// 模拟登录的会话不能为被模拟的用户申请重置密码
+	ctx := contextx.WithActorID(userContext(userID, known.DefaultTenant), "user-admin")
+	_, err := b.RequestPasswordReset(ctx, &apiv1.RequestPasswordResetRequest{Email: username + "@miniblog.test"})
+	assert.ErrorIs(t, err, errno.ErrImpersonationForbidden)
+	m.assertNoMail(t)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+)
+
+// Restore 恢复已删除的用户和随用户一起删除的博客, 只有被授权管理当前租户所有用户的管理员可以恢复.
+// 删除超过保留时间的用户已经被永久清理, 无法恢复. 删除用户时吊销的令牌不会恢复, 用户需要重新登录.
+func (b *userBiz) Restore(ctx context.Context, rq *apiv1.RestoreUserRequest) (*apiv1.RestoreUserResponse, error) {
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), contextx.TenantID(ctx), "user", "restore")
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return nil, errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` cannot restore user `%s`", contextx.UserID(ctx), rq.GetUserID())
+	}
+
+	ctx, err = b.tenantContext(ctx, "user", "restore")
+	if err != nil {
+		return nil, err
+	}
+
+	var userM *model.UserM
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		// 需要根据用户的删除时间判断哪些博客随用户一起删除, 因此先恢复博客
+		if _, err := b.store.Post().RestoreByUserID(ctx, rq.GetUserID()); err != nil {
+			return err
+		}
+		if err := b.store.User().Restore(ctx, rq.GetUserID()); err != nil {
+			return err
+		}
+
+		// 角色需要添加到被恢复用户所属的租户中, 而不是管理员所在的租户
+		restored, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+		if err != nil {
+			return err
+		}
+		userM = restored
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+		return nil, errno.ErrDBWrite
+	}
+
+	// 删除用户时移除了用户的全部角色, 恢复时只重新添加普通用户角色
+	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser, userM.TenantID); err != nil {
+		log.W(ctx).Errorw("Failed to add grouping policy for user", "user", rq.GetUserID(), "role", known.RoleUser)
+		return nil, errno.ErrAddRole.WithMessage("%s", err.Error())
+	}
+
+	log.W(ctx).Infow("User restored", "targetUserID", userM.UserID, "tenant", userM.TenantID)
+
+	return &apiv1.RestoreUserResponse{}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestore(t *testing.T) {
+	b, _ := newTestBiz(t)
+	_, userID := createTestUser(t, b, "acme")
+
+	_, err := b.Delete(adminContext(), &apiv1.DeleteUserRequest{UserID: userID})
+	require.NoError(t, err)
+	hasRole, err := b.authz.HasGroupingPolicy(userID, known.RoleUser, "acme")
+	require.NoError(t, err)
+	require.False(t, hasRole)
+
+	// 全局管理员所在的租户与被恢复的用户不同, 可以查询到其他租户中的用户, 角色需要添加到用户所属的租户中
+	_, err = b.Restore(adminContext(), &apiv1.RestoreUserRequest{UserID: userID})
+	require.NoError(t, err)
+
+	hasRole, err = b.authz.HasGroupingPolicy(userID, known.RoleUser, "acme")
+	require.NoError(t, err)
+	assert.True(t, hasRole)
+	hasRole, err = b.authz.HasGroupingPolicy(userID, known.RoleUser, known.DefaultTenant)
+	require.NoError(t, err)
+	assert.False(t, hasRole)
+
+	_, err = b.Restore(adminContext(), &apiv1.RestoreUserRequest{UserID: "user-notexist"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/pkg/conversion"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// 会话记录中User-Agent的最大长度, 与数据库列宽一致.
+const maxUserAgentLength = 512
+
+// ListSessions 列出当前用户尚未失效的登录会话.
+func (b *userBiz) ListSessions(ctx context.Context, rq *apiv1.ListSessionsRequest) (*apiv1.ListSessionsResponse, error) {
+	return b.listSessions(ctx, contextx.UserID(ctx))
+}
+
+// RevokeSession 吊销当前用户的登录会话, 可以用于在其他设备上登出.
+func (b *userBiz) RevokeSession(ctx context.Context, rq *apiv1.RevokeSessionRequest) (*apiv1.RevokeSessionResponse, error) {
+	if err := b.revokeUserSession(ctx, contextx.UserID(ctx), rq.GetSessionID()); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.RevokeSessionResponse{}, nil
+}
+
+// ListUserSessions 列出指定用户尚未失效的登录会话.
+func (b *userBiz) ListUserSessions(ctx context.Context, rq *apiv1.ListUserSessionsRequest) (*apiv1.ListSessionsResponse, error) {
+	if _, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	return b.listSessions(ctx, rq.GetUserID())
+}
+
+// RevokeUserSession 吊销指定用户的登录会话.
+func (b *userBiz) RevokeUserSession(ctx context.Context, rq *apiv1.RevokeUserSessionRequest) (*apiv1.RevokeSessionResponse, error) {
+	if err := b.revokeUserSession(ctx, rq.GetUserID(), rq.GetSessionID()); err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Session has been revoked", "user", rq.GetUserID(), "session", rq.GetSessionID())
+	return &apiv1.RevokeSessionResponse{}, nil
+}
+
+// 为通过认证的用户创建登录会话, 记录发起登录的客户端信息.
+func (b *userBiz) createSession(ctx context.Context, userID string, sessionID string, expiresAt time.Time) error {
+	userAgent := contextx.UserAgent(ctx)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	sessionM := &model.SessionM{
+		SessionID:  sessionID,
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IP:         contextx.ClientIP(ctx),
+		RequestID:  contextx.RequestID(ctx),
+		LastSeenAt: time.Now(),
+		ExpiresAt:  expiresAt,
+	}
+	if err := b.store.Session().Create(ctx, sessionM); err != nil {
+		return errno.ErrDBWrite
+	}
+
+	return nil
+}
+
+// 列出用户尚未吊销且尚未过期的会话, 发起本次请求的会话会被标记出来.
+func (b *userBiz) listSessions(ctx context.Context, userID string) (*apiv1.ListSessionsResponse, error) {
+	whr := where.F("userID", userID).Q("revokedAt IS NULL AND expiresAt > ?", time.Now())
+	count, sessionList, err := b.store.Session().List(ctx, whr)
+	if err != nil {
+		return nil, errno.ErrDBRead
+	}
+
+	sessions := make([]*apiv1.Session, 0, len(sessionList))
+	for _, item := range sessionList {
+		converted := conversion.SessionModelToSessionV1(item)
+		converted.Current = item.SessionID == contextx.SessionID(ctx)
+		sessions = append(sessions, converted)
+	}
+
+	return &apiv1.ListSessionsResponse{TotalCount: count, Sessions: sessions}, nil
+}
+
+// 吊销属于指定用户的会话, 已经失效的会话按不存在处理.
+func (b *userBiz) revokeUserSession(ctx context.Context, userID string, sessionID string) error {
+	sessionM, err := b.store.Session().Get(ctx, where.F("userID", userID, "sessionID", sessionID))
+	if err != nil || sessionM.RevokedAt != nil || !time.Now().Before(sessionM.ExpiresAt) {
+		return errno.ErrSessionNotFound
+	}
+
+	return b.revokeSession(ctx, sessionM)
+}
+
+// 吊销会话和会话对应的刷新令牌族, 并将会话ID写入黑名单, 使会话中签发的访问令牌立即失效.
+func (b *userBiz) revokeSession(ctx context.Context, sessionM *model.SessionM) error {
+	return b.store.TX(ctx, func(ctx context.Context) error {
+		revoked, err := b.store.Session().Revoke(ctx, sessionM.SessionID)
+		if err != nil {
+			return errno.ErrDBWrite
+		}
+		if err := b.store.RefreshToken().RevokeFamily(ctx, sessionM.SessionID); err != nil {
+			return errno.ErrDBWrite
+		}
+
+		// 会话已经被并发吊销时, 黑名单中已经有对应的记录
+		if !revoked {
+			return nil
+		}
+		// 会话中签发的访问令牌不会晚于会话过期, 黑名单记录保留到会话过期即可
+		if err := b.denylist.Revoke(ctx, sessionM.UserID, sessionM.SessionID, sessionM.ExpiresAt); err != nil {
+			log.W(ctx).Errorw("Failed to revoke access tokens of session", "session", sessionM.SessionID, "err", err)
+			return errno.ErrDBWrite
+		}
+		return nil
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// UpdateStatus 禁用, 封禁或重新启用用户, 只有被授权管理当前租户所有用户的管理员可以修改.
+// 修改立即生效, 非正常状态的用户持有的令牌会在认证时被拒绝.
+func (b *userBiz) UpdateStatus(ctx context.Context, rq *apiv1.UpdateUserStatusRequest) (*apiv1.UpdateUserStatusResponse, error) {
+	// 用户本人也不能修改自己的状态, 因此不使用authorizeUser
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), contextx.TenantID(ctx), "user", "update-status")
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed || rq.GetUserID() == contextx.UserID(ctx) {
+		return nil, errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` cannot update status of user `%s`", contextx.UserID(ctx), rq.GetUserID())
+	}
+
+	ctx, err = b.tenantContext(ctx, "user", "update-status")
+	if err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	userM.Status = rq.GetStatus()
+	userM.StatusReason = rq.GetReason()
+	userM.StatusUntil = nil
+	if rq.Until != nil {
+		until := time.Unix(rq.GetUntil(), 0)
+		userM.StatusUntil = &until
+	}
+	// 重新启用时清除原因
+	if userM.Status == known.UserStatusActive {
+		userM.StatusReason = ""
+	}
+
+	if err := b.store.User().Update(ctx, userM); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	log.W(ctx).Infow("User status updated", "targetUserID", userM.UserID, "status", userM.Status, "reason", userM.StatusReason)
+
+	return &apiv1.UpdateUserStatusResponse{}, nil
+}
+
+// CheckStatus 检查用户能否登录和访问接口, 禁用的用户和封禁期内的用户返回对应的错误.
+// 封禁到期后用户自动恢复正常, 不需要管理员重新启用.
+func CheckStatus(userM *model.UserM) error {
+	switch userM.Status {
+	case known.UserStatusDisabled:
+		return errno.ErrUserDisabled
+	case known.UserStatusBanned:
+		if userM.StatusUntil == nil || time.Now().Before(*userM.StatusUntil) {
+			return errno.ErrUserBanned
+		}
+	}
+
+	return nil
+}
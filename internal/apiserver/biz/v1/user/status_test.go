@@ -0,0 +1,87 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestUpdateStatus(t *testing.T) {
+	b, _ := newTestBiz(t)
+
+	tests := []struct {
+		name  string
+		rq    *apiv1.UpdateUserStatusRequest
+		match error
+	}{
+		{name: "disabled", rq: &apiv1.UpdateUserStatusRequest{Status: known.UserStatusDisabled, Reason: ptr.To("spam")}, match: errno.ErrUserDisabled},
+		{name: "banned", rq: &apiv1.UpdateUserStatusRequest{Status: known.UserStatusBanned}, match: errno.ErrUserBanned},
+		{name: "banned until", rq: &apiv1.UpdateUserStatusRequest{Status: known.UserStatusBanned, Until: ptr.To(time.Now().Add(time.Hour).Unix())}, match: errno.ErrUserBanned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			username, userID := createTestUser(t, b, known.DefaultTenant)
+			login := loginTestUser(t, b, username)
+
+			tt.rq.UserID = userID
+			_, err := b.UpdateStatus(adminContext(), tt.rq)
+			require.NoError(t, err)
+
+			// 登录和使用已有的刷新令牌都会被拒绝
+			_, err = b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: testPassword})
+			assert.ErrorIs(t, err, tt.match)
+			_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+			assert.ErrorIs(t, err, tt.match)
+
+			// 密码错误时返回与正常用户相同的错误, 不泄露用户状态
+			_, err = b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: "wrong-password"})
+			assert.ErrorIs(t, err, errno.ErrInvalidCredentials)
+
+			// 重新启用后恢复访问, 被拒绝的刷新令牌没有被消耗
+			_, err = b.UpdateStatus(adminContext(), &apiv1.UpdateUserStatusRequest{UserID: userID, Status: known.UserStatusActive})
+			require.NoError(t, err)
+			loginTestUser(t, b, username)
+			_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateStatusExpiredBan(t *testing.T) {
+	b, _ := newTestBiz(t)
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+
+	// 封禁到期后不需要管理员重新启用
+	_, err := b.UpdateStatus(adminContext(), &apiv1.UpdateUserStatusRequest{
+		UserID: userID,
+		Status: known.UserStatusBanned,
+		Until:  ptr.To(time.Now().Add(-time.Minute).Unix()),
+	})
+	require.NoError(t, err)
+	loginTestUser(t, b, username)
+}
+
+func TestUpdateStatusPermissionDenied(t *testing.T) {
+	b, _ := newTestBiz(t)
+	_, userID := createTestUser(t, b, known.DefaultTenant)
+	_, otherID := createTestUser(t, b, known.DefaultTenant)
+
+	// 普通用户不能修改其他用户的状态, 管理员也不能修改自己的状态
+	_, err := b.UpdateStatus(userContext(userID, known.DefaultTenant), &apiv1.UpdateUserStatusRequest{UserID: otherID, Status: known.UserStatusDisabled})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	_, err = b.UpdateStatus(adminContext(), &apiv1.UpdateUserStatusRequest{UserID: testAdminID, Status: known.UserStatusDisabled})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"io"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 创建只在默认租户中拥有管理员角色的租户管理员, 返回用户ID.
+func createTenantAdmin(t *testing.T, b *userBiz) string {
+	_, userID := createNamedTestUser(t, b, "tenantadmin", known.DefaultTenant)
+	_, err := b.authz.AddGroupingPolicy(userID, known.RoleAdmin, known.DefaultTenant)
+	require.NoError(t, err)
+
+	return userID
+}
+
+func TestGlobalAdminCrossTenant(t *testing.T) {
+	b, _ := newTestBiz(t)
+	_, userID := createTestUser(t, b, "globex")
+
+	// 在全部租户中拥有管理员角色的全局管理员可以管理其他租户中的用户
+	ctx := adminContext()
+	resp, err := b.Get(ctx, &apiv1.GetUserRequest{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, "globex", resp.GetUser().GetTenantID())
+
+	_, err = b.UpdateStatus(ctx, &apiv1.UpdateUserStatusRequest{UserID: userID, Status: known.UserStatusDisabled})
+	assert.NoError(t, err)
+	err = b.ExportUserData(ctx, &apiv1.ExportUserDataRequest{UserID: userID}, io.Discard)
+	assert.NoError(t, err)
+	_, err = b.Delete(ctx, &apiv1.DeleteUserRequest{UserID: userID})
+	assert.NoError(t, err)
+	_, err = b.Restore(ctx, &apiv1.RestoreUserRequest{UserID: userID})
+	assert.NoError(t, err)
+}
+
+func TestTenantAdminCrossTenant(t *testing.T) {
+	b, _ := newTestBiz(t)
+	_, userID := createTestUser(t, b, "globex")
+	_, err := b.Delete(adminContext(), &apiv1.DeleteUserRequest{UserID: userID})
+	require.NoError(t, err)
+	_, deletedID := createTestUser(t, b, "globex")
+	_, err = b.Delete(adminContext(), &apiv1.DeleteUserRequest{UserID: deletedID})
+	require.NoError(t, err)
+	_, err = b.Restore(adminContext(), &apiv1.RestoreUserRequest{UserID: userID})
+	require.NoError(t, err)
+
+	// 只在当前租户中拥有管理员角色的租户管理员查询不到其他租户中的用户
+	ctx := userContext(createTenantAdmin(t, b), known.DefaultTenant)
+	_, err = b.Get(ctx, &apiv1.GetUserRequest{UserID: userID})
+	assert.Error(t, err)
+	_, err = b.UpdateStatus(ctx, &apiv1.UpdateUserStatusRequest{UserID: userID, Status: known.UserStatusDisabled})
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	err = b.ExportUserData(ctx, &apiv1.ExportUserDataRequest{UserID: userID}, io.Discard)
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	_, err = b.Delete(ctx, &apiv1.DeleteUserRequest{UserID: userID})
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	_, err = b.Restore(ctx, &apiv1.RestoreUserRequest{UserID: deletedID})
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+
+	// 用户没有被修改
+	resp, err := b.Get(adminContext(), &apiv1.GetUserRequest{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, known.UserStatusActive, resp.GetUser().GetStatus())
+}
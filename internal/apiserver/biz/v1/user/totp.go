@@ -0,0 +1,268 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/token"
+	"miniblog/pkg/totp"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+)
+
+const (
+	// 身份验证器中显示的签发者名称.
+	totpIssuer = "miniblog"
+	// 确认绑定身份验证器时生成的恢复码数量.
+	recoveryCodeCount = 10
+)
+
+// 用于生成和校验一次性密码, 使用默认的6位密码和30秒步长.
+var otp = totp.New()
+
+// 为当前用户生成新的TOTP密钥, 之前未确认的密钥会被替换.
+func (b *userBiz) EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error) {
+	userID := contextx.UserID(ctx)
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	totpM, err := b.store.UserTOTP().Get(ctx, where.F("userID", userID))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBRead
+	}
+	if err == nil && totpM.ConfirmedAt != nil {
+		return nil, errno.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errno.ErrOperationFailed
+	}
+
+	if err := b.store.UserTOTP().Delete(ctx, where.F("userID", userID)); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+	if err := b.store.UserTOTP().Create(ctx, &model.UserTOTPM{UserID: userID, Secret: secret}); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.EnrollTOTPResponse{Secret: secret, Url: otp.URL(totpIssuer, userM.Username, secret)}, nil
+}
+
+// 校验身份验证器生成的一次性密码, 校验通过后开启两步验证并生成恢复码.
+func (b *userBiz) ConfirmTOTP(ctx context.Context, rq *apiv1.ConfirmTOTPRequest) (*apiv1.ConfirmTOTPResponse, error) {
+	userID := contextx.UserID(ctx)
+	totpM, err := b.store.UserTOTP().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, errno.ErrTOTPNotEnabled
+	}
+	if totpM.ConfirmedAt != nil {
+		return nil, errno.ErrTOTPAlreadyEnabled
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, errno.ErrOperationFailed
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		// 确认时只接受一次性密码, 此时用户还没有恢复码
+		if err := b.useTOTPCode(ctx, totpM, rq.GetCode()); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		totpM.ConfirmedAt = &now
+		if err := b.store.UserTOTP().Update(ctx, totpM); err != nil {
+			return errno.ErrDBWrite
+		}
+
+		if err := b.store.RecoveryCode().Delete(ctx, where.F("userID", userID)); err != nil {
+			return errno.ErrDBWrite
+		}
+		for _, code := range codes {
+			rcM := &model.RecoveryCodeM{UserID: userID, CodeHash: totp.HashRecoveryCode(code)}
+			if err := b.store.RecoveryCode().Create(ctx, rcM); err != nil {
+				return errno.ErrDBWrite
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.ConfirmTOTPResponse{RecoveryCodes: codes}, nil
+}
+
+// 关闭两步验证, 同时删除密钥和全部恢复码.
+func (b *userBiz) DisableTOTP(ctx context.Context, rq *apiv1.DisableTOTPRequest) (*apiv1.DisableTOTPResponse, error) {
+	userID := contextx.UserID(ctx)
+	totpM, err := b.getEnabledTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.verifySecondFactor(ctx, totpM, rq.GetCode()); err != nil {
+			return err
+		}
+
+		if err := b.store.UserTOTP().Delete(ctx, where.F("userID", userID)); err != nil {
+			return errno.ErrDBWrite
+		}
+		if err := b.store.RecoveryCode().Delete(ctx, where.F("userID", userID)); err != nil {
+			return errno.ErrDBWrite
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.DisableTOTPResponse{}, nil
+}
+
+// 使用Login返回的挑战令牌和一次性密码(或恢复码)换取访问令牌和刷新令牌, 每个挑战令牌只能使用一次.
+func (b *userBiz) VerifyLogin(ctx context.Context, rq *apiv1.VerifyLoginRequest) (*apiv1.VerifyLoginResponse, error) {
+	claims, err := token.VerifyChallenge(rq.GetChallengeToken())
+	if err != nil {
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	revoked, err := b.denylist.IsRevoked(ctx, claims.Identity, claims.ID, claims.IssuedAt)
+	if err != nil {
+		return nil, errno.ErrDBRead
+	}
+	if revoked {
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", claims.Identity))
+	if err != nil {
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	// 用户可能在挑战令牌签发后关闭了两步验证, 此时要求用户重新登录
+	totpM, err := b.getEnabledTOTP(ctx, claims.Identity)
+	if err != nil {
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	// 一次性密码同样受登录失败次数限制, 防止在挑战令牌有效期内穷举
+	if err := b.acquireLoginAttempt(ctx, userM.Username); err != nil {
+		return nil, err
+	}
+
+	if err := b.verifySecondFactor(ctx, totpM, rq.GetCode()); err != nil {
+		log.W(ctx).Warnw("Failed to verify second factor", "user", claims.Identity)
+		return nil, err
+	}
+
+	if err := b.releaseLoginAttempt(ctx, userM.Username); err != nil {
+		return nil, err
+	}
+
+	// 签发令牌前原子地作废挑战令牌, 并发使用同一个挑战令牌时只有一个请求能够登录成功
+	consumed, err := b.denylist.Consume(ctx, claims.Identity, claims.ID, claims.ExpiresAt)
+	if err != nil {
+		return nil, errno.ErrDBWrite
+	}
+	if !consumed {
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	if err := b.guard.Reset(ctx, userM.Username); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	resp, err := b.issueTokens(ctx, userM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.VerifyLoginResponse{
+		Token:           resp.Token,
+		ExpireAt:        resp.ExpireAt,
+		RefreshToken:    resp.RefreshToken,
+		RefreshExpireAt: resp.RefreshExpireAt,
+	}, nil
+}
+
+// 获取用户已确认的TOTP配置, 用户没有开启两步验证时返回错误.
+func (b *userBiz) getEnabledTOTP(ctx context.Context, userID string) (*model.UserTOTPM, error) {
+	totpM, err := b.store.UserTOTP().Get(ctx, where.F("userID", userID))
+	if err != nil || totpM.ConfirmedAt == nil {
+		return nil, errno.ErrTOTPNotEnabled
+	}
+
+	return totpM, nil
+}
+
+// 判断用户是否开启了两步验证.
+func (b *userBiz) totpEnabled(ctx context.Context, userID string) (bool, error) {
+	totpM, err := b.store.UserTOTP().Get(ctx, where.F("userID", userID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errno.ErrDBRead
+	}
+
+	return totpM.ConfirmedAt != nil, nil
+}
+
+// 校验第二因素, code可以是一次性密码, 也可以是一个未使用的恢复码.
+func (b *userBiz) verifySecondFactor(ctx context.Context, totpM *model.UserTOTPM, code string) error {
+	if step, ok := otp.Validate(totpM.Secret, code); ok {
+		return b.useStep(ctx, totpM, step)
+	}
+
+	used, err := b.store.RecoveryCode().MarkUsed(ctx, totpM.UserID, totp.HashRecoveryCode(code))
+	if err != nil {
+		return errno.ErrDBWrite
+	}
+	if !used {
+		return errno.ErrTOTPCodeInvalid
+	}
+
+	log.W(ctx).Infow("Recovery code used", "user", totpM.UserID)
+	return nil
+}
+
+// 校验一次性密码并记录其时间步, 已经使用过的密码及更早的密码不再被接受.
+func (b *userBiz) useTOTPCode(ctx context.Context, totpM *model.UserTOTPM, code string) error {
+	step, ok := otp.Validate(totpM.Secret, code)
+	if !ok {
+		return errno.ErrTOTPCodeInvalid
+	}
+
+	return b.useStep(ctx, totpM, step)
+}
+
+// 记录一次性密码的时间步, 时间步不大于已记录的时间步时说明密码被重放.
+func (b *userBiz) useStep(ctx context.Context, totpM *model.UserTOTPM, step int64) error {
+	used, err := b.store.UserTOTP().UseStep(ctx, totpM.UserID, step)
+	if err != nil {
+		return errno.ErrDBWrite
+	}
+	if !used {
+		return errno.ErrTOTPCodeInvalid
+	}
+
+	totpM.LastUsedStep = step
+	return nil
+}
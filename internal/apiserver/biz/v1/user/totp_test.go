@@ -0,0 +1,146 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/apiserver/pkg/denylist"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/token"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 为用户开启两步验证, 返回TOTP密钥和恢复码.
+func enableTestTOTP(t *testing.T, b *userBiz, userID string) (string, []string) {
+	ctx := userContext(userID, known.DefaultTenant)
+	enroll, err := b.EnrollTOTP(ctx, &apiv1.EnrollTOTPRequest{})
+	require.NoError(t, err)
+
+	code, err := otp.Generate(enroll.GetSecret())
+	require.NoError(t, err)
+	confirm, err := b.ConfirmTOTP(ctx, &apiv1.ConfirmTOTPRequest{Code: code})
+	require.NoError(t, err)
+
+	return enroll.GetSecret(), confirm.GetRecoveryCodes()
+}
+
+// 使用用户名和测试密码登录, 返回两步验证的挑战令牌.
+func loginTestChallenge(t *testing.T, b *userBiz, username string) string {
+	resp := loginTestUser(t, b, username)
+	require.NotEmpty(t, resp.GetChallengeToken())
+	assert.Empty(t, resp.GetToken())
+
+	return resp.GetChallengeToken()
+}
+
+func TestVerifyLogin(t *testing.T) {
+	b, _ := newTestBiz(t)
+	ctx := context.Background()
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	secret, codes := enableTestTOTP(t, b, userID)
+	challenge := loginTestChallenge(t, b, username)
+
+	// 错误的一次性密码不会作废挑战令牌
+	_, err := b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: "000000x"})
+	assert.ErrorIs(t, err, errno.ErrTOTPCodeInvalid)
+
+	// 确认绑定时使用的一次性密码已经被记录, 同一个时间步内的密码不能再次使用
+	code, err := otp.Generate(secret)
+	require.NoError(t, err)
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: code})
+	assert.ErrorIs(t, err, errno.ErrTOTPCodeInvalid)
+
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: "invalid", Code: code})
+	assert.ErrorIs(t, err, errno.ErrChallengeTokenInvalid)
+
+	// 登录令牌不能当作挑战令牌使用
+	accessToken, _, err := token.Sign(userID)
+	require.NoError(t, err)
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: accessToken, Code: code})
+	assert.ErrorIs(t, err, errno.ErrChallengeTokenInvalid)
+
+	// 校验失败后挑战令牌仍然可以使用
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[0]})
+	assert.NoError(t, err)
+}
+
+func TestVerifyLoginRecoveryCode(t *testing.T) {
+	b, _ := newTestBiz(t)
+	ctx := context.Background()
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	_, codes := enableTestTOTP(t, b, userID)
+	challenge := loginTestChallenge(t, b, username)
+
+	resp, err := b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[0]})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+	assert.NotEmpty(t, resp.GetRefreshToken())
+
+	// 挑战令牌只能使用一次, 即使第二因素有效
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[1]})
+	assert.ErrorIs(t, err, errno.ErrChallengeTokenInvalid)
+
+	// 恢复码只能使用一次
+	challenge = loginTestChallenge(t, b, username)
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[0]})
+	assert.ErrorIs(t, err, errno.ErrTOTPCodeInvalid)
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[1]})
+	assert.NoError(t, err)
+}
+
+// consumedDenylist 在作废令牌之前先由另一个调用作废同一个令牌, 用于模拟并发请求.
+type consumedDenylist struct {
+	denylist.Denylist
+}
+
+func (d *consumedDenylist) Consume(ctx context.Context, userID string, jti string, expiresAt time.Time) (bool, error) {
+	if _, err := d.Denylist.Consume(ctx, userID, jti, expiresAt); err != nil {
+		return false, err
+	}
+
+	return d.Denylist.Consume(ctx, userID, jti, expiresAt)
+}
+
+func TestVerifyLoginConsumedChallenge(t *testing.T) {
+	b, _ := newTestBiz(t)
+	ctx := context.Background()
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	_, codes := enableTestTOTP(t, b, userID)
+	challenge := loginTestChallenge(t, b, username)
+	claims, err := token.VerifyChallenge(challenge)
+	require.NoError(t, err)
+
+	// 模拟并发请求在本次请求校验第二因素期间作废了挑战令牌
+	b.denylist = &consumedDenylist{Denylist: b.denylist}
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[0]})
+	assert.ErrorIs(t, err, errno.ErrChallengeTokenInvalid)
+
+	revoked, err := b.denylist.IsRevoked(ctx, userID, claims.ID, claims.IssuedAt)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestVerifyLoginTOTPDisabled(t *testing.T) {
+	b, _ := newTestBiz(t)
+	ctx := context.Background()
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	_, codes := enableTestTOTP(t, b, userID)
+	challenge := loginTestChallenge(t, b, username)
+
+	// 挑战令牌签发后关闭了两步验证, 需要重新登录
+	_, err := b.DisableTOTP(userContext(userID, known.DefaultTenant), &apiv1.DisableTOTPRequest{Code: codes[0]})
+	require.NoError(t, err)
+	_, err = b.VerifyLogin(ctx, &apiv1.VerifyLoginRequest{ChallengeToken: challenge, Code: codes[1]})
+	assert.ErrorIs(t, err, errno.ErrChallengeTokenInvalid)
+
+	assert.NotEmpty(t, loginTestUser(t, b, username).GetToken())
+}
@@ -7,25 +7,34 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/apiserver/pkg/conversion"
+	"miniblog/internal/apiserver/pkg/denylist"
+	"miniblog/internal/apiserver/pkg/lockout"
 	"miniblog/internal/apiserver/store"
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
 	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/oidc"
 	"miniblog/pkg/token"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/onexstack/onexstack/pkg/authn"
-	"github.com/onexstack/onexstack/pkg/authz"
 
+	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
 	"github.com/onexstack/onexstack/pkg/store/where"
-	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm/clause"
 )
 
 type UserBiz interface {
@@ -38,62 +47,408 @@ type UserBiz interface {
 	UserExpansion
 }
 
-// 扩展接口实现了用户登录, Token刷新, 密码修改和差性能示例方法.
+// 扩展接口实现了用户登录, 两步验证, Token刷新, 登出, 令牌吊销, 模拟登录, 密码修改, 密码重置, 邮箱验证, 登录解锁, 会话管理, 外部身份提供方登录, 用户状态管理, 恢复已删除的用户和个人数据导出.
 type UserExpansion interface {
 	Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error)
+	VerifyLogin(ctx context.Context, rq *apiv1.VerifyLoginRequest) (*apiv1.VerifyLoginResponse, error)
+	EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, rq *apiv1.ConfirmTOTPRequest) (*apiv1.ConfirmTOTPResponse, error)
+	DisableTOTP(ctx context.Context, rq *apiv1.DisableTOTPRequest) (*apiv1.DisableTOTPResponse, error)
 	RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error)
+	Logout(ctx context.Context, rq *apiv1.LogoutRequest) (*apiv1.LogoutResponse, error)
+	RevokeTokens(ctx context.Context, rq *apiv1.RevokeTokensRequest) (*apiv1.RevokeTokensResponse, error)
+	Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error)
 	ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error)
-	ListWithBadPerformance(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error)
+	RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error)
+	ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error)
+	VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error)
+	SendVerificationEmail(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) (*apiv1.SendVerificationEmailResponse, error)
+	Unlock(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error)
+	UpdateStatus(ctx context.Context, rq *apiv1.UpdateUserStatusRequest) (*apiv1.UpdateUserStatusResponse, error)
+	Restore(ctx context.Context, rq *apiv1.RestoreUserRequest) (*apiv1.RestoreUserResponse, error)
+	ListSessions(ctx context.Context, rq *apiv1.ListSessionsRequest) (*apiv1.ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, rq *apiv1.RevokeSessionRequest) (*apiv1.RevokeSessionResponse, error)
+	ListUserSessions(ctx context.Context, rq *apiv1.ListUserSessionsRequest) (*apiv1.ListSessionsResponse, error)
+	RevokeUserSession(ctx context.Context, rq *apiv1.RevokeUserSessionRequest) (*apiv1.RevokeSessionResponse, error)
+	StartOIDCLogin(ctx context.Context, rq *apiv1.StartOIDCLoginRequest) (*apiv1.StartOIDCLoginResponse, error)
+	OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.LoginResponse, error)
+	ExportMyData(ctx context.Context, rq *apiv1.ExportMyDataRequest, w io.Writer) error
+	ExportUserData(ctx context.Context, rq *apiv1.ExportUserDataRequest, w io.Writer) error
 }
 
 type userBiz struct {
-	store store.IStore
-	authz *authz.Authz
+	store    store.IStore
+	authz    *auth.Authz
+	denylist denylist.Denylist
+	guard    *lockout.Guard
+	opts     *Options
+}
+
+// Options 定义了用户业务中发送邮件和外部身份提供方登录相关的配置.
+type Options struct {
+	// 发送邮件的Mailer
+	Mailer mail.Mailer
+	// 密码重置页面地址, 重置令牌以token查询参数附加在地址后
+	PasswordResetURL string
+	// 密码重置令牌的有效期
+	PasswordResetExpiration time.Duration
+	// 邮箱验证页面地址, 验证令牌以token查询参数附加在地址后
+	EmailVerificationURL string
+	// 邮箱验证令牌的有效期
+	EmailVerificationExpiration time.Duration
+	// 已配置的外部身份提供方, 键为身份提供方名称
+	OIDCProviders map[string]*oidc.Provider
+	// 外部身份提供方登录授权请求的有效期
+	OIDCLoginExpiration time.Duration
 }
 
 var _ UserBiz = (*userBiz)(nil)
 
-func New(store store.IStore, authz *authz.Authz) *userBiz {
-	return &userBiz{store: store, authz: authz}
+// 用户不存在时用于比对的密码摘要, 使登录失败的耗时与用户是否存在无关.
+var dummyPassword = sync.OnceValue(func() string {
+	hashed, _ := authn.Encrypt("miniblog-dummy-password")
+	return hashed
+})
+
+func New(store store.IStore, authz *auth.Authz, denylist denylist.Denylist, guard *lockout.Guard, opts *Options) *userBiz {
+	return &userBiz{store: store, authz: authz, denylist: denylist, guard: guard, opts: opts}
 }
 
 func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error) {
+	// 连续登录失败次数过多的用户名或IP需要等待一段时间才能再次尝试
+	if err := b.acquireLoginAttempt(ctx, rq.GetUsername()); err != nil {
+		return nil, err
+	}
+
 	// 获取用户登录的所有信息
 	whr := where.F("username", rq.GetUsername())
 
 	userM, err := b.store.User().Get(ctx, whr)
 	if err != nil {
-		return nil, errno.ErrUserNotFound
+		// 用户不存在时同样比对一次密码, 并返回与密码错误相同的错误, 防止用户名被枚举
+		_ = authn.Compare(dummyPassword(), rq.GetPassword())
+		return nil, failLogin(ctx, rq.GetUsername())
 	}
 
 	// 对比传入的明文密码和数据库中已加密过的密码是否匹配
 	// auth.Compare会将传入的明文密码加密然后和数据库中的密码对比
 	if err := authn.Compare(userM.Password, rq.GetPassword()); err != nil {
 		log.W(ctx).Errorw("Failed to compare password", "err", err)
-		return nil, errno.ErrPasswordInvalid
+		return nil, failLogin(ctx, rq.GetUsername())
+	}
+	// 密码正确时撤销本次尝试的失败计数
+	if err := b.releaseLoginAttempt(ctx, userM.Username); err != nil {
+		return nil, err
+	}
+	// 密码正确后才返回用户状态, 避免泄露用户是否被禁用
+	if err := CheckStatus(userM); err != nil {
+		return nil, err
+	}
+
+	// 开启了两步验证的用户只返回挑战令牌, 需要调用VerifyLogin提交一次性密码后才能获得访问令牌
+	enabled, err := b.totpEnabled(ctx, userM.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return b.issueChallenge(ctx, userM.UserID)
+	}
+
+	if err := b.guard.Reset(ctx, userM.Username); err != nil {
+		return nil, errno.ErrDBWrite
 	}
 
-	// 实现Token签发逻辑, 在签发token时会在token的payload中保存用户id
-	tokenStr, expireAt, err := token.Sign(userM.UserID)
+	return b.issueTokens(ctx, userM)
+}
+
+// 记录一次登录尝试, 并判断当前用户名和客户端IP是否允许尝试登录.
+func (b *userBiz) acquireLoginAttempt(ctx context.Context, username string) error {
+	until, err := b.guard.Acquire(ctx, username, contextx.ClientIP(ctx))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to record login attempt", "err", err)
+		return errno.ErrDBWrite
+	}
+	if !until.IsZero() {
+		log.W(ctx).Warnw("Login attempt rejected due to too many failures", "username", username, "ip", contextx.ClientIP(ctx), "until", until)
+		return errno.ErrTooManyLoginAttempts
+	}
+
+	return nil
+}
+
+// 撤销一次凭证校验通过的登录尝试.
+func (b *userBiz) releaseLoginAttempt(ctx context.Context, username string) error {
+	if err := b.guard.Release(ctx, username, contextx.ClientIP(ctx)); err != nil {
+		log.W(ctx).Errorw("Failed to release login attempt", "err", err)
+		return errno.ErrDBWrite
+	}
+
+	return nil
+}
+
+// 返回统一的登录失败错误, 失败次数已经在尝试登录时记录.
+func failLogin(ctx context.Context, username string) error {
+	log.W(ctx).Warnw("Login failed", "username", username, "ip", contextx.ClientIP(ctx))
+	return errno.ErrInvalidCredentials
+}
+
+// 清除用户的登录失败记录, 解除登录锁定.
+func (b *userBiz) Unlock(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error) {
+	if err := b.authorizeUser(ctx, rq.GetUserID(), "unlock"); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	if err := b.guard.Reset(ctx, userM.Username); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.UnlockUserResponse{}, nil
+}
+
+// 为通过了第一因素认证的用户签发挑战令牌, 用户需要调用VerifyLogin完成两步验证.
+func (b *userBiz) issueChallenge(ctx context.Context, userID string) (*apiv1.LoginResponse, error) {
+	challenge, challengeExpireAt, err := token.SignChallenge(userID)
 	if err != nil {
 		return nil, errno.ErrSignToken
 	}
 
-	return &apiv1.LoginResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+	return &apiv1.LoginResponse{
+		MfaRequired:       true,
+		ChallengeToken:    challenge,
+		ChallengeExpireAt: timestamppb.New(challengeExpireAt),
+	}, nil
+}
+
+// 为通过认证的用户创建登录会话, 签发访问令牌, 并开启一个新的刷新令牌族.
+func (b *userBiz) issueTokens(ctx context.Context, userM *model.UserM) (*apiv1.LoginResponse, error) {
+	// 两步验证和外部身份提供方登录同样经过这里, 非正常状态的用户不能获得令牌
+	if err := CheckStatus(userM); err != nil {
+		return nil, err
+	}
+
+	userID := userM.UserID
+	// 每次登录都会开启一个新的会话, 会话ID同时作为令牌族ID, 后续轮换出的刷新令牌都属于该令牌族
+	sessionID := uuid.New().String()
+
+	// 实现Token签发逻辑, 在签发token时会在token的payload中保存用户id, 租户id和会话id
+	tokenStr, expireAt, err := token.SignSession(userID, userM.TenantID, sessionID)
+	if err != nil {
+		return nil, errno.ErrSignToken
+	}
+
+	refreshToken, refreshExpireAt, err := b.issueRefreshToken(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.createSession(ctx, userID, sessionID, refreshExpireAt); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.LoginResponse{
+		Token:           tokenStr,
+		ExpireAt:        timestamppb.New(expireAt),
+		RefreshToken:    refreshToken,
+		RefreshExpireAt: timestamppb.New(refreshExpireAt),
+	}, nil
 }
 
-// 刷新用户的身份验证令牌.
+// 使用刷新令牌换取新的访问令牌和刷新令牌, 旧的刷新令牌随即失效.
+// 如果已失效的刷新令牌被再次使用, 说明令牌可能已经泄露, 此时会吊销整个令牌族.
 func (b *userBiz) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error) {
-	// TODO: 实现Token签发逻辑
-	return &apiv1.RefreshTokenResponse{Token: "<placeholder>", ExpireAt: timestamppb.New(time.Now().Add(2 * time.Hour))}, nil
+	tokenHash := token.HashRefresh(rq.GetRefreshToken())
+	rtM, err := b.store.RefreshToken().Get(ctx, where.F("tokenHash", tokenHash))
+	if err != nil {
+		return nil, errno.ErrRefreshTokenInvalid
+	}
+
+	if rtM.RevokedAt != nil {
+		return nil, b.revokeRefreshTokenFamily(ctx, rtM)
+	}
+
+	if time.Now().After(rtM.ExpiresAt) {
+		return nil, errno.ErrRefreshTokenExpired
+	}
+
+	var resp apiv1.RefreshTokenResponse
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		// 并发请求使用同一个刷新令牌时, 只有一个请求能够标记成功, 其余请求按重放处理
+		used, err := b.store.RefreshToken().MarkUsed(ctx, tokenHash)
+		if err != nil {
+			return errno.ErrDBWrite
+		}
+		if !used {
+			return errno.ErrRefreshTokenReused
+		}
+
+		// 用户可能在刷新令牌签发后被删除
+		userM, err := b.store.User().Get(ctx, where.F("userID", rtM.UserID))
+		if err != nil {
+			return errno.ErrUserNotFound
+		}
+		if err := CheckStatus(userM); err != nil {
+			return err
+		}
+
+		tokenStr, expireAt, err := token.SignSession(rtM.UserID, userM.TenantID, rtM.FamilyID)
+		if err != nil {
+			return errno.ErrSignToken
+		}
+
+		refreshToken, refreshExpireAt, err := b.issueRefreshToken(ctx, rtM.UserID, rtM.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		// 会话随刷新令牌的轮换延长有效期
+		if err := b.store.Session().Extend(ctx, rtM.FamilyID, refreshExpireAt); err != nil {
+			return errno.ErrDBWrite
+		}
+
+		resp.Token = tokenStr
+		resp.ExpireAt = timestamppb.New(expireAt)
+		resp.RefreshToken = refreshToken
+		resp.RefreshExpireAt = timestamppb.New(refreshExpireAt)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errno.ErrRefreshTokenReused) {
+			return nil, b.revokeRefreshTokenFamily(ctx, rtM)
+		}
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// 签发一个属于指定令牌族的刷新令牌, 数据库中只保存令牌的摘要.
+func (b *userBiz) issueRefreshToken(ctx context.Context, userID string, familyID string) (string, time.Time, error) {
+	refreshToken, expireAt, err := token.SignRefresh()
+	if err != nil {
+		return "", time.Time{}, errno.ErrSignToken
+	}
+
+	rtM := &model.RefreshTokenM{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: token.HashRefresh(refreshToken),
+		ExpiresAt: expireAt,
+	}
+	if err := b.store.RefreshToken().Create(ctx, rtM); err != nil {
+		return "", time.Time{}, errno.ErrDBWrite
+	}
+
+	return refreshToken, expireAt, nil
+}
+
+// 吊销刷新令牌所在的整个令牌族及其对应的会话, 并返回令牌被重放的错误.
+func (b *userBiz) revokeRefreshTokenFamily(ctx context.Context, rtM *model.RefreshTokenM) error {
+	log.W(ctx).Warnw("Refresh token reuse detected, revoking token family", "user", rtM.UserID, "family", rtM.FamilyID)
+	sessionM, err := b.store.Session().Get(ctx, where.F("sessionID", rtM.FamilyID))
+	if err != nil {
+		// 没有对应会话的令牌族只吊销刷新令牌
+		if err := b.store.RefreshToken().RevokeFamily(ctx, rtM.FamilyID); err != nil {
+			return errno.ErrDBWrite
+		}
+		return errno.ErrRefreshTokenReused
+	}
+
+	if err := b.revokeSession(ctx, sessionM); err != nil {
+		return err
+	}
+
+	return errno.ErrRefreshTokenReused
+}
+
+// 吊销当前访问令牌和当前会话, 如果请求中携带了刷新令牌, 同时吊销刷新令牌所在的令牌族.
+func (b *userBiz) Logout(ctx context.Context, rq *apiv1.LogoutRequest) (*apiv1.LogoutResponse, error) {
+	userID := contextx.UserID(ctx)
+	if tokenID := contextx.TokenID(ctx); tokenID != "" {
+		if err := b.denylist.Revoke(ctx, userID, tokenID, contextx.TokenExpireAt(ctx)); err != nil {
+			log.W(ctx).Errorw("Failed to revoke access token", "err", err)
+			return nil, errno.ErrDBWrite
+		}
+	}
+
+	if sessionID := contextx.SessionID(ctx); sessionID != "" {
+		sessionM, err := b.store.Session().Get(ctx, where.F("userID", userID, "sessionID", sessionID))
+		if err == nil {
+			if err := b.revokeSession(ctx, sessionM); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if rq.GetRefreshToken() != "" {
+		rtM, err := b.store.RefreshToken().Get(ctx, where.F("tokenHash", token.HashRefresh(rq.GetRefreshToken())))
+		// 只允许吊销属于当前用户的刷新令牌
+		if err != nil || rtM.UserID != userID {
+			return nil, errno.ErrRefreshTokenInvalid
+		}
+		if err := b.store.RefreshToken().RevokeFamily(ctx, rtM.FamilyID); err != nil {
+			return nil, errno.ErrDBWrite
+		}
+	}
+
+	return &apiv1.LogoutResponse{}, nil
+}
+
+// 吊销指定用户的全部访问令牌, 刷新令牌和个人访问令牌, 用户需要重新登录.
+func (b *userBiz) RevokeTokens(ctx context.Context, rq *apiv1.RevokeTokensRequest) (*apiv1.RevokeTokensResponse, error) {
+	if err := b.authorizeUser(ctx, rq.GetUserID(), "revoke-tokens"); err != nil {
+		return nil, err
+	}
+	if _, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	if err := b.revokeUserTokens(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	// 个人访问令牌不受密码修改影响, 只在吊销用户全部令牌时删除
+	if err := b.store.AccessToken().Delete(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, errno.ErrDBWrite
+	}
+
+	return &apiv1.RevokeTokensResponse{}, nil
+}
+
+// 吊销用户在当前时间之前签发的全部访问令牌, 以及用户所有的刷新令牌和会话.
+func (b *userBiz) revokeUserTokens(ctx context.Context, userID string) error {
+	if err := b.denylist.RevokeUser(ctx, userID, time.Now()); err != nil {
+		log.W(ctx).Errorw("Failed to revoke access tokens of user", "user", userID, "err", err)
+		return errno.ErrDBWrite
+	}
+
+	if _, err := b.store.RefreshToken().RevokeByUser(ctx, userID); err != nil {
+		return errno.ErrDBWrite
+	}
+
+	if _, err := b.store.Session().RevokeByUser(ctx, userID); err != nil {
+		return errno.ErrDBWrite
+	}
+
+	return nil
 }
 
 // 更新用户时, 不会调用BeforeUpdate钩子, 因此需要在修改密码时手动加密新密码.
 func (b *userBiz) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error) {
-	// where.T方法用于构造查询条件
-	// T函数自动为查询添加租户的隔离条件
-	// ???一个云端CRM(客户关系管理)系统可能为多个公司(租户)提供服务, 每个租户只能访问自己的客户数据
-	userM, err := b.store.User().Get(ctx, where.T(ctx))
+	// 模拟登录只用于复现问题, 不允许修改被模拟用户的密码
+	if contextx.Impersonated(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	if err := b.authorizeUser(ctx, rq.GetUserID(), "change-password"); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +465,17 @@ func (b *userBiz) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRe
 		return nil, err
 	}
 
+	// 密码修改后, 使用旧密码登录获得的令牌全部失效.
+	// 当前令牌可能与吊销操作在同一秒内签发, 因此再单独吊销一次
+	if err := b.revokeUserTokens(ctx, userM.UserID); err != nil {
+		return nil, err
+	}
+	if tokenID := contextx.TokenID(ctx); tokenID != "" {
+		if err := b.denylist.Revoke(ctx, userM.UserID, tokenID, contextx.TokenExpireAt(ctx)); err != nil {
+			return nil, errno.ErrDBWrite
+		}
+	}
+
 	return &apiv1.ChangePasswordResponse{}, nil
 }
 
@@ -118,22 +484,40 @@ func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*api
 	// 使用copier的Copy函数给目标结构体变量userM赋值
 	_ = copier.Copy(&userM, rq)
 
+	// 注册的用户可以选择开放注册的租户(由校验保证), 已登录用户创建的用户总是属于当前用户所在的租户
+	userM.TenantID = rq.GetTenantID()
+	if tenantID := contextx.TenantID(ctx); tenantID != "" {
+		userM.TenantID = tenantID
+	}
+	if userM.TenantID == "" {
+		userM.TenantID = known.DefaultTenant
+	}
+
 	// b.store.User().Create(ctx, &userM)将用户保存在数据库中
 	if err := b.store.User().Create(ctx, &userM); err != nil {
 		return nil, err
 	}
 
-	// 给用户添加普通用户role::user角色
-	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser); err != nil {
+	// 给用户添加所属租户中的普通用户role::user角色
+	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser, userM.TenantID); err != nil {
 		log.W(ctx).Errorw("Failed to add grouping policy for user", "user", userM.UserID, "role", known.RoleUser)
-		return nil, errno.ErrAddRole.WithMessage(err.Error())
+		return nil, errno.ErrAddRole.WithMessage("%s", err.Error())
+	}
+
+	// 用户已经创建成功, 验证邮件发送失败时用户可以重新申请发送, 因此只记录日志
+	if err := b.sendVerification(ctx, &userM); err != nil {
+		log.W(ctx).Errorw("Failed to send verification email", "user", userM.UserID, "err", err)
 	}
 
 	return &apiv1.CreateUserResponse{UserID: userM.UserID}, nil
 }
 
 func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*apiv1.UpdateUserResponse, error) {
-	userM, err := b.store.User().Get(ctx, where.T(ctx))
+	if err := b.authorizeUser(ctx, rq.GetUserID(), "update"); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
 	if err != nil {
 		return nil, err
 	}
@@ -142,41 +526,112 @@ func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*api
 	if rq.Username != nil {
 		userM.Username = rq.GetUsername()
 	}
-	if rq.Email != nil {
+	// 修改邮箱后需要重新验证新邮箱
+	emailChanged := rq.Email != nil && rq.GetEmail() != userM.Email
+	if emailChanged {
 		userM.Email = rq.GetEmail()
+		userM.EmailVerified = false
+		userM.VerifiedAt = nil
 	}
 	if rq.Nickname != nil {
 		userM.Nickname = rq.GetNickname()
 	}
 	if rq.Phone != nil {
-		userM.Phone = rq.GetPhone()
+		userM.Phone = rq.Phone
 	}
 
 	if err := b.store.User().Update(ctx, userM); err != nil {
 		return nil, err
 	}
 
+	if emailChanged {
+		if err := b.sendVerification(ctx, userM); err != nil {
+			log.W(ctx).Errorw("Failed to send verification email", "user", userM.UserID, "err", err)
+		}
+	}
+
 	return &apiv1.UpdateUserResponse{}, nil
 }
 
+// Delete 删除用户, 用户的博客, 策略和令牌在同一个事务中删除, 任意一步失败时全部回滚.
+// 响应中返回随用户一起删除的数据的数量.
 func (b *userBiz) Delete(ctx context.Context, rq *apiv1.DeleteUserRequest) (*apiv1.DeleteUserResponse, error) {
-	// 只有root用户可以删除用户
-	// 这里不用where.T()因为where.T()会查询root自己
-	// 因为where.T()会添加条件, 只会针对特定的数据进行查询
-	if err := b.store.User().Delete(ctx, where.F("userID", rq.GetUserID())); err != nil {
+	// 模拟登录只用于复现问题, 不允许删除用户
+	if contextx.Impersonated(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	if err := b.authorizeUser(ctx, rq.GetUserID(), "delete"); err != nil {
+		return nil, err
+	}
+	ctx, err := b.tenantContext(ctx, "user", "delete")
+	if err != nil {
 		return nil, err
 	}
 
-	if _, err := b.authz.RemoveGroupingPolicy(rq.GetUserID(), known.RoleUser); err != nil {
-		log.W(ctx).Errorw("Failed to remove grouping policy for user", "user", rq.GetUserID(), "role", known.RoleUser)
-		return nil, errno.ErrRemoveRole.WithMessage(err.Error())
+	// 这里不用where.T()因为where.T()会查询当前用户自己
+	// 因为where.T()会添加条件, 只会针对特定的数据进行查询
+	userID := rq.GetUserID()
+	if _, err := b.store.User().Get(ctx, where.F("userID", userID)); err != nil {
+		return nil, errno.ErrUserNotFound
 	}
 
-	return &apiv1.DeleteUserResponse{}, nil
+	var resp apiv1.DeleteUserResponse
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		// 先删除用户, 随用户一起删除的博客的删除时间不早于用户, 恢复用户时据此恢复博客
+		if err := b.store.User().Delete(ctx, where.F("userID", userID)); err != nil {
+			return err
+		}
+
+		var err error
+		if resp.DeletedPosts, err = b.store.Post().DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+		if resp.DeletedPolicies, err = b.store.CasbinRule().DeleteBySubject(ctx, userID); err != nil {
+			return err
+		}
+		if resp.DeletedAccessTokens, err = b.store.AccessToken().DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+		if resp.RevokedRefreshTokens, err = b.store.RefreshToken().RevokeByUser(ctx, userID); err != nil {
+			return err
+		}
+		if resp.RevokedSessions, err = b.store.Session().RevokeByUser(ctx, userID); err != nil {
+			return err
+		}
+
+		// 内存黑名单不参与事务, 放在最后一步, 事务回滚时只会多出一条该用户的吊销记录
+		return b.denylist.RevokeUser(ctx, userID, time.Now())
+	})
+	if err != nil {
+		log.W(ctx).Errorw("Failed to delete user", "user", userID, "err", err)
+		return nil, errno.ErrDBWrite
+	}
+
+	// 数据库中的策略已经在事务中删除, 提交后再同步授权器并通知其他实例, 同步失败时重新加载策略即可恢复一致
+	if _, err := b.authz.RemoveFilteredGroupingPolicy(0, userID); err != nil {
+		log.W(ctx).Errorw("Failed to remove grouping policies of deleted user", "user", userID, "err", err)
+	}
+	if _, err := b.authz.RemoveFilteredPolicy(0, userID); err != nil {
+		log.W(ctx).Errorw("Failed to remove policies of deleted user", "user", userID, "err", err)
+	}
+
+	log.W(ctx).Infow("User deleted", "targetUserID", userID, "posts", resp.DeletedPosts, "policies", resp.DeletedPolicies,
+		"accessTokens", resp.DeletedAccessTokens, "refreshTokens", resp.RevokedRefreshTokens, "sessions", resp.RevokedSessions)
+
+	return &resp, nil
 }
 
 func (b *userBiz) Get(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.GetUserResponse, error) {
-	userM, err := b.store.User().Get(ctx, where.T(ctx))
+	if err := b.authorizeUser(ctx, rq.GetUserID(), "get"); err != nil {
+		return nil, err
+	}
+	ctx, err := b.tenantContext(ctx, "user", "get")
+	if err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
 	if err != nil {
 		return nil, err
 	}
@@ -185,57 +640,32 @@ func (b *userBiz) Get(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.Get
 
 func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error) {
 	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
-	if contextx.Username(ctx) != known.AdminUsername {
-		whr.T(ctx)
+	if err := b.scopeUsers(ctx, whr); err != nil {
+		return nil, err
 	}
+	filterUsers(rq, whr)
+
 	count, userList, err := b.store.User().List(ctx, whr)
 	if err != nil {
 		return nil, err
 	}
 
-	// 线程安全的map存储并发查询结果
-	var m sync.Map
-	// 创建errgroup管理并发执行的goroutine
-	// 普通的goroutine没有同步机制, 可能导致goroutine泄漏或资源竞争
-	// 纯 WaitGroup和goroutine都需要额外的错误处理和📱机制
-	eg, ctx := errgroup.WithContext(ctx)
-
-	// 设置并发限制
-	eg.SetLimit(known.MaxErrGroupConcurrency)
-
-	// 并发统计用户的博客数
+	userIDs := make([]string, 0, len(userList))
 	for _, user := range userList {
-		eg.Go(func() error {
-			select {
-			case <-ctx.Done():
-				return nil
-			default:
-				count, _, err := b.store.Post().List(ctx, where.T(ctx))
-				if err != nil {
-					return err
-				}
-				// 讲存储层用户模型userM转换为API层的用户对象(apiv1.User)
-				converted := conversion.UserModelToUserV1(user)
-				converted.PostCount = count
-				// 将转换后的用户对象存储到syncMap中, id为key
-				m.Store(user.ID, converted)
-
-				return nil
-			}
-		})
+		userIDs = append(userIDs, user.UserID)
 	}
-
-	// 等待并发任务完成
-	if err := eg.Wait(); err != nil {
-		log.W(ctx).Errorw("Failed to wait all function calls returned", "err", err)
-		return nil, err
+	// 一次分组查询统计当前页所有用户的博客数
+	postCounts, err := b.store.Post().CountByUserIDs(ctx, userIDs)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to count posts of users", "err", err)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
 	}
 
-	// 构建Response
 	users := make([]*apiv1.User, 0, len(userList))
-	for _, item := range userList {
-		user, _ := m.Load(item.ID)
-		users = append(users, user.(*apiv1.User))
+	for _, user := range userList {
+		converted := conversion.UserModelToUserV1(user)
+		converted.PostCount = postCounts[user.UserID]
+		users = append(users, converted)
 	}
 
 	log.W(ctx).Debugw("Get users from backend storage", "count", len(users))
@@ -243,31 +673,85 @@ func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.L
 	return &apiv1.ListUserResponse{TotalCount: count, Users: users}, nil
 }
 
-// ListWithBadPerformance 是性能较差的实现方式(已废弃).
-func (b *userBiz) ListWithBadPerformance(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error) {
-	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
-	if contextx.Username(ctx) != known.AdminUsername {
-		whr.T(ctx)
+// 判断当前用户能否对指定用户执行操作, 只有用户本人和被授权管理当前租户所有用户的管理员可以操作.
+func (b *userBiz) authorizeUser(ctx context.Context, userID string, act string) error {
+	allowed, err := b.authz.AuthorizeOwner(contextx.UserID(ctx), contextx.TenantID(ctx), userID, "user", act)
+	if err != nil {
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` cannot %s user `%s`", contextx.UserID(ctx), act, userID)
 	}
 
-	count, userList, err := b.store.User().List(ctx, whr)
+	return nil
+}
+
+// 返回访问用户时使用的上下文, 存储层默认只能访问当前租户中的用户.
+// 在全部租户*中被授权对任意用户的资源obj执行操作act的全局管理员可以访问其他租户中的用户, 使用不按租户隔离数据的上下文.
+func (b *userBiz) tenantContext(ctx context.Context, obj string, act string) (context.Context, error) {
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), auth.AllDomains, obj, act)
 	if err != nil {
-		return nil, err
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if allowed {
+		return store.WithoutTenant(ctx), nil
 	}
 
-	users := make([]*apiv1.User, 0, len(userList))
-	for _, user := range userList {
-		count, _, err := b.store.Post().List(ctx, where.T(ctx))
-		if err != nil {
-			return nil, err
-		}
+	return ctx, nil
+}
 
-		converted := conversion.UserModelToUserV1(user)
-		converted.PostCount = count
-		users = append(users, converted)
+// 普通用户只能查询到自己, 被授权管理当前租户所有用户的管理员可以查询租户中的全部用户.
+func (b *userBiz) scopeUsers(ctx context.Context, whr *where.Options) error {
+	allowed, err := b.authz.AuthorizeAny(contextx.UserID(ctx), contextx.TenantID(ctx), "user", "list")
+	if err != nil {
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		whr.T(ctx)
 	}
 
-	log.W(ctx).Debugw("Get users from backend storage", "count", len(users))
+	return nil
+}
 
-	return &apiv1.ListUserResponse{TotalCount: count, Users: users}, nil
+// 按用户名前缀, 邮箱域名, 创建时间, 用户状态和邮箱验证状态过滤用户, 并按请求的字段排序.
+// 排序字段相同时按创建顺序倒序排列, 保证分页结果稳定.
+func filterUsers(rq *apiv1.ListUserRequest, whr *where.Options) {
+	if rq.UsernamePrefix != nil {
+		whr.Q("username LIKE ? ESCAPE '!'", escapeLike(rq.GetUsernamePrefix())+"%")
+	}
+	if rq.EmailDomain != nil {
+		whr.Q("email LIKE ? ESCAPE '!'", "%@"+escapeLike(rq.GetEmailDomain()))
+	}
+	if rq.CreatedAfter != nil {
+		whr.Q("createdAt >= ?", time.Unix(rq.GetCreatedAfter(), 0))
+	}
+	if rq.CreatedBefore != nil {
+		whr.Q("createdAt < ?", time.Unix(rq.GetCreatedBefore(), 0))
+	}
+	if rq.Status != nil {
+		whr.F("status", rq.GetStatus())
+	}
+	if rq.EmailVerified != nil {
+		whr.F("emailVerified", rq.GetEmailVerified())
+	}
+
+	if rq.SortBy == nil {
+		return
+	}
+	column := clause.Column{Name: rq.GetSortBy()}
+	if rq.GetSortBy() == known.UserSortByPostCount {
+		column = clause.Column{Name: postCountSQL, Raw: true}
+	}
+	whr.C(clause.OrderBy{Columns: []clause.OrderByColumn{{Column: column, Desc: rq.GetSortOrder() == known.SortOrderDesc}}})
+}
+
+// 统计用户博客数的子查询, 用于按博客数排序, 已删除的博客不计入.
+var postCountSQL = fmt.Sprintf("(SELECT COUNT(*) FROM `%s` WHERE `%s`.`userID` = `%s`.`userID` AND `%s`.`deletedAt` IS NULL)",
+	model.TableNamePostM, model.TableNamePostM, model.TableNameUserM, model.TableNamePostM)
+
+// 转义LIKE中的通配符, 配合ESCAPE '!'使用.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
 }
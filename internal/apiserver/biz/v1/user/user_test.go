@@ -0,0 +1,571 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/pkg/denylist"
+	"miniblog/internal/apiserver/pkg/lockout"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/token"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// 测试中使用的管理员, 被授权管理全部租户.
+	testAdminID = "user-admin"
+	// 测试用户的密码.
+	testPassword = "miniblog1234"
+)
+
+// 用于生成不重复的用户名和手机号.
+var testUserSeq atomic.Int64
+
+// 创建基于SQLite内存数据库的用户业务对象, store.NewStore只会初始化一次, 各个测试共享同一个数据库, 因此测试数据不能重复.
+// 与服务器相同, 按用户和租户隔离数据.
+func newTestBiz(t *testing.T) (*userBiz, store.IStore) {
+	where.RegisterTenant("userID", contextx.UserID)
+	store.RegisterTenant("tenantID", contextx.TenantID)
+
+	db, err := gorm.Open(sqlite.Open("file:biz_user_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+
+	s := store.NewStore(db)
+	db = s.DB(context.Background())
+	require.NoError(t, db.AutoMigrate(&model.UserM{}, &model.PostM{}, &model.CasbinRuleM{}, &model.RefreshTokenM{},
+		&model.AccessTokenM{}, &model.UserTOTPM{}, &model.RecoveryCodeM{}, &model.LoginAttemptM{}, &model.PasswordResetM{},
+		&model.EmailVerificationM{}, &model.SessionM{}, &model.OIDCLoginM{}, &model.UserIdentityM{}))
+
+	authz, err := auth.NewAuthz(db)
+	require.NoError(t, err)
+	_, err = authz.AddPolicy(known.RoleAdmin, auth.AllDomains, "*", "*", "allow")
+	require.NoError(t, err)
+	_, err = authz.AddGroupingPolicy(testAdminID, known.RoleAdmin, auth.AllDomains)
+	require.NoError(t, err)
+
+	token.Init("Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5", known.XUserID, time.Hour, token.WithRefreshExpiration(24*time.Hour))
+
+	return New(s, authz, denylist.NewMemory(), lockout.New(lockout.NewMemory()), &Options{
+		Mailer:                      mail.NewWriter(io.Discard, "noreply@miniblog.test"),
+		PasswordResetExpiration:     time.Hour,
+		EmailVerificationExpiration: time.Hour,
+	}), s
+}
+
+// 返回管理员在默认租户中发起请求的上下文.
+func adminContext() context.Context {
+	ctx := contextx.WithUserID(context.Background(), testAdminID)
+	return contextx.WithTenantID(ctx, known.DefaultTenant)
+}
+
+// 返回用户本人发起请求的上下文.
+func userContext(userID string, tenantID string) context.Context {
+	ctx := contextx.WithUserID(context.Background(), userID)
+	return contextx.WithTenantID(ctx, tenantID)
+}
+
+// 在指定租户中注册一个用户, 返回用户名和用户ID.
+func createTestUser(t *testing.T, b *userBiz, tenantID string) (string, string) {
+	return createNamedTestUser(t, b, "tester", tenantID)
+}
+
+// 在指定租户中注册一个用户名以prefix开头的用户, 返回用户名和用户ID.
+func createNamedTestUser(t *testing.T, b *userBiz, prefix string, tenantID string) (string, string) {
+	seq := testUserSeq.Add(1)
+	username := fmt.Sprintf("%s%d", prefix, seq)
+	resp, err := b.Create(context.Background(), &apiv1.CreateUserRequest{
+		Username: username,
+		Password: testPassword,
+		Email:    username + "@miniblog.test",
+		Phone:    fmt.Sprintf("1880000%04d", seq),
+		TenantID: &tenantID,
+	})
+	require.NoError(t, err)
+
+	return username, resp.GetUserID()
+}
+
+// 为用户创建count篇博客, 返回博客ID.
+func createTestPosts(t *testing.T, s store.IStore, userID string, count int) []string {
+	postIDs := make([]string, 0, count)
+	for i := range count {
+		postM := &model.PostM{UserID: userID, TenantID: known.DefaultTenant, Title: fmt.Sprintf("post %d", i), Content: "content"}
+		require.NoError(t, s.Post().Create(context.Background(), postM))
+		postIDs = append(postIDs, postM.PostID)
+	}
+
+	return postIDs
+}
+
+// 使用用户名和测试密码登录.
+func loginTestUser(t *testing.T, b *userBiz, username string) *apiv1.LoginResponse {
+	resp, err := b.Login(context.Background(), &apiv1.LoginRequest{Username: username, Password: testPassword})
+	require.NoError(t, err)
+
+	return resp
+}
+
+// captureMailer 将发送的邮件保存到通道中, 用于在测试中读取邮件里的链接.
+type captureMailer struct {
+	messages chan *mail.Message
+}
+
+func (m *captureMailer) Send(ctx context.Context, msg *mail.Message) error {
+	m.messages <- msg
+	return nil
+}
+
+// 使用captureMailer替换业务对象的邮件发送器.
+func captureTestMail(b *userBiz) *captureMailer {
+	m := &captureMailer{messages: make(chan *mail.Message, 10)}
+	b.opts.Mailer = m
+
+	return m
+}
+
+// 等待下一封指定主题的邮件, 返回邮件和邮件链接中的令牌, 其他主题的邮件(例如注册时的验证邮件)会被跳过.
+func (m *captureMailer) next(t *testing.T, subject string) (*mail.Message, string) {
+	for {
+		select {
+		case msg := <-m.messages:
+			if msg.Subject != subject {
+				continue
+			}
+			match := regexp.MustCompile(`token=(\S+)`).FindStringSubmatch(msg.Body)
+			require.Len(t, match, 2)
+			mailToken, err := url.QueryUnescape(match[1])
+			require.NoError(t, err)
+			return msg, mailToken
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no email was sent", "subject: %s", subject)
+			return nil, ""
+		}
+	}
+}
+
+// 判断短时间内没有发送邮件.
+func (m *captureMailer) assertNoMail(t *testing.T) {
+	select {
+	case msg := <-m.messages:
+		assert.Failf(t, "unexpected email", "subject: %s", msg.Subject)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// 返回用户唯一的会话.
+func getTestSession(t *testing.T, s store.IStore, userID string) *model.SessionM {
+	sessionM, err := s.Session().Get(context.Background(), where.F("userID", userID))
+	require.NoError(t, err)
+
+	return sessionM
+}
+
+func TestRefreshToken(t *testing.T) {
+	b, s := newTestBiz(t)
+	ctx := context.Background()
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	login := loginTestUser(t, b, username)
+
+	refreshed, err := b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+	require.NoError(t, err)
+	assert.NotEmpty(t, refreshed.GetToken())
+	assert.NotEqual(t, login.GetRefreshToken(), refreshed.GetRefreshToken())
+
+	// 轮换后的刷新令牌被再次使用时, 整个令牌族和对应的会话都会被吊销
+	_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+	assert.ErrorIs(t, err, errno.ErrRefreshTokenReused)
+	_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: refreshed.GetRefreshToken()})
+	assert.ErrorIs(t, err, errno.ErrRefreshTokenReused)
+
+	sessionM := getTestSession(t, s, userID)
+	assert.NotNil(t, sessionM.RevokedAt)
+	revoked, err := b.denylist.IsRevoked(ctx, userID, sessionM.SessionID, time.Now())
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	count, tokenList, err := s.RefreshToken().List(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	for _, rtM := range tokenList {
+		assert.NotNil(t, rtM.RevokedAt)
+	}
+
+	// 重新登录会开启新的令牌族, 不受已吊销的令牌族影响
+	relogin := loginTestUser(t, b, username)
+	_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: relogin.GetRefreshToken()})
+	assert.NoError(t, err)
+
+	_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: "invalid"})
+	assert.ErrorIs(t, err, errno.ErrRefreshTokenInvalid)
+}
+
+func TestRefreshTokenExpired(t *testing.T) {
+	b, s := newTestBiz(t)
+	ctx := context.Background()
+	_, userID := createTestUser(t, b, known.DefaultTenant)
+
+	refreshToken, _, err := token.SignRefresh()
+	require.NoError(t, err)
+	require.NoError(t, s.RefreshToken().Create(ctx, &model.RefreshTokenM{
+		UserID:    userID,
+		FamilyID:  "family-expired",
+		TokenHash: token.HashRefresh(refreshToken),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	_, err = b.RefreshToken(ctx, &apiv1.RefreshTokenRequest{RefreshToken: refreshToken})
+	assert.ErrorIs(t, err, errno.ErrRefreshTokenExpired)
+}
+
+func TestLogout(t *testing.T) {
+	b, s := newTestBiz(t)
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	login := loginTestUser(t, b, username)
+	sessionM := getTestSession(t, s, userID)
+
+	ctx := contextx.WithSessionID(userContext(userID, known.DefaultTenant), sessionM.SessionID)
+	ctx = contextx.WithTokenID(ctx, "token-logout")
+	ctx = contextx.WithTokenExpireAt(ctx, time.Now().Add(time.Hour))
+	_, err := b.Logout(ctx, &apiv1.LogoutRequest{RefreshToken: login.GetRefreshToken()})
+	require.NoError(t, err)
+
+	// 登出后访问令牌, 会话和刷新令牌都不能再使用
+	revoked, err := b.denylist.IsRevoked(ctx, userID, "token-logout", time.Now())
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	assert.NotNil(t, getTestSession(t, s, userID).RevokedAt)
+	_, err = b.RefreshToken(context.Background(), &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+	assert.ErrorIs(t, err, errno.ErrRefreshTokenReused)
+
+	// 不能吊销其他用户的刷新令牌
+	otherName, otherID := createTestUser(t, b, known.DefaultTenant)
+	other := loginTestUser(t, b, otherName)
+	_, err = b.Logout(userContext(userID, known.DefaultTenant), &apiv1.LogoutRequest{RefreshToken: other.GetRefreshToken()})
+	assert.ErrorIs(t, err, errno.ErrRefreshTokenInvalid)
+	assert.Nil(t, getTestSession(t, s, otherID).RevokedAt)
+	_, err = b.RefreshToken(context.Background(), &apiv1.RefreshTokenRequest{RefreshToken: other.GetRefreshToken()})
+	assert.NoError(t, err)
+}
+
+func TestLoginAttempts(t *testing.T) {
+	b, _ := newTestBiz(t)
+	ctx := contextx.WithClientIP(context.Background(), "192.0.2.1")
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+
+	// 正确的登录撤销本次尝试的计数, 不会触发限制
+	for range lockout.DefaultUsernamePolicy.FreeAttempts + 2 {
+		loginTestUser(t, b, username)
+	}
+
+	for range lockout.DefaultUsernamePolicy.FreeAttempts + 1 {
+		_, err := b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: "wrong-password"})
+		assert.ErrorIs(t, err, errno.ErrInvalidCredentials)
+	}
+	// 超过免限制次数后, 即使密码正确也需要等待
+	_, err := b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: testPassword})
+	assert.ErrorIs(t, err, errno.ErrTooManyLoginAttempts)
+
+	// 管理员解锁后可以立即登录
+	_, err = b.Unlock(adminContext(), &apiv1.UnlockUserRequest{UserID: userID})
+	require.NoError(t, err)
+	_, err = b.Login(ctx, &apiv1.LoginRequest{Username: username, Password: testPassword})
+	assert.NoError(t, err)
+}
+
+// 以管理员身份列出用户, 返回用户ID.
+func listTestUsers(t *testing.T, b *userBiz, rq *apiv1.ListUserRequest) []string {
+	rq.Limit = 10
+	resp, err := b.List(adminContext(), rq)
+	require.NoError(t, err)
+
+	userIDs := make([]string, 0, len(resp.GetUsers()))
+	for _, user := range resp.GetUsers() {
+		userIDs = append(userIDs, user.GetUserID())
+	}
+	return userIDs
+}
+
+// 直接修改用户记录, 用于构造不同创建时间, 邮箱和验证状态的用户.
+func updateTestUser(t *testing.T, s store.IStore, userID string, column string, value any) {
+	require.NoError(t, s.DB(context.Background()).Model(&model.UserM{}).Where("userID = ?", userID).Update(column, value).Error)
+}
+
+func TestListFilter(t *testing.T) {
+	b, s := newTestBiz(t)
+	// 前缀中的下划线需要转义, 否则会匹配任意字符
+	prefix := fmt.Sprintf("filter%d_", testUserSeq.Add(1))
+	_, early := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	_, verified := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	_, otherDomain := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	createNamedTestUser(t, b, strings.TrimSuffix(prefix, "_")+"x", known.DefaultTenant)
+
+	cutoff := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	updateTestUser(t, s, early, "createdAt", cutoff.AddDate(-1, 0, 0))
+	updateTestUser(t, s, verified, "emailVerified", true)
+	updateTestUser(t, s, otherDomain, "email", prefix+"@example.org")
+	updateTestUser(t, s, early, "status", known.UserStatusDisabled)
+
+	tests := []struct {
+		name string
+		rq   *apiv1.ListUserRequest
+		want []string
+	}{
+		{"username prefix", &apiv1.ListUserRequest{}, []string{early, verified, otherDomain}},
+		{"email domain", &apiv1.ListUserRequest{EmailDomain: ptr.To("example.org")}, []string{otherDomain}},
+		{"created before", &apiv1.ListUserRequest{CreatedBefore: ptr.To(cutoff.Unix())}, []string{early}},
+		{"created after", &apiv1.ListUserRequest{CreatedAfter: ptr.To(cutoff.Unix())}, []string{verified, otherDomain}},
+		{"email verified", &apiv1.ListUserRequest{EmailVerified: ptr.To(true)}, []string{verified}},
+		{"email unverified", &apiv1.ListUserRequest{EmailVerified: ptr.To(false)}, []string{early, otherDomain}},
+		{"status", &apiv1.ListUserRequest{Status: ptr.To(known.UserStatusDisabled)}, []string{early}},
+		{"status and email verified", &apiv1.ListUserRequest{Status: ptr.To(known.UserStatusActive), EmailVerified: ptr.To(false)}, []string{otherDomain}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rq.UsernamePrefix = ptr.To(prefix)
+			assert.ElementsMatch(t, tt.want, listTestUsers(t, b, tt.rq))
+		})
+	}
+}
+
+func TestListSort(t *testing.T) {
+	b, s := newTestBiz(t)
+	prefix := fmt.Sprintf("sorter%d_", testUserSeq.Add(1))
+	_, first := createNamedTestUser(t, b, prefix+"c", known.DefaultTenant)
+	_, second := createNamedTestUser(t, b, prefix+"a", known.DefaultTenant)
+	_, third := createNamedTestUser(t, b, prefix+"b", known.DefaultTenant)
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, userID := range []string{first, second, third} {
+		updateTestUser(t, s, userID, "createdAt", createdAt.Add(time.Duration(i)*time.Hour))
+	}
+
+	tests := []struct {
+		sortBy    string
+		sortOrder *string
+		want      []string
+	}{
+		{known.UserSortByCreatedAt, nil, []string{first, second, third}},
+		{known.UserSortByCreatedAt, ptr.To(known.SortOrderDesc), []string{third, second, first}},
+		{known.UserSortByUsername, ptr.To(known.SortOrderAsc), []string{second, third, first}},
+		{known.UserSortByUsername, ptr.To(known.SortOrderDesc), []string{first, third, second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy+"/"+ptr.Deref(tt.sortOrder, "default"), func(t *testing.T) {
+			userIDs := listTestUsers(t, b, &apiv1.ListUserRequest{
+				UsernamePrefix: ptr.To(prefix),
+				SortBy:         ptr.To(tt.sortBy),
+				SortOrder:      tt.sortOrder,
+			})
+			assert.Equal(t, tt.want, userIDs)
+		})
+	}
+
+	// 没有指定排序字段时按创建顺序倒序排列
+	assert.Equal(t, []string{third, second, first}, listTestUsers(t, b, &apiv1.ListUserRequest{UsernamePrefix: ptr.To(prefix)}))
+}
+
+func TestListPostCount(t *testing.T) {
+	b, s := newTestBiz(t)
+	ctx := adminContext()
+	prefix := fmt.Sprintf("lister%d-", testUserSeq.Add(1))
+
+	// 各用户的博客数互不相同, 已删除的博客计入时排序结果会发生变化
+	_, withDeleted := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	_, withoutPosts := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	_, withOne := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	_, withThree := createNamedTestUser(t, b, prefix, known.DefaultTenant)
+	postIDs := createTestPosts(t, s, withDeleted, 4)
+	require.NoError(t, s.Post().Delete(context.Background(), where.F("postID", postIDs[:2])))
+	createTestPosts(t, s, withOne, 1)
+	createTestPosts(t, s, withThree, 3)
+
+	counts, err := s.Post().CountByUserIDs(context.Background(), []string{withDeleted, withoutPosts, withOne, withThree})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{withDeleted: 2, withOne: 1, withThree: 3}, counts)
+
+	counts, err = s.Post().CountByUserIDs(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+
+	for _, order := range []string{known.SortOrderDesc, known.SortOrderAsc} {
+		t.Run(order, func(t *testing.T) {
+			resp, err := b.List(ctx, &apiv1.ListUserRequest{
+				Limit:          10,
+				UsernamePrefix: ptr.To(prefix),
+				SortBy:         ptr.To(known.UserSortByPostCount),
+				SortOrder:      ptr.To(order),
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, 4, resp.GetTotalCount())
+
+			var userIDs []string
+			var postCounts []int64
+			for _, user := range resp.GetUsers() {
+				userIDs = append(userIDs, user.GetUserID())
+				postCounts = append(postCounts, user.GetPostCount())
+			}
+			// 排序使用的子查询与返回的博客数一致
+			if order == known.SortOrderDesc {
+				assert.Equal(t, []string{withThree, withDeleted, withOne, withoutPosts}, userIDs)
+				assert.Equal(t, []int64{3, 2, 1, 0}, postCounts)
+			} else {
+				assert.Equal(t, []string{withoutPosts, withOne, withDeleted, withThree}, userIDs)
+				assert.Equal(t, []int64{0, 1, 2, 3}, postCounts)
+			}
+		})
+	}
+}
+
+// failingStore 在吊销刷新令牌时返回错误, 用于验证删除用户的事务会整体回滚.
+type failingStore struct {
+	store.IStore
+}
+
+func (s *failingStore) RefreshToken() store.RefreshTokenStore {
+	return &failingRefreshTokenStore{s.IStore.RefreshToken()}
+}
+
+type failingRefreshTokenStore struct {
+	store.RefreshTokenStore
+}
+
+func (s *failingRefreshTokenStore) RevokeByUser(ctx context.Context, userID string) (int64, error) {
+	return 0, errors.New("injected failure")
+}
+
+// 创建用户删除时需要一起清理的博客, 策略, 个人访问令牌, 会话和刷新令牌.
+func createTestUserData(t *testing.T, b *userBiz, s store.IStore) string {
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	createTestPosts(t, s, userID, 3)
+	_, err := b.authz.AddPolicy(userID, known.DefaultTenant, "post", "list", "allow")
+	require.NoError(t, err)
+	require.NoError(t, s.AccessToken().Create(context.Background(), &model.AccessTokenM{
+		UserID:    userID,
+		Name:      "ci",
+		TokenHash: token.HashRefresh(userID),
+		Actions:   "post:list",
+	}))
+	// 轮换后的刷新令牌已经失效, 删除时只吊销尚未失效的令牌
+	login := loginTestUser(t, b, username)
+	_, err = b.RefreshToken(context.Background(), &apiv1.RefreshTokenRequest{RefreshToken: login.GetRefreshToken()})
+	require.NoError(t, err)
+
+	return userID
+}
+
+// 统计主体为subject的策略和角色继承规则的数量.
+func countTestPolicies(t *testing.T, s store.IStore, subject string) int64 {
+	count, _, err := s.CasbinRule().List(context.Background(), where.F("v0", subject))
+	require.NoError(t, err)
+
+	return count
+}
+
+func TestDelete(t *testing.T) {
+	b, s := newTestBiz(t)
+	ctx := context.Background()
+	userID := createTestUserData(t, b, s)
+
+	resp, err := b.Delete(adminContext(), &apiv1.DeleteUserRequest{UserID: userID})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, resp.GetDeletedPosts())
+	assert.EqualValues(t, 2, resp.GetDeletedPolicies())
+	assert.EqualValues(t, 1, resp.GetDeletedAccessTokens())
+	assert.EqualValues(t, 1, resp.GetRevokedRefreshTokens())
+	assert.EqualValues(t, 1, resp.GetRevokedSessions())
+
+	_, err = s.User().Get(ctx, where.F("userID", userID))
+	assert.Error(t, err)
+	postCounts, err := s.Post().CountByUserIDs(ctx, []string{userID})
+	require.NoError(t, err)
+	assert.Empty(t, postCounts)
+	assert.Zero(t, countTestPolicies(t, s, userID))
+	hasRole, err := b.authz.HasGroupingPolicy(userID, known.RoleUser, known.DefaultTenant)
+	require.NoError(t, err)
+	assert.False(t, hasRole)
+	tokenCount, _, err := s.AccessToken().List(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+	assert.Zero(t, tokenCount)
+	assert.NotNil(t, getTestSession(t, s, userID).RevokedAt)
+	revoked, err := b.denylist.IsRevoked(ctx, userID, "any-token", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	_, err = b.Delete(userContext(userID, known.DefaultTenant), &apiv1.DeleteUserRequest{UserID: userID})
+	assert.Error(t, err)
+}
+
+func TestDeleteRollback(t *testing.T) {
+	b, s := newTestBiz(t)
+	ctx := context.Background()
+	userID := createTestUserData(t, b, s)
+
+	// 吊销刷新令牌是事务中较晚的一步, 此前删除的用户, 博客, 策略和个人访问令牌都需要回滚
+	failing := New(&failingStore{s}, b.authz, b.denylist, b.guard, b.opts)
+	_, err := failing.Delete(adminContext(), &apiv1.DeleteUserRequest{UserID: userID})
+	assert.ErrorIs(t, err, errno.ErrDBWrite)
+
+	_, err = s.User().Get(ctx, where.F("userID", userID))
+	assert.NoError(t, err)
+	postCounts, err := s.Post().CountByUserIDs(ctx, []string{userID})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{userID: 3}, postCounts)
+	assert.EqualValues(t, 2, countTestPolicies(t, s, userID))
+	hasRole, err := b.authz.HasGroupingPolicy(userID, known.RoleUser, known.DefaultTenant)
+	require.NoError(t, err)
+	assert.True(t, hasRole)
+	tokenCount, _, err := s.AccessToken().List(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, tokenCount)
+	assert.Nil(t, getTestSession(t, s, userID).RevokedAt)
+	revoked, err := b.denylist.IsRevoked(ctx, userID, "any-token", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestNonOwnerPermissionDenied(t *testing.T) {
+	b, s := newTestBiz(t)
+	_, owner := createTestUser(t, b, known.DefaultTenant)
+	_, other := createTestUser(t, b, known.DefaultTenant)
+
+	// 普通用户不能查看, 修改和删除其他用户
+	ctx := userContext(other, known.DefaultTenant)
+	_, err := b.Get(ctx, &apiv1.GetUserRequest{UserID: owner})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	_, err = b.Update(ctx, &apiv1.UpdateUserRequest{UserID: owner, Nickname: ptr.To("changed")})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	_, err = b.Delete(ctx, &apiv1.DeleteUserRequest{UserID: owner})
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+
+	// 用户没有被修改或删除, 用户本人可以正常查看
+	assert.NotEqual(t, "changed", getTestUser(t, s, owner).Nickname)
+	_, err = b.Get(userContext(owner, known.DefaultTenant), &apiv1.GetUserRequest{UserID: owner})
+	assert.NoError(t, err)
+}
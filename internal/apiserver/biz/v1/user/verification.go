@@ -0,0 +1,109 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/mail"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// VerifyEmail 使用验证令牌将用户的电子邮箱标记为已验证, 令牌只能使用一次.
+// 令牌签发后用户修改了邮箱时, 令牌对应的是旧邮箱, 不能用于验证新邮箱.
+func (b *userBiz) VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error) {
+	tokenHash := hashMailToken(rq.GetToken())
+	verificationM, err := b.store.EmailVerification().Get(ctx, where.F("tokenHash", tokenHash))
+	if err != nil {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", verificationM.UserID))
+	if err != nil || userM.Email != verificationM.Email {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		used, err := b.store.EmailVerification().MarkUsed(ctx, tokenHash, now)
+		if err != nil {
+			return errno.ErrDBWrite
+		}
+		if !used {
+			return errno.ErrVerificationTokenInvalid
+		}
+
+		userM.EmailVerified = true
+		userM.VerifiedAt = &now
+		if err := b.store.User().Update(ctx, userM); err != nil {
+			return errno.ErrDBWrite
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Email has been verified", "user", userM.UserID)
+	return &apiv1.VerifyEmailResponse{}, nil
+}
+
+// SendVerificationEmail 为当前用户重新发送验证邮件, 之前发送的验证链接随之失效.
+func (b *userBiz) SendVerificationEmail(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) (*apiv1.SendVerificationEmailResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.F("userID", contextx.UserID(ctx)))
+	if err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+	if userM.EmailVerified {
+		return nil, errno.ErrEmailAlreadyVerified
+	}
+
+	if err := b.sendVerification(ctx, userM); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.SendVerificationEmailResponse{}, nil
+}
+
+// 为用户当前的电子邮箱签发验证令牌, 并通过邮件发送验证链接.
+// 每个用户同一时间只保留最新的验证令牌.
+func (b *userBiz) sendVerification(ctx context.Context, userM *model.UserM) error {
+	verificationToken, err := newMailToken()
+	if err != nil {
+		return errno.ErrInternal
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.EmailVerification().Delete(ctx, where.F("userID", userM.UserID)); err != nil {
+			return err
+		}
+		return b.store.EmailVerification().Create(ctx, &model.EmailVerificationM{
+			UserID:    userM.UserID,
+			Email:     userM.Email,
+			TokenHash: hashMailToken(verificationToken),
+			ExpiresAt: time.Now().Add(b.opts.EmailVerificationExpiration),
+		})
+	})
+	if err != nil {
+		return errno.ErrDBWrite
+	}
+
+	b.sendMail(ctx, &mail.Message{
+		To:      []string{userM.Email},
+		Subject: "Verify your miniblog email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email address. The link expires in %s.\n\n%s\n\nIf you did not sign up for miniblog, you can ignore this email.\n",
+			userM.Username, b.opts.EmailVerificationExpiration, mailLink(b.opts.EmailVerificationURL, verificationToken)),
+	})
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package user
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+// 验证邮件的主题.
+const verificationSubject = "Verify your miniblog email address"
+
+// 返回用户在数据库中的记录.
+func getTestUser(t *testing.T, s store.IStore, userID string) *model.UserM {
+	userM, err := s.User().Get(context.Background(), where.F("userID", userID))
+	require.NoError(t, err)
+
+	return userM
+}
+
+func TestVerifyEmail(t *testing.T) {
+	b, s := newTestBiz(t)
+	m := captureTestMail(b)
+	ctx := context.Background()
+
+	// 注册时向用户的邮箱发送验证邮件
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	msg, verificationToken := m.next(t, verificationSubject)
+	assert.Equal(t, []string{username + "@miniblog.test"}, msg.To)
+	assert.False(t, getTestUser(t, s, userID).EmailVerified)
+
+	_, err := b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: verificationToken})
+	require.NoError(t, err)
+	userM := getTestUser(t, s, userID)
+	assert.True(t, userM.EmailVerified)
+	assert.NotNil(t, userM.VerifiedAt)
+
+	// 验证令牌只能使用一次, 已验证的邮箱不需要再次发送验证邮件
+	_, err = b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: verificationToken})
+	assert.ErrorIs(t, err, errno.ErrVerificationTokenInvalid)
+	_, err = b.SendVerificationEmail(userContext(userID, known.DefaultTenant), &apiv1.SendVerificationEmailRequest{})
+	assert.ErrorIs(t, err, errno.ErrEmailAlreadyVerified)
+
+	_, err = b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: "invalid"})
+	assert.ErrorIs(t, err, errno.ErrVerificationTokenInvalid)
+}
+
+func TestSendVerificationEmail(t *testing.T) {
+	b, _ := newTestBiz(t)
+	m := captureTestMail(b)
+	ctx := context.Background()
+	_, userID := createTestUser(t, b, known.DefaultTenant)
+	_, first := m.next(t, verificationSubject)
+
+	// 重新发送后之前的验证链接失效
+	_, err := b.SendVerificationEmail(userContext(userID, known.DefaultTenant), &apiv1.SendVerificationEmailRequest{})
+	require.NoError(t, err)
+	_, second := m.next(t, verificationSubject)
+
+	_, err = b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: first})
+	assert.ErrorIs(t, err, errno.ErrVerificationTokenInvalid)
+	_, err = b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: second})
+	assert.NoError(t, err)
+}
+
+func TestVerifyEmailExpired(t *testing.T) {
+	b, s := newTestBiz(t)
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+
+	verificationToken, err := newMailToken()
+	require.NoError(t, err)
+	require.NoError(t, s.EmailVerification().Create(context.Background(), &model.EmailVerificationM{
+		UserID:    userID,
+		Email:     username + "@miniblog.test",
+		TokenHash: hashMailToken(verificationToken),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	_, err = b.VerifyEmail(context.Background(), &apiv1.VerifyEmailRequest{Token: verificationToken})
+	assert.ErrorIs(t, err, errno.ErrVerificationTokenInvalid)
+	assert.False(t, getTestUser(t, s, userID).EmailVerified)
+}
+
+func TestUpdateEmailResetsVerification(t *testing.T) {
+	b, s := newTestBiz(t)
+	m := captureTestMail(b)
+	ctx := context.Background()
+	username, userID := createTestUser(t, b, known.DefaultTenant)
+	_, verificationToken := m.next(t, verificationSubject)
+	_, err := b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: verificationToken})
+	require.NoError(t, err)
+
+	// 只修改其他字段时邮箱仍然是已验证的
+	userCtx := userContext(userID, known.DefaultTenant)
+	_, err = b.Update(userCtx, &apiv1.UpdateUserRequest{UserID: userID, Nickname: ptr.To("nick"), Email: ptr.To(username + "@miniblog.test")})
+	require.NoError(t, err)
+	assert.True(t, getTestUser(t, s, userID).EmailVerified)
+
+	// 修改邮箱后需要重新验证, 验证邮件发送到新邮箱
+	newEmail := username + "@new.miniblog.test"
+	_, err = b.Update(userCtx, &apiv1.UpdateUserRequest{UserID: userID, Email: ptr.To(newEmail)})
+	require.NoError(t, err)
+	userM := getTestUser(t, s, userID)
+	assert.False(t, userM.EmailVerified)
+	assert.Nil(t, userM.VerifiedAt)
+
+	msg, verificationToken := m.next(t, verificationSubject)
+	assert.Equal(t, []string{newEmail}, msg.To)
+
+	// 令牌签发后邮箱再次被修改时, 令牌不能用于验证当前的邮箱
+	updateTestUser(t, s, userID, "email", username+"@other.miniblog.test")
+	_, err = b.VerifyEmail(ctx, &apiv1.VerifyEmailRequest{Token: verificationToken})
+	assert.ErrorIs(t, err, errno.ErrVerificationTokenInvalid)
+	assert.False(t, getTestUser(t, s, userID).EmailVerified)
+}
@@ -7,17 +7,21 @@ package apiserver
 
 import (
 	"context"
+	"encoding/json"
 	"miniblog/internal/pkg/server"
+	"net/http"
 
 	handler "miniblog/internal/apiserver/handler/grpc"
 	mw "miniblog/internal/pkg/middleware/grpc"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/token"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
 
@@ -39,29 +43,39 @@ var _ server.Server = (*grpcServer)(nil)
 //  2. 处理默认值或回退逻辑
 //  3. 表达灵活选项
 func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
+	// 注意拦截器顺序
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		// 请求id拦截器
+		mw.RequestIDInterprceptor(),
+		// 客户端信息拦截器
+		mw.ClientInfoInterceptor(),
+
+		// Bypass拦截器, 通过所有请求的认证
+		// mw.AuthnBypasswInterceptor(),
+		// 认证拦截器
+		selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever, c.denylist, c.accessTokens, c.sessions), NewAuthnWhiteListMatcher()),
+
+		// 授权拦截器
+		selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz, c.permissions), NewAuthzWhiteListMatcher()),
+
+		// 请求默认值设置拦截器
+		mw.DefaultInterceptor(),
+
+		// NewValidator创建通用校验层实例, 解析传入参数校验实例c.val
+		// NewValidator会从实例中提取所有方法声明格式为ValidateXXX(ctx context.Context, rq *apiv1.XXX) error的方法
+		// 将这些方法保存在通用校验层的内部registry中
+		mw.ValidatorInterceptor(genericvalidation.NewValidator(c.val)),
+	}
+	// 服务端流式RPC(例如个人数据导出)使用相同的拦截器
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0, len(unaryInterceptors))
+	for _, interceptor := range unaryInterceptors {
+		streamInterceptors = append(streamInterceptors, mw.StreamInterceptor(interceptor))
+	}
+
 	// 配置grpc服务器选项, 包括拦截器
 	serverOptions := []grpc.ServerOption{
-		// 注意拦截器顺序
-		grpc.ChainUnaryInterceptor(
-			// 请求id拦截器
-			mw.RequestIDInterprceptor(),
-
-			// Bypass拦截器, 通过所有请求的认证
-			// mw.AuthnBypasswInterceptor(),
-			// 认证拦截器
-			selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever), NewAuthnWhiteListMatcher()),
-
-			// 授权拦截器
-			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthnWhiteListMatcher()),
-
-			// 请求默认值设置拦截器
-			mw.DefaultInterceptor(),
-
-			// NewValidator创建通用校验层实例, 解析传入参数校验实例c.val
-			// NewValidator会从实例中提取所有方法声明格式为ValidateXXX(ctx context.Context, rq *apiv1.XXX) error的方法
-			// 将这些方法保存在通用校验层的内部registry中
-			mw.ValidatorInterceptor(genericvalidation.NewValidator(c.val)),
-		),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	}
 	// 创建grpc服务器
 	grpcsrv, err := server.NewGRPCServer(
@@ -92,6 +106,14 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		c.cfg.GRPCOptions,
 		c.cfg.TLSOptions,
 		func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+			// JWKS接口不属于grpc服务, 直接注册到网关上
+			if err := mux.HandlePath(http.MethodGet, "/.well-known/jwks.json", serveJWKS); err != nil {
+				return err
+			}
+			// prometheus指标接口同样不属于grpc服务
+			if err := mux.HandlePath(http.MethodGet, "/metrics", serveMetrics); err != nil {
+				return err
+			}
 			return apiv1.RegisterMiniBlogHandler(context.Background(), mux, conn)
 		},
 	)
@@ -108,6 +130,17 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 	}, nil
 }
 
+// 返回用于校验token签名的公钥集合.
+func serveJWKS(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(token.JWKS())
+}
+
+// 返回prometheus指标.
+func serveMetrics(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	promhttp.Handler().ServeHTTP(w, r)
+}
+
 // 启动grpc服务器或http反向代理服务器, 异常时退出.
 func (s *grpcServer) RunOrDie() {
 	s.srv.RunOrDie()
@@ -122,9 +155,16 @@ func (s *grpcServer) GracefulStop(ctx context.Context) {
 func NewAuthnWhiteListMatcher() selector.Matcher {
 	// 无需认证的方法
 	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:              {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:           {},
+		apiv1.MiniBlog_Login_FullMethodName:                {},
+		apiv1.MiniBlog_VerifyLogin_FullMethodName:          {},
+		apiv1.MiniBlog_RefreshToken_FullMethodName:         {},
+		apiv1.MiniBlog_RequestPasswordReset_FullMethodName: {},
+		apiv1.MiniBlog_ResetPassword_FullMethodName:        {},
+		apiv1.MiniBlog_VerifyEmail_FullMethodName:          {},
+		apiv1.MiniBlog_StartOIDCLogin_FullMethodName:       {},
+		apiv1.MiniBlog_OIDCCallback_FullMethodName:         {},
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whitelist[call.FullMethod()]
@@ -133,11 +173,20 @@ func NewAuthnWhiteListMatcher() selector.Matcher {
 }
 
 // 创建授权白名单匹配器.
+// 登出只需要认证, 任何登录的用户都可以吊销自己的令牌, 因此不声明权限.
 func NewAuthzWhiteListMatcher() selector.Matcher {
 	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:              {},
+		apiv1.MiniBlog_Logout_FullMethodName:               {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:           {},
+		apiv1.MiniBlog_Login_FullMethodName:                {},
+		apiv1.MiniBlog_VerifyLogin_FullMethodName:          {},
+		apiv1.MiniBlog_RefreshToken_FullMethodName:         {},
+		apiv1.MiniBlog_RequestPasswordReset_FullMethodName: {},
+		apiv1.MiniBlog_ResetPassword_FullMethodName:        {},
+		apiv1.MiniBlog_VerifyEmail_FullMethodName:          {},
+		apiv1.MiniBlog_StartOIDCLogin_FullMethodName:       {},
+		apiv1.MiniBlog_OIDCCallback_FullMethodName:         {},
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whitelist[call.FullMethod()]
@@ -0,0 +1,49 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package apiserver
+
+import (
+	"context"
+	"miniblog/internal/apiserver/pkg/permission"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/stretchr/testify/assert"
+)
+
+// 判断匹配器是否会对方法执行拦截器.
+func matches(fullMethod string, matcher interface {
+	Match(context.Context, interceptors.CallMeta) bool
+}) bool {
+	return matcher.Match(context.Background(), interceptors.NewServerCallMeta(fullMethod, nil, nil))
+}
+
+func TestAuthzWhiteListMatcher(t *testing.T) {
+	matcher := NewAuthzWhiteListMatcher()
+
+	// 登出不声明权限, 需要跳过授权, 否则会被授权拦截器拒绝
+	assert.False(t, matches(apiv1.MiniBlog_Logout_FullMethodName, matcher))
+	assert.True(t, matches(apiv1.MiniBlog_DeleteUser_FullMethodName, matcher))
+}
+
+// 每个RPC要么声明了权限, 要么在授权白名单中, 否则所有调用都会被拒绝.
+func TestEveryMethodIsAuthorizable(t *testing.T) {
+	registry := permission.NewRegistry()
+	authn, authz := NewAuthnWhiteListMatcher(), NewAuthzWhiteListMatcher()
+
+	methods := apiv1.File_apiserver_v1_apiserver_proto.Services().ByName("MiniBlog").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		fullMethod := "/v1.MiniBlog/" + string(methods.Get(i).Name())
+		_, declared := registry.MethodPermission(fullMethod)
+		assert.True(t, declared || !matches(fullMethod, authz), "%s declares no permission and is not whitelisted", fullMethod)
+
+		// 无需认证的方法没有用户身份, 同样不能经过授权
+		if !matches(fullMethod, authn) {
+			assert.False(t, matches(fullMethod, authz), "%s skips authentication but not authorization", fullMethod)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// CreateAccessToken 创建个人访问令牌.
+func (h *Handler) CreateAccessToken(ctx context.Context, rq *apiv1.CreateAccessTokenRequest) (*apiv1.CreateAccessTokenResponse, error) {
+	return h.biz.AccessTokenV1().Create(ctx, rq)
+}
+
+// ListAccessToken 列出当前用户的个人访问令牌.
+func (h *Handler) ListAccessToken(ctx context.Context, rq *apiv1.ListAccessTokenRequest) (*apiv1.ListAccessTokenResponse, error) {
+	return h.biz.AccessTokenV1().List(ctx, rq)
+}
+
+// RevokeAccessToken 吊销个人访问令牌.
+func (h *Handler) RevokeAccessToken(ctx context.Context, rq *apiv1.RevokeAccessTokenRequest) (*apiv1.RevokeAccessTokenResponse, error) {
+	return h.biz.AccessTokenV1().Revoke(ctx, rq)
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"miniblog/internal/apiserver/pkg/export"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// 每个HttpBody中数据的最大长度, 避免单条消息超过客户端允许接收的大小.
+const maxChunkSize = 32 * 1024
+
+// ExportMyData 导出当前用户的个人数据, 归档分块写入流中.
+func (h *Handler) ExportMyData(rq *apiv1.ExportMyDataRequest, stream grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	ctx := stream.Context()
+	return h.biz.UserV1().ExportMyData(ctx, rq, newAttachmentWriter(stream, contextx.UserID(ctx), rq.GetFormat()))
+}
+
+// ExportUserData 导出指定用户的个人数据, 归档分块写入流中.
+func (h *Handler) ExportUserData(rq *apiv1.ExportUserDataRequest, stream grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return h.biz.UserV1().ExportUserData(stream.Context(), rq, newAttachmentWriter(stream, rq.GetUserID(), rq.GetFormat()))
+}
+
+// attachmentWriter 将归档分块发送给客户端, 第一次写入时设置附件的响应头.
+// 导出失败时如果还没有写入数据, 客户端只会收到错误, 不会收到附件的响应头.
+type attachmentWriter struct {
+	stream      grpc.ServerStreamingServer[httpbody.HttpBody]
+	contentType string
+	disposition string
+	started     bool
+}
+
+func newAttachmentWriter(stream grpc.ServerStreamingServer[httpbody.HttpBody], userID string, format string) *attachmentWriter {
+	if format == "" {
+		format = known.DataExportFormatJSON
+	}
+	return &attachmentWriter{
+		stream:      stream,
+		contentType: export.ContentType(format),
+		disposition: export.ContentDisposition(userID, format),
+	}
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		// grpc-gateway 将 content-disposition 元数据作为同名的 HTTP 响应头返回
+		if err := w.stream.SetHeader(metadata.Pairs(known.ContentDisposition, w.disposition)); err != nil {
+			return 0, err
+		}
+		w.started = true
+	}
+
+	for n := 0; n < len(p); {
+		end := min(n+maxChunkSize, len(p))
+		if err := w.stream.Send(&httpbody.HttpBody{ContentType: w.contentType, Data: p[n:end]}); err != nil {
+			return n, err
+		}
+		n = end
+	}
+	return len(p), nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// StartOIDCLogin 发起外部身份提供方登录.
+func (h *Handler) StartOIDCLogin(ctx context.Context, rq *apiv1.StartOIDCLoginRequest) (*apiv1.StartOIDCLoginResponse, error) {
+	return h.biz.UserV1().StartOIDCLogin(ctx, rq)
+}
+
+// OIDCCallback 处理外部身份提供方的登录回调.
+func (h *Handler) OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.LoginResponse, error) {
+	return h.biz.UserV1().OIDCCallback(ctx, rq)
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// ListPolicies 列出授权策略.
+func (h *Handler) ListPolicies(ctx context.Context, rq *apiv1.ListPoliciesRequest) (*apiv1.ListPoliciesResponse, error) {
+	return h.biz.PolicyV1().ListPolicies(ctx, rq)
+}
+
+// AddPolicy 添加授权策略.
+func (h *Handler) AddPolicy(ctx context.Context, rq *apiv1.AddPolicyRequest) (*apiv1.AddPolicyResponse, error) {
+	return h.biz.PolicyV1().AddPolicy(ctx, rq)
+}
+
+// RemovePolicy 删除授权策略.
+func (h *Handler) RemovePolicy(ctx context.Context, rq *apiv1.RemovePolicyRequest) (*apiv1.RemovePolicyResponse, error) {
+	return h.biz.PolicyV1().RemovePolicy(ctx, rq)
+}
+
+// ListGroupingPolicies 列出角色继承规则.
+func (h *Handler) ListGroupingPolicies(ctx context.Context, rq *apiv1.ListGroupingPoliciesRequest) (*apiv1.ListGroupingPoliciesResponse, error) {
+	return h.biz.PolicyV1().ListGroupingPolicies(ctx, rq)
+}
+
+// AddGroupingPolicy 添加角色继承规则.
+func (h *Handler) AddGroupingPolicy(ctx context.Context, rq *apiv1.AddGroupingPolicyRequest) (*apiv1.AddGroupingPolicyResponse, error) {
+	return h.biz.PolicyV1().AddGroupingPolicy(ctx, rq)
+}
+
+// RemoveGroupingPolicy 删除角色继承规则.
+func (h *Handler) RemoveGroupingPolicy(ctx context.Context, rq *apiv1.RemoveGroupingPolicyRequest) (*apiv1.RemoveGroupingPolicyResponse, error) {
+	return h.biz.PolicyV1().RemoveGroupingPolicy(ctx, rq)
+}
+
+// ListUserRoles 列出用户的角色.
+func (h *Handler) ListUserRoles(ctx context.Context, rq *apiv1.ListUserRolesRequest) (*apiv1.ListUserRolesResponse, error) {
+	return h.biz.PolicyV1().ListUserRoles(ctx, rq)
+}
+
+// AssignRole 为用户分配角色.
+func (h *Handler) AssignRole(ctx context.Context, rq *apiv1.AssignRoleRequest) (*apiv1.AssignRoleResponse, error) {
+	return h.biz.PolicyV1().AssignRole(ctx, rq)
+}
+
+// RevokeRole 收回用户的角色.
+func (h *Handler) RevokeRole(ctx context.Context, rq *apiv1.RevokeRoleRequest) (*apiv1.RevokeRoleResponse, error) {
+	return h.biz.PolicyV1().RevokeRole(ctx, rq)
+}
+
+// ExplainAuthorization 解释授权结果.
+func (h *Handler) ExplainAuthorization(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) (*apiv1.ExplainAuthorizationResponse, error) {
+	return h.biz.PolicyV1().ExplainAuthorization(ctx, rq)
+}
@@ -26,6 +26,11 @@ func (h *Handler) DeletePost(ctx context.Context, rq *apiv1.DeletePostRequest) (
 	return h.biz.PostV1().Delete(ctx, rq)
 }
 
+// RestorePost 恢复已删除的博客帖子.
+func (h *Handler) RestorePost(ctx context.Context, rq *apiv1.RestorePostRequest) (*apiv1.RestorePostResponse, error) {
+	return h.biz.PostV1().Restore(ctx, rq)
+}
+
 // GetPost 获取博客帖子.
 func (h *Handler) GetPost(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error) {
 	return h.biz.PostV1().Get(ctx, rq)
@@ -0,0 +1,32 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// ListSessions 列出当前用户的登录会话.
+func (h *Handler) ListSessions(ctx context.Context, rq *apiv1.ListSessionsRequest) (*apiv1.ListSessionsResponse, error) {
+	return h.biz.UserV1().ListSessions(ctx, rq)
+}
+
+// RevokeSession 吊销当前用户的登录会话.
+func (h *Handler) RevokeSession(ctx context.Context, rq *apiv1.RevokeSessionRequest) (*apiv1.RevokeSessionResponse, error) {
+	return h.biz.UserV1().RevokeSession(ctx, rq)
+}
+
+// ListUserSessions 列出指定用户的登录会话.
+func (h *Handler) ListUserSessions(ctx context.Context, rq *apiv1.ListUserSessionsRequest) (*apiv1.ListSessionsResponse, error) {
+	return h.biz.UserV1().ListUserSessions(ctx, rq)
+}
+
+// RevokeUserSession 吊销指定用户的登录会话.
+func (h *Handler) RevokeUserSession(ctx context.Context, rq *apiv1.RevokeUserSessionRequest) (*apiv1.RevokeSessionResponse, error) {
+	return h.biz.UserV1().RevokeUserSession(ctx, rq)
+}
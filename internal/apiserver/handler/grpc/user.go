@@ -17,6 +17,81 @@ func (h *Handler) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.Log
 	return h.biz.UserV1().Login(ctx, rq)
 }
 
+// VerifyLogin 使用挑战令牌和一次性密码完成两步登录.
+func (h *Handler) VerifyLogin(ctx context.Context, rq *apiv1.VerifyLoginRequest) (*apiv1.VerifyLoginResponse, error) {
+	return h.biz.UserV1().VerifyLogin(ctx, rq)
+}
+
+// EnrollTOTP 为当前用户生成 TOTP 密钥.
+func (h *Handler) EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error) {
+	return h.biz.UserV1().EnrollTOTP(ctx, rq)
+}
+
+// ConfirmTOTP 确认绑定身份验证器并开启两步验证.
+func (h *Handler) ConfirmTOTP(ctx context.Context, rq *apiv1.ConfirmTOTPRequest) (*apiv1.ConfirmTOTPResponse, error) {
+	return h.biz.UserV1().ConfirmTOTP(ctx, rq)
+}
+
+// DisableTOTP 关闭两步验证.
+func (h *Handler) DisableTOTP(ctx context.Context, rq *apiv1.DisableTOTPRequest) (*apiv1.DisableTOTPResponse, error) {
+	return h.biz.UserV1().DisableTOTP(ctx, rq)
+}
+
+// RefreshToken 使用刷新令牌换取新的令牌.
+func (h *Handler) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error) {
+	return h.biz.UserV1().RefreshToken(ctx, rq)
+}
+
+// Logout 用户登出, 吊销当前令牌.
+func (h *Handler) Logout(ctx context.Context, rq *apiv1.LogoutRequest) (*apiv1.LogoutResponse, error) {
+	return h.biz.UserV1().Logout(ctx, rq)
+}
+
+// RevokeTokens 吊销指定用户的全部令牌.
+func (h *Handler) RevokeTokens(ctx context.Context, rq *apiv1.RevokeTokensRequest) (*apiv1.RevokeTokensResponse, error) {
+	return h.biz.UserV1().RevokeTokens(ctx, rq)
+}
+
+// Impersonate 管理员模拟指定用户登录.
+func (h *Handler) Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error) {
+	return h.biz.UserV1().Impersonate(ctx, rq)
+}
+
+// UnlockUser 解除用户登录锁定.
+func (h *Handler) UnlockUser(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error) {
+	return h.biz.UserV1().Unlock(ctx, rq)
+}
+
+// UpdateUserStatus 禁用, 封禁或重新启用用户.
+func (h *Handler) UpdateUserStatus(ctx context.Context, rq *apiv1.UpdateUserStatusRequest) (*apiv1.UpdateUserStatusResponse, error) {
+	return h.biz.UserV1().UpdateStatus(ctx, rq)
+}
+
+// ChangePassword 修改用户密码.
+func (h *Handler) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error) {
+	return h.biz.UserV1().ChangePassword(ctx, rq)
+}
+
+// RequestPasswordReset 申请重置密码.
+func (h *Handler) RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error) {
+	return h.biz.UserV1().RequestPasswordReset(ctx, rq)
+}
+
+// ResetPassword 使用重置令牌设置新密码.
+func (h *Handler) ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error) {
+	return h.biz.UserV1().ResetPassword(ctx, rq)
+}
+
+// VerifyEmail 使用验证令牌验证电子邮箱.
+func (h *Handler) VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error) {
+	return h.biz.UserV1().VerifyEmail(ctx, rq)
+}
+
+// SendVerificationEmail 为当前用户重新发送验证邮件.
+func (h *Handler) SendVerificationEmail(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) (*apiv1.SendVerificationEmailResponse, error) {
+	return h.biz.UserV1().SendVerificationEmail(ctx, rq)
+}
+
 func (h *Handler) CreateUser(ctx context.Context, rq *apiv1.CreateUserRequest) (*apiv1.CreateUserResponse, error) {
 	return h.biz.UserV1().Create(ctx, rq)
 }
@@ -31,6 +106,11 @@ func (h *Handler) DeleteUser(ctx context.Context, rq *apiv1.DeleteUserRequest) (
 	return h.biz.UserV1().Delete(ctx, rq)
 }
 
+// RestoreUser 恢复已删除的用户.
+func (h *Handler) RestoreUser(ctx context.Context, rq *apiv1.RestoreUserRequest) (*apiv1.RestoreUserResponse, error) {
+	return h.biz.UserV1().Restore(ctx, rq)
+}
+
 // GetUser 获取用户信息.
 func (h *Handler) GetUser(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.GetUserResponse, error) {
 	return h.biz.UserV1().Get(ctx, rq)
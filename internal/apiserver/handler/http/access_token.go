@@ -0,0 +1,27 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// CreateAccessToken 创建个人访问令牌.
+func (h *Handler) CreateAccessToken(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.AccessTokenV1().Create, h.val.ValidateCreateAccessTokenRequest)
+}
+
+// ListAccessToken 列出当前用户的个人访问令牌.
+func (h *Handler) ListAccessToken(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AccessTokenV1().List, h.val.ValidateListAccessTokenRequest)
+}
+
+// RevokeAccessToken 吊销个人访问令牌.
+func (h *Handler) RevokeAccessToken(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.AccessTokenV1().Revoke, h.val.ValidateRevokeAccessTokenRequest)
+}
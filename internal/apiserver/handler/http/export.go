@@ -0,0 +1,89 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/onexstack/onexstack/pkg/core"
+
+	"miniblog/internal/apiserver/pkg/export"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// ExportMyData 导出当前用户的个人数据, 以附件的形式返回归档.
+func (h *Handler) ExportMyData(c *gin.Context) {
+	var rq apiv1.ExportMyDataRequest
+	if err := core.ShouldBindQuery(c, &rq, h.val.ValidateExportMyDataRequest); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	w := newAttachmentWriter(c, contextx.UserID(c.Request.Context()), rq.GetFormat())
+	w.finish(h.biz.UserV1().ExportMyData(c.Request.Context(), &rq, w))
+}
+
+// ExportUserData 导出指定用户的个人数据, 以附件的形式返回归档.
+func (h *Handler) ExportUserData(c *gin.Context) {
+	var rq apiv1.ExportUserDataRequest
+	// 先绑定查询参数, 保证路径中的userID不会被同名的查询参数覆盖
+	binder := func(obj any) error {
+		if err := c.ShouldBindQuery(obj); err != nil {
+			return err
+		}
+		return c.ShouldBindUri(obj)
+	}
+	if err := core.ReadRequest(c, &rq, binder, h.val.ValidateExportUserDataRequest); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	w := newAttachmentWriter(c, rq.GetUserID(), rq.GetFormat())
+	w.finish(h.biz.UserV1().ExportUserData(c.Request.Context(), &rq, w))
+}
+
+// attachmentWriter 将归档直接写入响应, 第一次写入时设置附件的响应头.
+type attachmentWriter struct {
+	c           *gin.Context
+	contentType string
+	disposition string
+}
+
+func newAttachmentWriter(c *gin.Context, userID string, format string) *attachmentWriter {
+	if format == "" {
+		format = known.DataExportFormatJSON
+	}
+	return &attachmentWriter{c: c, contentType: export.ContentType(format), disposition: export.ContentDisposition(userID, format)}
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.c.Writer.Written() {
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", w.disposition)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// 处理导出的结果, 还没有写入数据时返回统一格式的错误响应.
+// 已经开始写入归档时响应头无法修改, 只能记录日志并中断响应.
+func (w *attachmentWriter) finish(err error) {
+	if err == nil {
+		return
+	}
+	if !w.c.Writer.Written() {
+		core.WriteResponse(w.c, nil, err)
+		return
+	}
+
+	log.W(w.c.Request.Context()).Errorw("Failed to write data export to response", "err", err)
+	w.c.Abort()
+}
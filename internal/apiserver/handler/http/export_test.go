@@ -0,0 +1,135 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"context"
+	"io"
+	"miniblog/internal/apiserver/biz"
+	userv1 "miniblog/internal/apiserver/biz/v1/user"
+	"miniblog/internal/apiserver/pkg/permission"
+	"miniblog/internal/apiserver/pkg/validation"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBiz 只提供用户业务接口.
+type fakeBiz struct {
+	biz.IBiz
+
+	user *fakeUserBiz
+}
+
+func (b *fakeBiz) UserV1() userv1.UserBiz {
+	return b.user
+}
+
+// fakeUserBiz 将data分两次写入归档, 然后返回err, 用于模拟导出成功, 导出前失败和导出中途失败.
+type fakeUserBiz struct {
+	userv1.UserBiz
+
+	data     []byte
+	err      error
+	exported string
+}
+
+func (b *fakeUserBiz) ExportMyData(ctx context.Context, rq *apiv1.ExportMyDataRequest, w io.Writer) error {
+	return b.export(contextx.UserID(ctx), w)
+}
+
+func (b *fakeUserBiz) ExportUserData(ctx context.Context, rq *apiv1.ExportUserDataRequest, w io.Writer) error {
+	return b.export(rq.GetUserID(), w)
+}
+
+func (b *fakeUserBiz) export(userID string, w io.Writer) error {
+	b.exported = userID
+	half := len(b.data) / 2
+	for _, chunk := range [][]byte{b.data[:half], b.data[half:]} {
+		if len(chunk) == 0 {
+			continue
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return b.err
+}
+
+// 创建注册了导出接口的引擎, 请求由user-000001发起.
+func newExportEngine(user *fakeUserBiz) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	h := NewHandler(&fakeBiz{user: user}, validation.New(nil, permission.NewRegistry(), nil))
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(contextx.WithUserID(c.Request.Context(), "user-000001"))
+	})
+	engine.GET("/v1/data-export", h.ExportMyData)
+	engine.GET("/v1/user-data-exports/:userID", h.ExportUserData)
+	return engine
+}
+
+func serveExport(engine *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestExportMyData(t *testing.T) {
+	user := &fakeUserBiz{data: []byte("PK archive")}
+	engine := newExportEngine(user)
+
+	// 归档作为附件写入响应, 默认导出为JSON
+	w := serveExport(engine, "/v1/data-export?format=zip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="miniblog-export-user-000001.zip"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "PK archive", w.Body.String())
+	assert.Equal(t, "user-000001", user.exported)
+
+	w = serveExport(engine, "/v1/data-export")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="miniblog-export-user-000001.json"`, w.Header().Get("Content-Disposition"))
+
+	w = serveExport(engine, "/v1/data-export?format=tar")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+}
+
+func TestExportUserData(t *testing.T) {
+	user := &fakeUserBiz{data: []byte("{}")}
+	engine := newExportEngine(user)
+
+	// 路径中的userID不会被同名的查询参数覆盖
+	w := serveExport(engine, "/v1/user-data-exports/user-000002?userID=user-000003")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="miniblog-export-user-000002.json"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "{}", w.Body.String())
+	assert.Equal(t, "user-000002", user.exported)
+}
+
+func TestExportError(t *testing.T) {
+	// 还没有写入数据时返回统一格式的错误响应, 不设置附件的响应头
+	user := &fakeUserBiz{err: errno.ErrUserNotFound}
+	w := serveExport(newExportEngine(user), "/v1/user-data-exports/user-000002")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), errno.ErrUserNotFound.Reason)
+
+	// 已经开始写入归档时不会在归档后追加错误响应
+	user = &fakeUserBiz{data: []byte("PK partial"), err: errno.ErrInternal}
+	w = serveExport(newExportEngine(user), "/v1/data-export?format=zip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "PK partial", w.Body.String())
+}
@@ -0,0 +1,19 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"miniblog/pkg/token"
+)
+
+// JWKS 返回用于校验token签名的公钥集合, 其他服务无需共享密钥即可校验miniblog签发的token.
+func (h *Handler) JWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, token.JWKS())
+}
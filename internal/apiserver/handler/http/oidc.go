@@ -0,0 +1,22 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// StartOIDCLogin 发起外部身份提供方登录.
+func (h *Handler) StartOIDCLogin(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.UserV1().StartOIDCLogin, h.val.ValidateStartOIDCLoginRequest)
+}
+
+// OIDCCallback 处理外部身份提供方的登录回调.
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.UserV1().OIDCCallback, h.val.ValidateOIDCCallbackRequest)
+}
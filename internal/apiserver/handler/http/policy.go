@@ -0,0 +1,72 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// ListPolicies 列出授权策略.
+func (h *Handler) ListPolicies(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().ListPolicies, h.val.ValidateListPoliciesRequest)
+}
+
+// AddPolicy 添加授权策略.
+func (h *Handler) AddPolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().AddPolicy, h.val.ValidateAddPolicyRequest)
+}
+
+// RemovePolicy 删除授权策略.
+func (h *Handler) RemovePolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().RemovePolicy, h.val.ValidateRemovePolicyRequest)
+}
+
+// ListGroupingPolicies 列出角色继承规则.
+func (h *Handler) ListGroupingPolicies(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().ListGroupingPolicies, h.val.ValidateListGroupingPoliciesRequest)
+}
+
+// AddGroupingPolicy 添加角色继承规则.
+func (h *Handler) AddGroupingPolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().AddGroupingPolicy, h.val.ValidateAddGroupingPolicyRequest)
+}
+
+// RemoveGroupingPolicy 删除角色继承规则.
+func (h *Handler) RemoveGroupingPolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().RemoveGroupingPolicy, h.val.ValidateRemoveGroupingPolicyRequest)
+}
+
+// ListUserRoles 列出用户的角色.
+func (h *Handler) ListUserRoles(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PolicyV1().ListUserRoles, h.val.ValidateListUserRolesRequest)
+}
+
+// AssignRole 为用户分配角色, 用户 ID 来自路径参数, 角色来自请求体.
+func (h *Handler) AssignRole(c *gin.Context) {
+	core.HandleRequest(c, bindJSONAndUri(c), h.biz.PolicyV1().AssignRole, h.val.ValidateAssignRoleRequest)
+}
+
+// RevokeRole 收回用户的角色.
+func (h *Handler) RevokeRole(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PolicyV1().RevokeRole, h.val.ValidateRevokeRoleRequest)
+}
+
+// ExplainAuthorization 解释授权结果.
+func (h *Handler) ExplainAuthorization(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().ExplainAuthorization, h.val.ValidateExplainAuthorizationRequest)
+}
+
+// bindJSONAndUri 先绑定请求体再绑定路径参数, 路径参数优先于请求体中的同名字段.
+func bindJSONAndUri(c *gin.Context) core.Binder {
+	return func(obj any) error {
+		if err := c.ShouldBindJSON(obj); err != nil {
+			return err
+		}
+		return c.ShouldBindUri(obj)
+	}
+}
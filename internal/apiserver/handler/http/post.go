@@ -26,6 +26,11 @@ func (h *Handler) DeletePost(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.PostV1().Delete, h.val.ValidateDeletePostRequest)
 }
 
+// RestorePost 恢复已删除的博客帖子.
+func (h *Handler) RestorePost(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PostV1().Restore, h.val.ValidateRestorePostRequest)
+}
+
 // GetPost 获取博客帖子.
 func (h *Handler) GetPost(c *gin.Context) {
 	core.HandleUriRequest(c, h.biz.PostV1().Get, h.val.ValidateGetPostRequest)
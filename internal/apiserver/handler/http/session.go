@@ -0,0 +1,32 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// ListSessions 列出当前用户的登录会话.
+func (h *Handler) ListSessions(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.UserV1().ListSessions, h.val.ValidateListSessionsRequest)
+}
+
+// RevokeSession 吊销当前用户的登录会话.
+func (h *Handler) RevokeSession(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().RevokeSession, h.val.ValidateRevokeSessionRequest)
+}
+
+// ListUserSessions 列出指定用户的登录会话.
+func (h *Handler) ListUserSessions(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().ListUserSessions, h.val.ValidateListUserSessionsRequest)
+}
+
+// RevokeUserSession 吊销指定用户的登录会话.
+func (h *Handler) RevokeUserSession(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().RevokeUserSession, h.val.ValidateRevokeUserSessionRequest)
+}
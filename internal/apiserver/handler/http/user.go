@@ -16,9 +16,54 @@ func (h *Handler) Login(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().Login, h.val.ValidateLoginRequest)
 }
 
+// VerifyLogin 使用挑战令牌和一次性密码完成两步登录.
+func (h *Handler) VerifyLogin(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().VerifyLogin, h.val.ValidateVerifyLoginRequest)
+}
+
+// EnrollTOTP 为当前用户生成 TOTP 密钥.
+func (h *Handler) EnrollTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().EnrollTOTP, h.val.ValidateEnrollTOTPRequest)
+}
+
+// ConfirmTOTP 确认绑定身份验证器并开启两步验证.
+func (h *Handler) ConfirmTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ConfirmTOTP, h.val.ValidateConfirmTOTPRequest)
+}
+
+// DisableTOTP 关闭两步验证.
+func (h *Handler) DisableTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().DisableTOTP, h.val.ValidateDisableTOTPRequest)
+}
+
 // RefreshToken 刷新 JWT Token.
 func (h *Handler) RefreshToken(c *gin.Context) {
-	core.HandleJSONRequest(c, h.biz.UserV1().RefreshToken)
+	core.HandleJSONRequest(c, h.biz.UserV1().RefreshToken, h.val.ValidateRefreshTokenRequest)
+}
+
+// Logout 用户登出, 吊销当前令牌.
+func (h *Handler) Logout(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().Logout, h.val.ValidateLogoutRequest)
+}
+
+// RevokeTokens 吊销指定用户的全部令牌.
+func (h *Handler) RevokeTokens(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().RevokeTokens, h.val.ValidateRevokeTokensRequest)
+}
+
+// Impersonate 管理员模拟指定用户登录.
+func (h *Handler) Impersonate(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().Impersonate, h.val.ValidateImpersonateRequest)
+}
+
+// UnlockUser 解除用户登录锁定.
+func (h *Handler) UnlockUser(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().Unlock, h.val.ValidateUnlockUserRequest)
+}
+
+// UpdateUserStatus 禁用, 封禁或重新启用用户, 用户 ID 来自路径参数, 状态来自请求体.
+func (h *Handler) UpdateUserStatus(c *gin.Context) {
+	core.HandleRequest(c, bindJSONAndUri(c), h.biz.UserV1().UpdateStatus, h.val.ValidateUpdateUserStatusRequest)
 }
 
 // ChangeUserPassword 修改用户密码.
@@ -26,6 +71,26 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().ChangePassword, h.val.ValidateChangePasswordRequest)
 }
 
+// RequestPasswordReset 申请重置密码.
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().RequestPasswordReset, h.val.ValidateRequestPasswordResetRequest)
+}
+
+// ResetPassword 使用重置令牌设置新密码.
+func (h *Handler) ResetPassword(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ResetPassword, h.val.ValidateResetPasswordRequest)
+}
+
+// VerifyEmail 使用验证令牌验证电子邮箱.
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().VerifyEmail, h.val.ValidateVerifyEmailRequest)
+}
+
+// SendVerificationEmail 为当前用户重新发送验证邮件.
+func (h *Handler) SendVerificationEmail(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().SendVerificationEmail, h.val.ValidateSendVerificationEmailRequest)
+}
+
 // CreateUser 创建新用户.
 func (h *Handler) CreateUser(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().Create, h.val.ValidateCreateUserRequest)
@@ -41,6 +106,11 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 	core.HandleUriRequest(c, h.biz.UserV1().Delete, h.val.ValidateDeleteUserRequest)
 }
 
+// RestoreUser 恢复已删除的用户.
+func (h *Handler) RestoreUser(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().Restore, h.val.ValidateRestoreUserRequest)
+}
+
 // GetUser 获取用户信息.
 func (h *Handler) GetUser(c *gin.Context) {
 	core.HandleUriRequest(c, h.biz.UserV1().Get, h.val.ValidateGetUserRequest)
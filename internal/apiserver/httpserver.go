@@ -15,6 +15,7 @@ import (
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type ginServer struct {
@@ -25,18 +26,22 @@ type ginServer struct {
 var _ server.Server = (*ginServer)(nil)
 
 // 初始化一个新的Gin服务器实例.
-func (c *ServerConfig) NewGinServer() server.Server {
+func (c *ServerConfig) NewGinServer() (server.Server, error) {
 	// 创建gin引擎
 	engine := gin.New()
+	// 默认不信任任何代理, 客户端IP取自连接的对端地址, 防止客户端通过X-Forwarded-For伪造IP绕过登录限制
+	if err := engine.SetTrustedProxies(c.cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
 
 	// 注册全局中间件, 用于恢复 panic, 设置 HTTP 头, 添加请求 ID 等
-	engine.Use(gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestIDMiddleware()) // 注册REST API路由
+	engine.Use(gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestIDMiddleware(), mw.ClientInfoMiddleware()) // 注册REST API路由
 
 	c.InstallRESTAPI(engine)
 
 	httpsrv := server.NewHTTPServer(c.cfg.HTTPOptions, c.cfg.TLSOptions, engine)
 
-	return &ginServer{srv: httpsrv}
+	return &ginServer{srv: httpsrv}, nil
 }
 
 // 注册API路由, 路由的路径和http方法遵守REST规范.
@@ -50,12 +55,29 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	// 注册健康检查接口
 	engine.GET("/healthz", handler.Healthz)
 
+	// 注册JWKS接口, 公开校验token签名的公钥
+	engine.GET("/.well-known/jwks.json", handler.JWKS)
+
 	// 注册用户登录和令牌刷新接口
 	engine.POST("login", handler.Login)
-	engine.PUT("/refresh-token", mw.AuthnMiddleware(c.retriever), handler.RefreshToken)
+	// 两步登录的第二步使用挑战令牌作为凭证, 因此不经过认证中间件
+	engine.POST("/verify-login", handler.VerifyLogin)
+	// 刷新令牌本身即为凭证, 访问令牌过期后仍需能够刷新, 因此不经过认证中间件
+	engine.PUT("/refresh-token", handler.RefreshToken)
+	// 忘记密码的用户无法登录, 重置密码通过邮件中的重置令牌证明身份, 因此不经过认证中间件
+	engine.POST("/request-password-reset", handler.RequestPasswordReset)
+	engine.POST("/reset-password", handler.ResetPassword)
+	// 验证邮件中的链接可能在未登录的设备上打开, 验证令牌本身即为凭证, 因此不经过认证中间件
+	engine.POST("/verify-email", handler.VerifyEmail)
+	// 外部身份提供方登录发生在用户登录之前, 回调中的state和授权码即为凭证, 因此不经过认证中间件
+	engine.GET("/oidc/login", handler.StartOIDCLogin)
+	engine.GET("/oidc/callback", handler.OIDCCallback)
 
 	// 中间件切片, 用于在请求处理前后执行逻辑, 如JWT认证
-	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.retriever), mw.AuthzMiddleware(c.authz)}
+	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.retriever, c.denylist, c.accessTokens, c.sessions), mw.AuthzMiddleware(c.authz, c.permissions)}
+
+	// 注册用户登出接口, 登出只需要认证
+	engine.POST("/logout", mw.AuthnMiddleware(c.retriever, c.denylist, c.accessTokens, c.sessions), handler.Logout)
 
 	// 注册v1版本API路由分组
 	v1 := engine.Group("/v1")
@@ -67,19 +89,86 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 			// 其余需要进行认证和授权
 			userv1.Use(authMiddlewares...)
 			userv1.PUT(":userID/change-password", handler.ChangePassword) // 修改用户密码
+			userv1.POST(":userID/revoke-tokens", handler.RevokeTokens)    // 吊销用户令牌
+			userv1.POST(":userID/unlock", handler.UnlockUser)             // 解除用户登录锁定
+			userv1.PUT(":userID/status", handler.UpdateUserStatus)        // 修改用户状态
+			userv1.POST(":userID/impersonate", handler.Impersonate)       // 模拟用户登录
+			userv1.POST(":userID/restore", handler.RestoreUser)           // 恢复已删除的用户
 			userv1.PUT(":userID", handler.UpdateUser)                     // 更新用户信息
 			userv1.DELETE(":userID", handler.DeleteUser)                  // 删除用户
 			userv1.GET(":userID", handler.GetUser)                        // 查询用户详情
 			userv1.GET("", handler.ListUser)                              // 查询用户列表.
 		}
 
+		accessTokenv1 := v1.Group("/access-tokens", authMiddlewares...)
+		{
+			accessTokenv1.POST("", handler.CreateAccessToken)           // 创建个人访问令牌
+			accessTokenv1.GET("", handler.ListAccessToken)              // 查询个人访问令牌列表
+			accessTokenv1.DELETE(":tokenID", handler.RevokeAccessToken) // 吊销个人访问令牌
+		}
+
+		sessionv1 := v1.Group("/sessions", authMiddlewares...)
+		{
+			sessionv1.GET("", handler.ListSessions)               // 查询当前用户的登录会话
+			sessionv1.DELETE(":sessionID", handler.RevokeSession) // 吊销当前用户的登录会话
+		}
+
+		userSessionv1 := v1.Group("/user-sessions", authMiddlewares...)
+		{
+			userSessionv1.GET(":userID", handler.ListUserSessions)                // 查询指定用户的登录会话
+			userSessionv1.DELETE(":userID/:sessionID", handler.RevokeUserSession) // 吊销指定用户的登录会话
+		}
+
+		dataExportv1 := v1.Group("/data-export", authMiddlewares...)
+		{
+			dataExportv1.GET("", handler.ExportMyData) // 导出当前用户的个人数据
+		}
+
+		userDataExportv1 := v1.Group("/user-data-exports", authMiddlewares...)
+		{
+			userDataExportv1.GET(":userID", handler.ExportUserData) // 导出指定用户的个人数据
+		}
+
+		policyv1 := v1.Group("/policies", authMiddlewares...)
+		{
+			policyv1.GET("", handler.ListPolicies)                 // 查询授权策略
+			policyv1.POST("", handler.AddPolicy)                   // 添加授权策略
+			policyv1.DELETE("", handler.RemovePolicy)              // 删除授权策略
+			policyv1.POST("explain", handler.ExplainAuthorization) // 解释授权结果
+		}
+
+		groupingPolicyv1 := v1.Group("/grouping-policies", authMiddlewares...)
+		{
+			groupingPolicyv1.GET("", handler.ListGroupingPolicies)    // 查询角色继承规则
+			groupingPolicyv1.POST("", handler.AddGroupingPolicy)      // 添加角色继承规则
+			groupingPolicyv1.DELETE("", handler.RemoveGroupingPolicy) // 删除角色继承规则
+		}
+
+		userRolev1 := v1.Group("/user-roles", authMiddlewares...)
+		{
+			userRolev1.GET(":userID", handler.ListUserRoles)       // 查询用户的角色
+			userRolev1.POST(":userID", handler.AssignRole)         // 为用户分配角色
+			userRolev1.DELETE(":userID/:role", handler.RevokeRole) // 收回用户的角色
+		}
+
+		totpv1 := v1.Group("/totp", authMiddlewares...)
+		{
+			totpv1.POST("enroll", handler.EnrollTOTP)   // 绑定身份验证器
+			totpv1.POST("confirm", handler.ConfirmTOTP) // 确认绑定身份验证器
+			totpv1.POST("disable", handler.DisableTOTP) // 关闭两步验证
+		}
+
+		// 为当前用户重新发送验证邮件
+		v1.POST("/verification-email", append(authMiddlewares, handler.SendVerificationEmail)...)
+
 		postv1 := v1.Group("/posts", authMiddlewares...)
 		{
-			postv1.POST("", handler.CreatePost)       // 创建博客
-			postv1.PUT(":postID", handler.UpdatePost) // 更新博客
-			postv1.DELETE("", handler.DeletePost)     // 删除博客
-			postv1.GET(":postID", handler.GetPost)    // 查询博客详情
-			postv1.GET("", handler.ListPost)          // 查询博客列表
+			postv1.POST("", handler.CreatePost)                 // 创建博客
+			postv1.PUT(":postID", handler.UpdatePost)           // 更新博客
+			postv1.DELETE("", handler.DeletePost)               // 删除博客
+			postv1.POST(":postID/restore", handler.RestorePost) // 恢复已删除的博客
+			postv1.GET(":postID", handler.GetPost)              // 查询博客详情
+			postv1.GET("", handler.ListPost)                    // 查询博客列表
 		}
 	}
 }
@@ -89,6 +178,9 @@ func InstallGenericAPI(engine *gin.Engine) {
 	// 注册pprof路由, 用来提供性能调试和优化的API接口
 	pprof.Register(engine)
 
+	// 注册prometheus指标接口
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// 注册404路由
 	engine.NoRoute(func(c *gin.Context) {
 		c.JSON(http.StatusNotFound, "Page not found")
@@ -33,8 +33,17 @@ func (m *PostM) AfterCreate(tx *gorm.DB) error {
 	return tx.Save(m).Error
 }
 
-// 在创建数据库记录后生成userID.
+// 在创建数据库记录后生成userID, 已经指定userID的用户(例如初始化的root用户)保留原有的userID.
 func (m *UserM) AfterCreate(tx *gorm.DB) error {
+	if m.UserID != "" {
+		return nil
+	}
 	m.UserID = rid.UserID.New(uint64(m.ID))
 	return tx.Save(m).Error
 }
+
+// 在创建数据库记录后生成tokenID.
+func (m *AccessTokenM) AfterCreate(tx *gorm.DB) error {
+	m.TokenID = rid.AccessTokenID.New(uint64(m.ID))
+	return tx.Save(m).Error
+}
@@ -11,19 +11,23 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const TableNamePostM = "post"
 
 // PostM 博文表
 type PostM struct {
-	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UserID    string    `gorm:"column:userID;not null;comment:用户唯一 ID" json:"userID"`                                  // 用户唯一 ID
-	PostID    string    `gorm:"column:postID;not null;uniqueIndex:idx_post_postID;comment:博文唯一 ID" json:"postID"`      // 博文唯一 ID
-	Title     string    `gorm:"column:title;not null;comment:博文标题" json:"title"`                                       // 博文标题
-	Content   string    `gorm:"column:content;not null;comment:博文内容" json:"content"`                                   // 博文内容
-	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:博文创建时间" json:"createdAt"`   // 博文创建时间
-	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp;comment:博文最后修改时间" json:"updatedAt"` // 博文最后修改时间
+	ID        int64          `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UserID    string         `gorm:"column:userID;not null;comment:用户唯一 ID" json:"userID"`                                  // 用户唯一 ID
+	TenantID  string         `gorm:"column:tenantID;not null;index:idx_post_tenantID;comment:博文所属租户" json:"tenantID"`       // 博文所属租户
+	PostID    string         `gorm:"column:postID;not null;uniqueIndex:idx_post_postID;comment:博文唯一 ID" json:"postID"`      // 博文唯一 ID
+	Title     string         `gorm:"column:title;not null;comment:博文标题" json:"title"`                                       // 博文标题
+	Content   string         `gorm:"column:content;not null;comment:博文内容" json:"content"`                                   // 博文内容
+	CreatedAt time.Time      `gorm:"column:createdAt;not null;default:current_timestamp;comment:博文创建时间" json:"createdAt"`   // 博文创建时间
+	UpdatedAt time.Time      `gorm:"column:updatedAt;not null;default:current_timestamp;comment:博文最后修改时间" json:"updatedAt"` // 博文最后修改时间
+	DeletedAt gorm.DeletedAt `gorm:"column:deletedAt;index:idx_post_deletedAt;comment:博文删除时间, 为空时表示未删除" json:"deletedAt"`   // 博文删除时间, 为空时表示未删除
 }
 
 // TableName PostM's table name
@@ -11,21 +11,30 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 const TableNameUserM = "user"
 
 // UserM 用户表
 type UserM struct {
-	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UserID    string    `gorm:"column:userID;not null;uniqueIndex:idx_user_userID;comment:用户唯一 ID" json:"userID"`       // 用户唯一 ID
-	Username  string    `gorm:"column:username;not null;uniqueIndex:idx_user_username;comment:用户名（唯一）" json:"username"` // 用户名（唯一）
-	Password  string    `gorm:"column:password;not null;comment:用户密码（加密后）" json:"password"`                             // 用户密码（加密后）
-	Nickname  string    `gorm:"column:nickname;not null;comment:用户昵称" json:"nickname"`                                  // 用户昵称
-	Email     string    `gorm:"column:email;not null;comment:用户电子邮箱地址" json:"email"`                                    // 用户电子邮箱地址
-	Phone     string    `gorm:"column:phone;not null;uniqueIndex:idx_user_phone;comment:用户手机号" json:"phone"`            // 用户手机号
-	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:用户创建时间" json:"createdAt"`    // 用户创建时间
-	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp;comment:用户最后修改时间" json:"updatedAt"`  // 用户最后修改时间
+	ID            int64          `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UserID        string         `gorm:"column:userID;not null;uniqueIndex:idx_user_userID;comment:用户唯一 ID" json:"userID"`                                       // 用户唯一 ID
+	TenantID      string         `gorm:"column:tenantID;not null;index:idx_user_tenantID;comment:用户所属租户" json:"tenantID"`                                        // 用户所属租户
+	Username      string         `gorm:"column:username;not null;uniqueIndex:idx_user_username;comment:用户名（唯一）" json:"username"`                                 // 用户名（唯一）
+	Password      string         `gorm:"column:password;not null;comment:用户密码（加密后）" json:"password"`                                                             // 用户密码（加密后）
+	Nickname      string         `gorm:"column:nickname;not null;comment:用户昵称" json:"nickname"`                                                                  // 用户昵称
+	Email         string         `gorm:"column:email;not null;comment:用户电子邮箱地址" json:"email"`                                                                    // 用户电子邮箱地址
+	Phone         *string        `gorm:"column:phone;uniqueIndex:idx_user_phone;comment:用户手机号, 通过外部身份提供方创建的用户可以为空" json:"phone"`                                 // 用户手机号, 通过外部身份提供方创建的用户可以为空
+	EmailVerified bool           `gorm:"column:emailVerified;not null;comment:电子邮箱是否已验证" json:"emailVerified"`                                                   // 电子邮箱是否已验证
+	VerifiedAt    *time.Time     `gorm:"column:verifiedAt;comment:电子邮箱验证时间" json:"verifiedAt"`                                                                   // 电子邮箱验证时间
+	Status        string         `gorm:"column:status;not null;default:active;index:idx_user_status;comment:用户状态, 可选值为 active, disabled 和 banned" json:"status"` // 用户状态, 可选值为 active, disabled 和 banned
+	StatusReason  string         `gorm:"column:statusReason;not null;comment:禁用或封禁用户的原因" json:"statusReason"`                                                    // 禁用或封禁用户的原因
+	StatusUntil   *time.Time     `gorm:"column:statusUntil;comment:封禁的截止时间, 为空时表示永久封禁" json:"statusUntil"`                                                       // 封禁的截止时间, 为空时表示永久封禁
+	CreatedAt     time.Time      `gorm:"column:createdAt;not null;default:current_timestamp;comment:用户创建时间" json:"createdAt"`                                    // 用户创建时间
+	UpdatedAt     time.Time      `gorm:"column:updatedAt;not null;default:current_timestamp;comment:用户最后修改时间" json:"updatedAt"`                                  // 用户最后修改时间
+	DeletedAt     gorm.DeletedAt `gorm:"column:deletedAt;index:idx_user_deletedAt;comment:用户删除时间, 为空时表示未删除" json:"deletedAt"`                                    // 用户删除时间, 为空时表示未删除
 }
 
 // TableName UserM's table name
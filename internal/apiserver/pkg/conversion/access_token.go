@@ -0,0 +1,33 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package conversion
+
+import (
+	"miniblog/internal/apiserver/model"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// 将模型层的AccessTokenM转换为Protobuf层的AccessToken, 令牌摘要不会返回给客户端.
+func AccessTokenModelToAccessTokenV1(tokenModel *model.AccessTokenM) *apiv1.AccessToken {
+	protoToken := &apiv1.AccessToken{
+		TokenID:   tokenModel.TokenID,
+		Name:      tokenModel.Name,
+		Actions:   strings.Split(tokenModel.Actions, ","),
+		CreatedAt: timestamppb.New(tokenModel.CreatedAt),
+	}
+	if tokenModel.ExpiresAt != nil {
+		protoToken.ExpiresAt = timestamppb.New(*tokenModel.ExpiresAt)
+	}
+	if tokenModel.LastUsedAt != nil {
+		protoToken.LastUsedAt = timestamppb.New(*tokenModel.LastUsedAt)
+	}
+
+	return protoToken
+}
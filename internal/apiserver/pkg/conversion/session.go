@@ -0,0 +1,32 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package conversion
+
+import (
+	"miniblog/internal/apiserver/model"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// 将模型层的SessionM转换为Protobuf层的Session.
+func SessionModelToSessionV1(sessionModel *model.SessionM) *apiv1.Session {
+	session := &apiv1.Session{
+		SessionID:  sessionModel.SessionID,
+		UserAgent:  sessionModel.UserAgent,
+		Ip:         sessionModel.IP,
+		RequestID:  sessionModel.RequestID,
+		LastSeenAt: timestamppb.New(sessionModel.LastSeenAt),
+		ExpiresAt:  timestamppb.New(sessionModel.ExpiresAt),
+		CreatedAt:  timestamppb.New(sessionModel.CreatedAt),
+	}
+	if sessionModel.RevokedAt != nil {
+		session.RevokedAt = timestamppb.New(*sessionModel.RevokedAt)
+	}
+
+	return session
+}
@@ -9,6 +9,7 @@ import (
 	"miniblog/internal/apiserver/model"
 
 	"github.com/onexstack/onexstack/pkg/core"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	apiv1 "miniblog/pkg/api/apiserver/v1"
 )
@@ -18,6 +19,16 @@ func UserModelToUserV1(userModel *model.UserM) *apiv1.User {
 
 	_ = core.CopyWithConverters(&protoUser, userModel)
 
+	// 转换器不支持*time.Time, 单独处理可以为空的验证时间
+	protoUser.VerifiedAt = nil
+	if userModel.VerifiedAt != nil {
+		protoUser.VerifiedAt = timestamppb.New(*userModel.VerifiedAt)
+	}
+	protoUser.StatusUntil = nil
+	if userModel.StatusUntil != nil {
+		protoUser.StatusUntil = timestamppb.New(*userModel.StatusUntil)
+	}
+
 	return &protoUser
 }
 
@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package denylist
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"time"
+)
+
+// dbDenylist 是基于数据库的令牌黑名单, 多个实例之间共享吊销记录.
+type dbDenylist struct {
+	store store.IStore
+	// 令牌的最长有效期, 用户级别的吊销记录需要保留这么长时间
+	retention time.Duration
+}
+
+// 确保 dbDenylist 实现了 Denylist 接口.
+var _ Denylist = (*dbDenylist)(nil)
+
+// NewDB 创建基于数据库的令牌黑名单.
+func NewDB(store store.IStore, retention time.Duration) *dbDenylist {
+	return &dbDenylist{store: store, retention: retention}
+}
+
+// Revoke 将单个令牌写入黑名单.
+func (d *dbDenylist) Revoke(ctx context.Context, userID string, jti string, expiresAt time.Time) error {
+	return d.store.RevokedToken().Create(ctx, &model.RevokedTokenM{
+		JTI:       &jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	})
+}
+
+// Consume 依赖jti的唯一索引, 只有第一个写入的调用返回true.
+func (d *dbDenylist) Consume(ctx context.Context, userID string, jti string, expiresAt time.Time) (bool, error) {
+	return d.store.RevokedToken().CreateIfAbsent(ctx, &model.RevokedTokenM{
+		JTI:       &jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	})
+}
+
+// RevokeUser 写入一条不带jti的记录, 表示吊销用户在revokedAt之前签发的全部令牌.
+func (d *dbDenylist) RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	return d.store.RevokedToken().Create(ctx, &model.RevokedTokenM{
+		UserID:    userID,
+		ExpiresAt: revokedAt.Add(d.retention),
+		CreatedAt: revokedAt,
+	})
+}
+
+// IsRevoked 判断令牌是否已被吊销.
+func (d *dbDenylist) IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error) {
+	return d.store.RevokedToken().IsRevoked(ctx, userID, jti, issuedAt)
+}
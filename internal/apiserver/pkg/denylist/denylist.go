@@ -0,0 +1,25 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package denylist 实现了访问令牌黑名单, 用于在令牌过期前提前使其失效.
+package denylist
+
+import (
+	"context"
+	"time"
+)
+
+// Denylist 定义了令牌黑名单需要实现的方法.
+type Denylist interface {
+	// Revoke 吊销jti对应的单个令牌, 记录会保留到令牌过期.
+	Revoke(ctx context.Context, userID string, jti string, expiresAt time.Time) error
+	// Consume 吊销只能使用一次的令牌, 返回值表示本次调用是否真正完成了吊销.
+	// 并发使用同一个令牌时只有一个调用返回true.
+	Consume(ctx context.Context, userID string, jti string, expiresAt time.Time) (bool, error)
+	// RevokeUser 吊销用户在revokedAt之前签发的全部令牌.
+	RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error
+	// IsRevoked 判断令牌是否已被吊销.
+	IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error)
+}
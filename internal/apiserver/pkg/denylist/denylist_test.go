@@ -0,0 +1,112 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package denylist
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 创建基于SQLite内存数据库的黑名单, store.NewStore只会初始化一次, 各个测试共享同一个数据库, 因此测试数据不能重复.
+func newTestDB(t *testing.T) Denylist {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.RevokedTokenM{}))
+	// 内存数据库的每个连接都是一个独立的数据库, 并发测试时只能使用同一个连接
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	return NewDB(store.NewStore(db), time.Hour)
+}
+
+func TestDenylist(t *testing.T) {
+	for name, newDenylist := range map[string]func(t *testing.T) Denylist{
+		"memory": func(t *testing.T) Denylist { return NewMemory() },
+		"db":     newTestDB,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newDenylist(t)
+			now := time.Now()
+
+			revoked, err := d.IsRevoked(ctx, "user-000001", "jti-1", now)
+			require.NoError(t, err)
+			assert.False(t, revoked)
+
+			// 吊销单个令牌不影响同一用户的其他令牌
+			require.NoError(t, d.Revoke(ctx, "user-000001", "jti-1", now.Add(time.Hour)))
+			revoked, err = d.IsRevoked(ctx, "user-000001", "jti-1", now)
+			require.NoError(t, err)
+			assert.True(t, revoked)
+			revoked, err = d.IsRevoked(ctx, "user-000001", "jti-2", now)
+			require.NoError(t, err)
+			assert.False(t, revoked)
+
+			// 吊销用户全部令牌后, 之前签发的令牌失效, 之后签发的令牌不受影响
+			require.NoError(t, d.RevokeUser(ctx, "user-000002", now))
+			revoked, err = d.IsRevoked(ctx, "user-000002", "jti-3", now.Add(-time.Minute))
+			require.NoError(t, err)
+			assert.True(t, revoked)
+			revoked, err = d.IsRevoked(ctx, "user-000002", "jti-4", now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.False(t, revoked)
+			revoked, err = d.IsRevoked(ctx, "user-000003", "jti-5", now.Add(-time.Minute))
+			require.NoError(t, err)
+			assert.False(t, revoked)
+		})
+	}
+}
+
+func TestDenylistConsume(t *testing.T) {
+	for name, newDenylist := range map[string]func(t *testing.T) Denylist{
+		"memory": func(t *testing.T) Denylist { return NewMemory() },
+		"db":     newTestDB,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newDenylist(t)
+			expiresAt := time.Now().Add(time.Hour)
+
+			// 并发使用同一个令牌时只有一个调用能够完成吊销
+			var wg sync.WaitGroup
+			var consumed atomic.Int64
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ok, err := d.Consume(ctx, "user-000004", "jti-consume-1", expiresAt)
+					assert.NoError(t, err)
+					if ok {
+						consumed.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+			assert.EqualValues(t, 1, consumed.Load())
+
+			revoked, err := d.IsRevoked(ctx, "user-000004", "jti-consume-1", time.Now())
+			require.NoError(t, err)
+			assert.True(t, revoked)
+
+			// 已经单独吊销的令牌不能再被使用
+			require.NoError(t, d.Revoke(ctx, "user-000004", "jti-consume-2", expiresAt))
+			ok, err := d.Consume(ctx, "user-000004", "jti-consume-2", expiresAt)
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
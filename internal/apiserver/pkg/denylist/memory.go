@@ -0,0 +1,89 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package denylist
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryDenylist 是基于内存的令牌黑名单, 只适用于单实例部署.
+type memoryDenylist struct {
+	mu sync.RWMutex
+	// jti到令牌过期时间的映射
+	tokens map[string]time.Time
+	// 用户ID到最近一次全部吊销时间的映射
+	users map[string]time.Time
+}
+
+// 确保 memoryDenylist 实现了 Denylist 接口.
+var _ Denylist = (*memoryDenylist)(nil)
+
+// NewMemory 创建基于内存的令牌黑名单.
+func NewMemory() *memoryDenylist {
+	return &memoryDenylist{
+		tokens: make(map[string]time.Time),
+		users:  make(map[string]time.Time),
+	}
+}
+
+// Revoke 吊销单个令牌, 同时清理已经过期的记录, 避免内存无限增长.
+func (d *memoryDenylist) Revoke(ctx context.Context, userID string, jti string, expiresAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range d.tokens {
+		if now.After(exp) {
+			delete(d.tokens, id)
+		}
+	}
+	d.tokens[jti] = expiresAt
+
+	return nil
+}
+
+// Consume 在同一把锁内判断并吊销单个令牌.
+func (d *memoryDenylist) Consume(ctx context.Context, userID string, jti string, expiresAt time.Time) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.tokens[jti]; ok {
+		return false, nil
+	}
+	d.tokens[jti] = expiresAt
+
+	return true, nil
+}
+
+// RevokeUser 记录用户的全部吊销时间, 只保留最近的一次.
+func (d *memoryDenylist) RevokeUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if revokedAt.After(d.users[userID]) {
+		d.users[userID] = revokedAt
+	}
+
+	return nil
+}
+
+// IsRevoked 判断令牌是否已被吊销.
+// iat 只精确到秒, 因此与吊销操作处于同一秒内签发的令牌不会被视为已吊销.
+func (d *memoryDenylist) IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.tokens[jti]; ok {
+		return true, nil
+	}
+	if revokedAt, ok := d.users[userID]; ok && issuedAt.Before(revokedAt.Truncate(time.Second)) {
+		return true, nil
+	}
+
+	return false, nil
+}
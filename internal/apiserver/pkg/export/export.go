@@ -0,0 +1,135 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package export 将用户的个人数据写入归档, 用于响应数据主体的导出请求.
+// 归档支持单个JSON文档和zip两种格式, 两种格式包含相同的数据.
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version 表示归档格式的版本, 归档的结构发生不兼容的变化时递增.
+const Version = 1
+
+// 导出所有字段, 未设置的字段同样写入归档, 使不同用户的归档结构一致.
+var marshalOptions = protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}
+
+// Manifest 描述zip归档的内容, 写入归档中的manifest.json.
+type Manifest struct {
+	Version    int32     `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	UserID     string    `json:"userID"`
+	Files      []string  `json:"files"`
+}
+
+// ContentType 返回归档格式对应的MIME类型.
+func ContentType(format string) string {
+	if format == known.DataExportFormatZIP {
+		return "application/zip"
+	}
+	return "application/json"
+}
+
+// Filename 返回归档的默认文件名.
+func Filename(userID string, format string) string {
+	return fmt.Sprintf("miniblog-export-%s.%s", userID, format)
+}
+
+// ContentDisposition 返回以附件形式下载归档时使用的Content-Disposition响应头.
+func ContentDisposition(userID string, format string) string {
+	return fmt.Sprintf("attachment; filename=%q", Filename(userID, format))
+}
+
+// Write 按format将归档写入w, 不支持的格式返回错误.
+func Write(w io.Writer, format string, data *apiv1.DataExport) error {
+	switch format {
+	case known.DataExportFormatJSON:
+		return writeJSON(w, data)
+	case known.DataExportFormatZIP:
+		return writeZIP(w, data)
+	default:
+		return fmt.Errorf("unsupported data export format %q", format)
+	}
+}
+
+// 将全部数据写入一个JSON文档.
+func writeJSON(w io.Writer, data *apiv1.DataExport) error {
+	b, err := marshalOptions.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// zip归档中每类数据一个文件, 便于用户直接查看.
+func writeZIP(w io.Writer, data *apiv1.DataExport) error {
+	files := []struct {
+		name    string
+		marshal func() ([]byte, error)
+	}{
+		{"profile.json", func() ([]byte, error) { return marshalOptions.Marshal(data.GetUser()) }},
+		{"posts.json", func() ([]byte, error) { return marshalList(data.GetPosts()) }},
+		{"roles.json", func() ([]byte, error) { return marshalList(data.GetRoles()) }},
+		{"sessions.json", func() ([]byte, error) { return marshalList(data.GetSessions()) }},
+	}
+
+	manifest := Manifest{
+		Version:    data.GetVersion(),
+		ExportedAt: data.GetExportedAt().AsTime(),
+		UserID:     data.GetUser().GetUserID(),
+	}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file.name)
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeZIPFile(zw, "manifest.json", func() ([]byte, error) { return json.MarshalIndent(manifest, "", "  ") }); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeZIPFile(zw, file.name, file.marshal); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// 在zip归档中写入一个文件.
+func writeZIPFile(zw *zip.Writer, name string, marshal func() ([]byte, error)) error {
+	b, err := marshal()
+	if err != nil {
+		return err
+	}
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(b)
+	return err
+}
+
+// 将消息列表序列化为JSON数组, protojson只能序列化单个消息.
+func marshalList[T proto.Message](items []T) ([]byte, error) {
+	list := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, b)
+	}
+	return json.MarshalIndent(list, "", "  ")
+}
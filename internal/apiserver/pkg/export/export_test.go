@@ -0,0 +1,102 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 创建包含各类数据的归档内容.
+func newTestData() *apiv1.DataExport {
+	return &apiv1.DataExport{
+		Version:    Version,
+		ExportedAt: timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		User:       &apiv1.User{UserID: "user-000001", Username: "colin"},
+		Posts: []*apiv1.Post{
+			{PostID: "post-000001", UserID: "user-000001", Title: "hello"},
+			{PostID: "post-000002", UserID: "user-000001", Title: "world"},
+		},
+		Roles:    []*apiv1.RoleAssignment{{Role: "role::user", Domain: "default"}},
+		Sessions: []*apiv1.Session{{SessionID: "session-000001"}},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	data := newTestData()
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, known.DataExportFormatJSON, data))
+
+	var got apiv1.DataExport
+	require.NoError(t, protojson.Unmarshal(buf.Bytes(), &got))
+	assert.True(t, proto.Equal(data, &got))
+	assert.Equal(t, "application/json", ContentType(known.DataExportFormatJSON))
+}
+
+func TestWriteZIP(t *testing.T) {
+	data := newTestData()
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, known.DataExportFormatZIP, data))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	var names []string
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = b
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"manifest.json", "profile.json", "posts.json", "roles.json", "sessions.json"}, names)
+
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, int32(Version), manifest.Version)
+	assert.Equal(t, "user-000001", manifest.UserID)
+	assert.Equal(t, names[1:], manifest.Files)
+
+	var posts []json.RawMessage
+	require.NoError(t, json.Unmarshal(files["posts.json"], &posts))
+	require.Len(t, posts, 2)
+	var post apiv1.Post
+	require.NoError(t, protojson.Unmarshal(posts[1], &post))
+	assert.Equal(t, "world", post.GetTitle())
+
+	// 没有数据时写入空数组而不是null
+	data.Sessions = nil
+	buf.Reset()
+	require.NoError(t, Write(&buf, known.DataExportFormatZIP, data))
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	rc, err := zr.Open("sessions.json")
+	require.NoError(t, err)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	assert.Error(t, Write(io.Discard, "tar", newTestData()))
+}
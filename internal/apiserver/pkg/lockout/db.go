@@ -0,0 +1,61 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package lockout
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/store"
+	"time"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+)
+
+// dbStore 是基于数据库的计数存储, 多个实例之间共享失败记录.
+type dbStore struct {
+	store store.IStore
+}
+
+var _ Store = (*dbStore)(nil)
+
+// NewDB 创建基于数据库的计数存储.
+func NewDB(store store.IStore) *dbStore {
+	return &dbStore{store: store}
+}
+
+// Get 返回subject的失败记录.
+func (s *dbStore) Get(ctx context.Context, subject string) (Attempt, error) {
+	attemptM, err := s.store.LoginAttempt().Get(ctx, where.F("subject", subject))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Attempt{}, nil
+	}
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	return Attempt{Failures: int(attemptM.Failures), LastFailedAt: attemptM.LastFailedAt}, nil
+}
+
+// Increment 累加失败次数.
+func (s *dbStore) Increment(ctx context.Context, subject string, now time.Time, resetAfter time.Duration) (Attempt, error) {
+	attemptM, err := s.store.LoginAttempt().Increment(ctx, subject, now, now.Add(-resetAfter))
+	if err != nil || attemptM == nil {
+		return Attempt{}, err
+	}
+
+	return Attempt{Failures: int(attemptM.Failures), LastFailedAt: attemptM.LastFailedAt}, nil
+}
+
+// Decrement 减少失败次数.
+func (s *dbStore) Decrement(ctx context.Context, subject string) error {
+	return s.store.LoginAttempt().Decrement(ctx, subject)
+}
+
+// Reset 清除subject的失败记录.
+func (s *dbStore) Reset(ctx context.Context, subject string) error {
+	return s.store.LoginAttempt().Delete(ctx, where.F("subject", subject))
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package lockout 按用户名和客户端IP统计连续登录失败的次数, 并据此限制登录频率, 用于防止密码被暴力破解.
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// Attempt 表示一个计数对象连续登录失败的情况.
+type Attempt struct {
+	// 连续失败次数
+	Failures int
+	// 最近一次失败的时间
+	LastFailedAt time.Time
+}
+
+// Store 定义了登录失败计数的存储接口.
+type Store interface {
+	// Get 返回subject的失败记录, 没有记录时返回零值.
+	Get(ctx context.Context, subject string) (Attempt, error)
+	// Increment 将subject的失败次数加一, 最近一次失败早于now-resetAfter的记录从1重新计数.
+	// 返回累加之前的失败记录, 并发调用时每个调用看到的记录都包含之前调用的计数.
+	Increment(ctx context.Context, subject string, now time.Time, resetAfter time.Duration) (Attempt, error)
+	// Decrement 将subject的失败次数减一.
+	Decrement(ctx context.Context, subject string) error
+	// Reset 清除subject的失败记录.
+	Reset(ctx context.Context, subject string) error
+}
+
+// Policy 定义了连续失败后的限制策略.
+// 前FreeAttempts次失败不受限制, 之后每次失败需要等待的时间从BaseDelay开始翻倍, 最多为MaxDelay.
+// 失败次数达到MaxFailures后锁定LockoutDuration, 锁定期间的每次失败都会重新锁定.
+type Policy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	MaxFailures     int
+	LockoutDuration time.Duration
+	// 超过ResetAfter没有再失败时, 失败记录失效
+	ResetAfter time.Duration
+}
+
+// DefaultUsernamePolicy 是按用户名计数的默认策略.
+var DefaultUsernamePolicy = Policy{
+	FreeAttempts:    3,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+	MaxFailures:     10,
+	LockoutDuration: 15 * time.Minute,
+	ResetAfter:      time.Hour,
+}
+
+// DefaultIPPolicy 是按客户端IP计数的默认策略, 同一个IP可能被多个用户共享, 因此限制较为宽松.
+var DefaultIPPolicy = Policy{
+	FreeAttempts:    20,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+	MaxFailures:     100,
+	LockoutDuration: 15 * time.Minute,
+	ResetAfter:      time.Hour,
+}
+
+// 返回失败记录解除限制的时间, 不受限制时返回零值.
+func (p Policy) blockedUntil(a Attempt, now time.Time) time.Time {
+	if a.Failures <= p.FreeAttempts || now.Sub(a.LastFailedAt) > p.ResetAfter {
+		return time.Time{}
+	}
+	if a.Failures >= p.MaxFailures {
+		return a.LastFailedAt.Add(p.LockoutDuration)
+	}
+
+	delay := p.MaxDelay
+	// 避免移位溢出
+	if shift := a.Failures - p.FreeAttempts - 1; shift < 32 && p.BaseDelay<<shift < p.MaxDelay {
+		delay = p.BaseDelay << shift
+	}
+	return a.LastFailedAt.Add(delay)
+}
+
+// Guard 根据失败记录判断是否允许登录.
+type Guard struct {
+	store    Store
+	username Policy
+	ip       Policy
+	now      func() time.Time
+}
+
+// 函数选项类型, 用于自定义New的行为.
+type Option func(*Guard)
+
+// 允许通过选项自定义按用户名计数的策略.
+func WithUsernamePolicy(policy Policy) Option {
+	return func(g *Guard) {
+		g.username = policy
+	}
+}
+
+// 允许通过选项自定义按客户端IP计数的策略.
+func WithIPPolicy(policy Policy) Option {
+	return func(g *Guard) {
+		g.ip = policy
+	}
+}
+
+// 允许通过选项注入时钟, 便于测试.
+func WithClock(now func() time.Time) Option {
+	return func(g *Guard) {
+		if now != nil {
+			g.now = now
+		}
+	}
+}
+
+// New 创建一个Guard实例.
+func New(store Store, opts ...Option) *Guard {
+	g := &Guard{store: store, username: DefaultUsernamePolicy, ip: DefaultIPPolicy, now: time.Now}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Acquire 在校验凭证之前记录一次登录尝试, 并判断用户名和IP是否允许本次尝试, 被限制时返回允许再次尝试的时间, 否则返回零值.
+// 尝试先按失败计数, 并发的请求无法绕过限制, 凭证正确时需要调用Release撤销本次计数. 被限制的尝试同样计入失败次数.
+// 不存在的用户名同样会被计数和限制, 因此无法通过是否被限制判断用户名是否存在.
+func (g *Guard) Acquire(ctx context.Context, username string, ip string) (time.Time, error) {
+	now := g.now()
+
+	var until time.Time
+	for _, c := range g.subjects(username, ip) {
+		previous, err := g.store.Increment(ctx, c.subject, now, c.policy.ResetAfter)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t := c.policy.blockedUntil(previous, now); t.After(now) && t.After(until) {
+			until = t
+		}
+	}
+
+	return until, nil
+}
+
+// Release 撤销Acquire记录的一次尝试, 在凭证校验通过时调用.
+func (g *Guard) Release(ctx context.Context, username string, ip string) error {
+	for _, c := range g.subjects(username, ip) {
+		if err := g.store.Decrement(ctx, c.subject); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reset 清除用户名的失败记录, 在登录成功或管理员解锁时调用. IP的失败记录只会随时间失效.
+func (g *Guard) Reset(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, usernameSubject(username))
+}
+
+// 计数对象及其适用的策略.
+type subject struct {
+	subject string
+	policy  Policy
+}
+
+// 返回需要检查的计数对象, 无法获取客户端IP时只按用户名计数.
+func (g *Guard) subjects(username string, ip string) []subject {
+	subjects := []subject{{subject: usernameSubject(username), policy: g.username}}
+	if ip != "" {
+		subjects = append(subjects, subject{subject: "ip:" + ip, policy: g.ip})
+	}
+
+	return subjects
+}
+
+// 返回用户名对应的计数对象.
+func usernameSubject(username string) string {
+	return "username:" + username
+}
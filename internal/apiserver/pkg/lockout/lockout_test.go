@@ -0,0 +1,205 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package lockout
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 创建基于SQLite内存数据库的计数存储, store.NewStore只会初始化一次, 各个测试共享同一个数据库, 因此计数对象不能重复.
+func newTestDB(t *testing.T) Store {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.LoginAttemptM{}))
+	// 内存数据库的每个连接都是一个独立的数据库, 并发测试时只能使用同一个连接
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	return NewDB(store.NewStore(db))
+}
+
+// 校验计数存储的行为, 基于内存和基于数据库的实现需要表现一致.
+func testStore(t *testing.T, s Store, subject string) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	attempt, err := s.Get(ctx, subject)
+	require.NoError(t, err)
+	assert.Zero(t, attempt.Failures)
+
+	// Increment返回累加之前的记录
+	previous, err := s.Increment(ctx, subject, now, time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, previous.Failures)
+	previous, err = s.Increment(ctx, subject, now.Add(time.Minute), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, previous.Failures)
+	assert.True(t, now.Equal(previous.LastFailedAt))
+
+	attempt, err = s.Get(ctx, subject)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempt.Failures)
+	assert.True(t, now.Add(time.Minute).Equal(attempt.LastFailedAt))
+
+	// 失败次数不会小于0
+	for range 3 {
+		require.NoError(t, s.Decrement(ctx, subject))
+	}
+	attempt, err = s.Get(ctx, subject)
+	require.NoError(t, err)
+	assert.Zero(t, attempt.Failures)
+
+	// 超过resetAfter没有失败的记录从1重新计数
+	for range 3 {
+		_, err = s.Increment(ctx, subject, now.Add(time.Minute), time.Hour)
+		require.NoError(t, err)
+	}
+	_, err = s.Increment(ctx, subject, now.Add(2*time.Hour), time.Hour)
+	require.NoError(t, err)
+	attempt, err = s.Get(ctx, subject)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures)
+
+	require.NoError(t, s.Reset(ctx, subject))
+	attempt, err = s.Get(ctx, subject)
+	require.NoError(t, err)
+	assert.Zero(t, attempt.Failures)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemory(), "username:alice")
+}
+
+func TestDBStore(t *testing.T) {
+	testStore(t, newTestDB(t), "username:db-alice")
+}
+
+func TestPolicyBlockedUntil(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := DefaultUsernamePolicy
+
+	assert.True(t, p.blockedUntil(Attempt{Failures: 3, LastFailedAt: base}, base).IsZero())
+	assert.Equal(t, base.Add(time.Second), p.blockedUntil(Attempt{Failures: 4, LastFailedAt: base}, base))
+	assert.Equal(t, base.Add(4*time.Second), p.blockedUntil(Attempt{Failures: 6, LastFailedAt: base}, base))
+	assert.Equal(t, base.Add(p.LockoutDuration), p.blockedUntil(Attempt{Failures: 10, LastFailedAt: base}, base))
+
+	// 退避时间不超过MaxDelay
+	p.MaxFailures = 100
+	assert.Equal(t, base.Add(p.MaxDelay), p.blockedUntil(Attempt{Failures: 90, LastFailedAt: base}, base))
+
+	// 失败记录超过ResetAfter后失效
+	assert.True(t, p.blockedUntil(Attempt{Failures: 10, LastFailedAt: base}, base.Add(2*time.Hour)).IsZero())
+}
+
+func TestGuard(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := New(NewMemory(), WithClock(func() time.Time { return now }), WithIPPolicy(Policy{
+		FreeAttempts:    5,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		MaxFailures:     20,
+		LockoutDuration: time.Hour,
+		ResetAfter:      time.Hour,
+	}))
+
+	// 前几次失败不受限制, 之后需要等待退避时间
+	for range DefaultUsernamePolicy.FreeAttempts + 1 {
+		until, err := g.Acquire(ctx, "alice", "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, until.IsZero())
+	}
+	until, err := g.Acquire(ctx, "alice", "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Second), until)
+
+	// 被限制的尝试同样计入失败次数
+	now = now.Add(time.Second)
+	until, err = g.Acquire(ctx, "alice", "10.0.0.2")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Second), until)
+
+	// 凭证正确时撤销本次计数, 不影响之后的尝试
+	now = now.Add(2 * time.Second)
+	until, err = g.Acquire(ctx, "alice", "10.0.0.3")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Second), until)
+	require.NoError(t, g.Release(ctx, "alice", "10.0.0.3"))
+	now = now.Add(4 * time.Second)
+	until, err = g.Acquire(ctx, "alice", "10.0.0.3")
+	require.NoError(t, err)
+	assert.True(t, until.IsZero())
+
+	// 同一个IP尝试多个用户名时按IP限制
+	until, err = g.Acquire(ctx, "bob", "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, until.IsZero())
+	until, err = g.Acquire(ctx, "carol", "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Second), until)
+
+	// 成功登录后清除用户名的失败记录
+	require.NoError(t, g.Reset(ctx, "alice"))
+	until, err = g.Acquire(ctx, "alice", "")
+	require.NoError(t, err)
+	assert.True(t, until.IsZero())
+}
+
+func TestGuardLockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := New(NewMemory(), WithClock(func() time.Time { return now }))
+
+	for range DefaultUsernamePolicy.MaxFailures {
+		_, err := g.Acquire(ctx, "alice", "")
+		require.NoError(t, err)
+	}
+	until, err := g.Acquire(ctx, "alice", "")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(DefaultUsernamePolicy.LockoutDuration), until)
+
+	// 长时间没有失败后, 失败记录重新计数
+	now = now.Add(2 * time.Hour)
+	until, err = g.Acquire(ctx, "alice", "")
+	require.NoError(t, err)
+	assert.True(t, until.IsZero())
+}
+
+func TestGuardConcurrentAcquire(t *testing.T) {
+	for _, s := range []Store{NewMemory(), newTestDB(t)} {
+		ctx := context.Background()
+		g := New(s)
+
+		// 并发的尝试不能绕过限制, 只有前FreeAttempts+1次尝试被允许
+		var wg sync.WaitGroup
+		var allowed atomic.Int64
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				until, err := g.Acquire(ctx, "concurrent", "")
+				assert.NoError(t, err)
+				if until.IsZero() {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.EqualValues(t, DefaultUsernamePolicy.FreeAttempts+1, allowed.Load())
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore 是基于内存的计数存储, 只适用于单实例部署和测试.
+type memoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]Attempt
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemory 创建基于内存的计数存储.
+func NewMemory() *memoryStore {
+	return &memoryStore{attempts: make(map[string]Attempt)}
+}
+
+// Get 返回subject的失败记录.
+func (s *memoryStore) Get(ctx context.Context, subject string) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attempts[subject], nil
+}
+
+// Increment 累加失败次数, 同时清理已经失效的记录, 防止内存无限增长.
+func (s *memoryStore) Increment(ctx context.Context, subject string, now time.Time, resetAfter time.Duration) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	staleBefore := now.Add(-resetAfter)
+	for key, attempt := range s.attempts {
+		if attempt.LastFailedAt.Before(staleBefore) {
+			delete(s.attempts, key)
+		}
+	}
+
+	previous := s.attempts[subject]
+	s.attempts[subject] = Attempt{Failures: previous.Failures + 1, LastFailedAt: now}
+
+	return previous, nil
+}
+
+// Decrement 减少失败次数, 失败次数不会小于0.
+func (s *memoryStore) Decrement(ctx context.Context, subject string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[subject]; ok && attempt.Failures > 0 {
+		attempt.Failures--
+		s.attempts[subject] = attempt
+	}
+
+	return nil
+}
+
+// Reset 清除subject的失败记录.
+func (s *memoryStore) Reset(ctx context.Context, subject string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, subject)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package permission
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+)
+
+// 旧策略中 gRPC 方法使用的动作.
+const grpcAction = "CALL"
+
+// IsLegacyRule 判断策略是否为按 gRPC 方法或 URL 路径编写的旧策略, 旧策略的资源都以/开头.
+// 策略的字段依次为主体, 域, 资源和动作.
+func IsLegacyRule(rule []string) bool {
+	return len(rule) >= 4 && strings.HasPrefix(rule[2], "/")
+}
+
+// MigrateRule 将按 gRPC 方法或 URL 路径编写的旧策略转换为按权限编写的策略.
+// 旧策略的资源支持 keyMatch 通配符, 一条旧策略可能对应多个权限, 无法对应任何权限时返回false.
+func (r *Registry) MigrateRule(rule []string) ([][]string, bool) {
+	if !IsLegacyRule(rule) {
+		return nil, false
+	}
+
+	object, action := rule[2], rule[3]
+
+	var permissions []string
+	if action == grpcAction {
+		for fullMethod, permission := range r.methods {
+			if util.KeyMatch(fullMethod, object) {
+				permissions = append(permissions, permission)
+			}
+		}
+	} else {
+		for _, b := range r.bindings {
+			if b.method == action && util.KeyMatch(samplePath(b.path), object) {
+				permissions = append(permissions, b.permission)
+			}
+		}
+	}
+
+	sort.Strings(permissions)
+	seen := make(map[string]struct{}, len(permissions))
+	var rules [][]string
+	for _, permission := range permissions {
+		if _, ok := seen[permission]; ok {
+			continue
+		}
+		seen[permission] = struct{}{}
+
+		resource, verb := Split(permission)
+		migrated := append([]string{rule[0], rule[1], resource, verb}, rule[4:]...)
+		rules = append(rules, migrated)
+	}
+
+	return rules, len(rules) > 0
+}
+
+// 将路径模板中的变量替换为变量名, 得到一个可以用于匹配的示例路径, 例如 /v1/users/{userID} 转换为 /v1/users/userID.
+func samplePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i], _, _ = strings.Cut(strings.Trim(segment, "{}"), "=")
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package permission 将 gRPC 方法和 HTTP 路由解析为与传输协议无关的权限名称.
+// 权限名称在 proto 文件中通过 (v1.permission) 方法选项为每个 RPC 声明一次, 格式为 resource:verb.
+package permission
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/wire"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// ProviderSet 是权限注册表的 Wire 的 Provider 集合.
+var ProviderSet = wire.NewSet(NewRegistry)
+
+// 权限名称中资源和动作的分隔符.
+const separator = ":"
+
+// Registry 记录每个 RPC 声明的权限, 构建后只读, 可以并发使用.
+type Registry struct {
+	// gRPC 完整方法名到权限的映射, 例如 /v1.MiniBlog/DeleteUser
+	methods map[string]string
+	// HTTP 方法和 gin 路由到权限的映射, 例如 DELETE /v1/users/:userID
+	routes map[string]string
+	// 所有 HTTP 绑定, 用于迁移按 URL 路径编写的策略
+	bindings []binding
+}
+
+// binding 表示 RPC 的一个 HTTP 绑定.
+type binding struct {
+	method     string
+	path       string
+	permission string
+}
+
+// NewRegistry 根据 MiniBlog 服务的描述创建权限注册表.
+func NewRegistry() *Registry {
+	return newRegistry(apiv1.File_apiserver_v1_apiserver_proto.Services().ByName("MiniBlog"))
+}
+
+// 根据服务描述创建权限注册表, 没有声明权限的方法不会被注册.
+func newRegistry(sd protoreflect.ServiceDescriptor) *Registry {
+	r := &Registry{methods: make(map[string]string), routes: make(map[string]string)}
+
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		permission, _ := proto.GetExtension(md.Options(), apiv1.E_Permission).(string)
+		if permission == "" {
+			continue
+		}
+
+		r.methods["/"+string(sd.FullName())+"/"+string(md.Name())] = permission
+
+		rule, _ := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+		for _, b := range httpBindings(rule, permission) {
+			r.routes[b.method+" "+ginRoute(b.path)] = permission
+			r.bindings = append(r.bindings, b)
+		}
+	}
+
+	return r
+}
+
+// MethodPermission 返回 gRPC 方法声明的权限.
+func (r *Registry) MethodPermission(fullMethod string) (string, bool) {
+	permission, ok := r.methods[fullMethod]
+	return permission, ok
+}
+
+// RoutePermission 返回 HTTP 路由对应的 RPC 声明的权限, route 为 gin 注册的路由, 例如 /v1/users/:userID.
+func (r *Registry) RoutePermission(method string, route string) (string, bool) {
+	permission, ok := r.routes[method+" "+route]
+	return permission, ok
+}
+
+// Has 判断权限是否被某个 RPC 声明.
+func (r *Registry) Has(permission string) bool {
+	for _, declared := range r.methods {
+		if declared == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions 返回所有声明的权限, 按名称排序.
+func (r *Registry) Permissions() []string {
+	seen := make(map[string]struct{}, len(r.methods))
+	permissions := make([]string, 0, len(r.methods))
+	for _, permission := range r.methods {
+		if _, ok := seen[permission]; ok {
+			continue
+		}
+		seen[permission] = struct{}{}
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+
+	return permissions
+}
+
+// Split 将权限拆分为授权时使用的资源和动作, 例如 user:delete 拆分为 user 和 delete.
+func Split(permission string) (resource string, verb string) {
+	resource, verb, _ = strings.Cut(permission, separator)
+	return resource, verb
+}
+
+// 返回 HTTP 规则中的全部绑定, 包括附加绑定.
+func httpBindings(rule *annotations.HttpRule, permission string) []binding {
+	if rule == nil {
+		return nil
+	}
+
+	var bindings []binding
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		bindings = append(bindings, binding{method: "GET", path: pattern.Get, permission: permission})
+	case *annotations.HttpRule_Post:
+		bindings = append(bindings, binding{method: "POST", path: pattern.Post, permission: permission})
+	case *annotations.HttpRule_Put:
+		bindings = append(bindings, binding{method: "PUT", path: pattern.Put, permission: permission})
+	case *annotations.HttpRule_Delete:
+		bindings = append(bindings, binding{method: "DELETE", path: pattern.Delete, permission: permission})
+	case *annotations.HttpRule_Patch:
+		bindings = append(bindings, binding{method: "PATCH", path: pattern.Patch, permission: permission})
+	case *annotations.HttpRule_Custom:
+		bindings = append(bindings, binding{method: pattern.Custom.GetKind(), path: pattern.Custom.GetPath(), permission: permission})
+	}
+	for _, additional := range rule.GetAdditionalBindings() {
+		bindings = append(bindings, httpBindings(additional, permission)...)
+	}
+
+	return bindings
+}
+
+// 将 HTTP 规则中的路径模板转换为 gin 路由, 例如 /v1/users/{userID} 转换为 /v1/users/:userID.
+func ginRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name, _, _ := strings.Cut(strings.Trim(segment, "{}"), "=")
+			segments[i] = ":" + name
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
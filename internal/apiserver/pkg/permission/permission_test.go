@@ -0,0 +1,74 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	permission, ok := r.MethodPermission("/v1.MiniBlog/DeleteUser")
+	assert.True(t, ok)
+	assert.Equal(t, "user:delete", permission)
+
+	permission, ok = r.RoutePermission("DELETE", "/v1/users/:userID")
+	assert.True(t, ok)
+	assert.Equal(t, "user:delete", permission)
+
+	permission, ok = r.RoutePermission("DELETE", "/v1/user-roles/:userID/:role")
+	assert.True(t, ok)
+	assert.Equal(t, "user-role:revoke", permission)
+
+	// 不需要授权的方法没有声明权限
+	_, ok = r.MethodPermission("/v1.MiniBlog/Login")
+	assert.False(t, ok)
+	_, ok = r.RoutePermission("POST", "/login")
+	assert.False(t, ok)
+
+	assert.Contains(t, r.Permissions(), "post:create")
+	assert.True(t, r.Has("post:create"))
+	assert.False(t, r.Has("POST"))
+}
+
+func TestSplit(t *testing.T) {
+	resource, verb := Split("user-session:revoke")
+	assert.Equal(t, "user-session", resource)
+	assert.Equal(t, "revoke", verb)
+}
+
+func TestMigrateRule(t *testing.T) {
+	r := NewRegistry()
+
+	rules, ok := r.MigrateRule([]string{"role::user", "*", "/v1.MiniBlog/DeleteUser", "CALL", "deny"})
+	assert.True(t, ok)
+	assert.Equal(t, [][]string{{"role::user", "*", "user", "delete", "deny"}}, rules)
+
+	rules, ok = r.MigrateRule([]string{"role::user", "*", "/v1/users", "GET", "deny"})
+	assert.True(t, ok)
+	assert.Equal(t, [][]string{{"role::user", "*", "user", "list", "deny"}}, rules)
+
+	// 通配符可能对应多个权限
+	rules, ok = r.MigrateRule([]string{"role::user", "*", "/v1/user-sessions/*", "GET", "deny"})
+	assert.True(t, ok)
+	assert.Equal(t, [][]string{{"role::user", "*", "user-session", "list", "deny"}}, rules)
+
+	rules, ok = r.MigrateRule([]string{"role::user", "*", "/v1/users/*", "PUT", "deny"})
+	assert.True(t, ok)
+	assert.Equal(t, [][]string{
+		{"role::user", "*", "user", "change-password", "deny"},
+		{"role::user", "*", "user", "update", "deny"},
+		{"role::user", "*", "user", "update-status", "deny"},
+	}, rules)
+
+	_, ok = r.MigrateRule([]string{"role::user", "*", "/v1/unknown", "GET", "deny"})
+	assert.False(t, ok)
+	_, ok = r.MigrateRule([]string{"role::user", "*", "user", "delete", "deny"})
+	assert.False(t, ok)
+}
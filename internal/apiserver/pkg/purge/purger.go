@@ -0,0 +1,132 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package purge 永久删除超过保留时间的已删除用户和博客.
+// 删除用户和博客时只记录删除时间, 保留期内管理员可以恢复, 超过保留时间后由后台任务清理.
+package purge
+
+import (
+	"context"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/log"
+	"time"
+)
+
+// PolicyRemover 定义了从授权器中删除用户策略的接口, 用于在数据库中的策略被删除后同步授权器.
+type PolicyRemover interface {
+	// RemoveFilteredPolicy 删除匹配的策略.
+	RemoveFilteredPolicy(fieldIndex int, fieldValues ...string) (bool, error)
+	// RemoveFilteredGroupingPolicy 删除匹配的角色继承规则.
+	RemoveFilteredGroupingPolicy(fieldIndex int, fieldValues ...string) (bool, error)
+}
+
+// Purger 每隔interval永久删除一次删除时间超过retention的用户和博客.
+type Purger struct {
+	store     store.IStore
+	authz     PolicyRemover
+	retention time.Duration
+	interval  time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewPurger 创建已删除数据的清理器, 需要调用Start启动后台清理.
+func NewPurger(store store.IStore, authz PolicyRemover, retention time.Duration, interval time.Duration) *Purger {
+	return &Purger{
+		store:     store,
+		authz:     authz,
+		retention: retention,
+		interval:  interval,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Purge 永久删除删除时间早于before的用户和博客, 返回清理的用户数和博客数.
+// 每个用户的博客, 策略和用户本身在同一个事务中删除, 任意一步失败时该用户的数据保持不变.
+func (p *Purger) Purge(ctx context.Context, before time.Time) (int, int64, error) {
+	users, err := p.store.User().ListDeleted(ctx, before)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var purgedUsers int
+	var purgedPosts int64
+	for _, userM := range users {
+		var posts int64
+		err := p.store.TX(ctx, func(ctx context.Context) error {
+			var err error
+			if posts, err = p.store.Post().PurgeByUserID(ctx, userM.UserID); err != nil {
+				return err
+			}
+			if _, err = p.store.CasbinRule().DeleteBySubject(ctx, userM.UserID); err != nil {
+				return err
+			}
+			return p.store.User().Purge(ctx, userM.UserID)
+		})
+		if err != nil {
+			return purgedUsers, purgedPosts, err
+		}
+		purgedUsers++
+		purgedPosts += posts
+
+		p.removePolicies(userM.UserID)
+	}
+
+	// 单独删除的博客
+	posts, err := p.store.Post().PurgeDeleted(ctx, before)
+	if err != nil {
+		return purgedUsers, purgedPosts, err
+	}
+
+	return purgedUsers, purgedPosts + posts, nil
+}
+
+// 事务提交后从授权器中删除用户的策略, 数据库中的策略已经删除, 同步失败时只记录日志, 重新加载策略后即可恢复一致.
+func (p *Purger) removePolicies(userID string) {
+	if _, err := p.authz.RemoveFilteredGroupingPolicy(0, userID); err != nil {
+		log.Errorw("Failed to remove grouping policies of purged user", "userID", userID, "err", err)
+	}
+	if _, err := p.authz.RemoveFilteredPolicy(0, userID); err != nil {
+		log.Errorw("Failed to remove policies of purged user", "userID", userID, "err", err)
+	}
+}
+
+// Start 在后台每隔interval清理一次超过保留时间的数据.
+func (p *Purger) Start() {
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				users, posts, err := p.Purge(context.Background(), time.Now().Add(-p.retention))
+				if err != nil {
+					log.Errorw("Failed to purge deleted users and posts", "err", err)
+				}
+				if users > 0 || posts > 0 {
+					log.Infow("Purged deleted users and posts", "users", users, "posts", posts)
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台清理, 等待正在进行的清理结束.
+func (p *Purger) Stop(ctx context.Context) error {
+	close(p.stop)
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package purge
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"k8s.io/utils/ptr"
+)
+
+// fakeAuthz 记录从授权器中删除策略的主体.
+type fakeAuthz struct {
+	policies         []string
+	groupingPolicies []string
+}
+
+func (a *fakeAuthz) RemoveFilteredPolicy(fieldIndex int, fieldValues ...string) (bool, error) {
+	a.policies = append(a.policies, fieldValues...)
+	return true, nil
+}
+
+func (a *fakeAuthz) RemoveFilteredGroupingPolicy(fieldIndex int, fieldValues ...string) (bool, error) {
+	a.groupingPolicies = append(a.groupingPolicies, fieldValues...)
+	return true, nil
+}
+
+// 创建基于SQLite内存数据库的存储, store.NewStore只会初始化一次, 各个测试共享同一个数据库.
+func newTestStore(t *testing.T) (*gorm.DB, store.IStore) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	s := store.NewStore(db)
+	db = s.DB(context.Background())
+	require.NoError(t, db.AutoMigrate(&model.UserM{}, &model.PostM{}, &model.CasbinRuleM{}))
+
+	return db, s
+}
+
+func TestPurge(t *testing.T) {
+	db, s := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	users := []*model.UserM{
+		{UserID: "user-expired", Username: "expired", Phone: ptr.To("1")},
+		{UserID: "user-recent", Username: "recent", Phone: ptr.To("2")},
+		{UserID: "user-active", Username: "active", Phone: ptr.To("3")},
+	}
+	require.NoError(t, db.Create(&users).Error)
+	// 博客ID在创建后生成
+	posts := []*model.PostM{
+		{UserID: "user-expired"},
+		{UserID: "user-expired"},
+		{UserID: "user-recent"},
+		{UserID: "user-active"},
+		{UserID: "user-active"},
+		{UserID: "user-active"},
+	}
+	for _, post := range posts {
+		require.NoError(t, db.Create(post).Error)
+	}
+	rules := []*model.CasbinRuleM{
+		{PType: ptr.To("g"), V0: ptr.To("user-expired"), V1: ptr.To("role::user"), V2: ptr.To("default")},
+		{PType: ptr.To("g"), V0: ptr.To("user-active"), V1: ptr.To("role::user"), V2: ptr.To("default")},
+	}
+	require.NoError(t, db.Create(&rules).Error)
+
+	// 过期删除的用户, 保留期内删除的用户, 以及过期和保留期内单独删除的博客
+	deletedAt := map[string]time.Time{"user-expired": now.Add(-48 * time.Hour), "user-recent": now.Add(-time.Hour)}
+	for userID, at := range deletedAt {
+		require.NoError(t, db.Model(&model.UserM{}).Where("userID = ?", userID).Update("deletedAt", at).Error)
+	}
+	require.NoError(t, db.Model(&model.PostM{}).Where("postID = ?", posts[3].PostID).Update("deletedAt", now.Add(-48*time.Hour)).Error)
+	require.NoError(t, db.Model(&model.PostM{}).Where("postID = ?", posts[4].PostID).Update("deletedAt", now.Add(-time.Hour)).Error)
+
+	authz := &fakeAuthz{}
+	purger := NewPurger(s, authz, 24*time.Hour, time.Hour)
+	purgedUsers, purgedPosts, err := purger.Purge(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purgedUsers)
+	assert.Equal(t, int64(3), purgedPosts)
+	assert.Equal(t, []string{"user-expired"}, authz.policies)
+	assert.Equal(t, []string{"user-expired"}, authz.groupingPolicies)
+
+	var userIDs []string
+	require.NoError(t, db.Unscoped().Model(&model.UserM{}).Where("username IN ?", []string{"expired", "recent", "active"}).Order("id").Pluck("userID", &userIDs).Error)
+	assert.Equal(t, []string{"user-recent", "user-active"}, userIDs)
+
+	var postIDs []string
+	require.NoError(t, db.Unscoped().Model(&model.PostM{}).Where("userID IN ?", []string{"user-expired", "user-recent", "user-active"}).Order("id").Pluck("postID", &postIDs).Error)
+	assert.Equal(t, []string{posts[2].PostID, posts[4].PostID, posts[5].PostID}, postIDs)
+
+	var ruleSubjects []string
+	require.NoError(t, db.Model(&model.CasbinRuleM{}).Pluck("v0", &ruleSubjects).Error)
+	assert.Equal(t, []string{"user-active"}, ruleSubjects)
+
+	// 再次清理时没有需要删除的数据
+	purgedUsers, purgedPosts, err = purger.Purge(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purgedUsers)
+	assert.Zero(t, purgedPosts)
+}
+
+func TestRestore(t *testing.T) {
+	db, s := newTestStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.Create(&model.UserM{UserID: "user-restore", Username: "restore", Phone: ptr.To("4")}).Error)
+	post := &model.PostM{UserID: "user-restore"}
+	require.NoError(t, db.Create(post).Error)
+
+	// 没有被删除的数据不能恢复
+	assert.ErrorIs(t, s.User().Restore(ctx, "user-restore"), gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, s.Post().Restore(ctx, post.PostID), gorm.ErrRecordNotFound)
+
+	require.NoError(t, db.Where("postID = ?", post.PostID).Delete(&model.PostM{}).Error)
+	require.NoError(t, db.Where("userID = ?", "user-restore").Delete(&model.UserM{}).Error)
+
+	// 作者被删除时不能单独恢复博客
+	assert.ErrorIs(t, s.Post().Restore(ctx, post.PostID), gorm.ErrRecordNotFound)
+
+	require.NoError(t, s.User().Restore(ctx, "user-restore"))
+	require.NoError(t, s.Post().Restore(ctx, post.PostID))
+
+	var count int64
+	require.NoError(t, db.Model(&model.PostM{}).Where("userID = ?", "user-restore").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
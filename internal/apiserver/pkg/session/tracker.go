@@ -0,0 +1,109 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package session 记录登录会话的最后活跃时间.
+// 认证中间件每个请求都会更新活跃时间, 因此先在内存中合并, 再定期批量写入数据库.
+package session
+
+import (
+	"context"
+	"miniblog/internal/pkg/log"
+	"sync"
+	"time"
+)
+
+// Store 定义了批量写入会话最后活跃时间的存储接口.
+type Store interface {
+	// Touch 批量更新会话的最后活跃时间, key为会话ID.
+	Touch(ctx context.Context, lastSeen map[string]time.Time) error
+}
+
+// Tracker 在内存中合并会话的活跃时间, 每隔interval批量写入一次.
+type Tracker struct {
+	store    Store
+	interval time.Duration
+
+	mu sync.Mutex
+	// 尚未写入数据库的会话最后活跃时间
+	pending map[string]time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewTracker 创建会话活跃时间记录器, 需要调用Start启动后台写入.
+func NewTracker(store Store, interval time.Duration) *Tracker {
+	return &Tracker{
+		store:    store,
+		interval: interval,
+		pending:  make(map[string]time.Time),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Touch 记录会话在seenAt时刻处于活跃状态, 只修改内存, 不会阻塞请求.
+func (t *Tracker) Touch(sessionID string, seenAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.pending[sessionID]; !ok || seenAt.After(last) {
+		t.pending[sessionID] = seenAt
+	}
+}
+
+// Flush 将内存中的活跃时间写入数据库, 写入失败的记录会与之后的记录合并后重试.
+func (t *Tracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = make(map[string]time.Time, len(batch))
+	t.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := t.store.Touch(ctx, batch); err != nil {
+		for sessionID, seenAt := range batch {
+			t.Touch(sessionID, seenAt)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Start 在后台每隔interval写入一次活跃时间.
+func (t *Tracker) Start() {
+	go func() {
+		defer close(t.done)
+
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := t.Flush(context.Background()); err != nil {
+					log.Errorw("Failed to flush session last seen time", "err", err)
+				}
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台写入, 并写入剩余的活跃时间, 用于服务关停时避免丢失记录.
+func (t *Tracker) Stop(ctx context.Context) error {
+	close(t.stop)
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return t.Flush(ctx)
+}
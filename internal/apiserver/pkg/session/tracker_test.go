@@ -0,0 +1,73 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore 记录每次批量写入的内容.
+type fakeStore struct {
+	mu      sync.Mutex
+	err     error
+	batches []map[string]time.Time
+}
+
+func (s *fakeStore) Touch(ctx context.Context, lastSeen map[string]time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.batches = append(s.batches, lastSeen)
+	return nil
+}
+
+func TestTrackerFlush(t *testing.T) {
+	store := &fakeStore{}
+	tracker := NewTracker(store, time.Hour)
+	now := time.Now()
+
+	// 同一个会话只保留最晚的活跃时间
+	tracker.Touch("session-1", now)
+	tracker.Touch("session-1", now.Add(-time.Minute))
+	tracker.Touch("session-2", now.Add(time.Second))
+	require.NoError(t, tracker.Flush(context.Background()))
+	require.Len(t, store.batches, 1)
+	assert.Equal(t, map[string]time.Time{"session-1": now, "session-2": now.Add(time.Second)}, store.batches[0])
+
+	// 写入失败的记录在下一次写入时重试
+	store.err = errors.New("database is down")
+	tracker.Touch("session-3", now)
+	assert.Error(t, tracker.Flush(context.Background()))
+
+	store.err = nil
+	tracker.Touch("session-1", now.Add(time.Minute))
+	require.NoError(t, tracker.Flush(context.Background()))
+	require.Len(t, store.batches, 2)
+	assert.Equal(t, map[string]time.Time{"session-1": now.Add(time.Minute), "session-3": now}, store.batches[1])
+}
+
+func TestTrackerStop(t *testing.T) {
+	store := &fakeStore{}
+	tracker := NewTracker(store, time.Hour)
+	tracker.Start()
+
+	tracker.Touch("session-1", time.Now())
+	require.NoError(t, tracker.Stop(context.Background()))
+
+	// 关停时写入剩余的记录
+	require.Len(t, store.batches, 1)
+	assert.Contains(t, store.batches[0], "session-1")
+}
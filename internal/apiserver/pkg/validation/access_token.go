@@ -0,0 +1,74 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	"strings"
+	"unicode/utf8"
+
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// 个人访问令牌的权限以逗号分隔保存, 总长度不能超过数据库列宽.
+const maxAccessTokenActionsLength = 255
+
+func (v *Validator) ValidateAccessTokenRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"TokenID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("tokenID cannot be empty")
+			}
+			return nil
+		},
+		"Name": func(value any) error {
+			if n := utf8.RuneCountInString(value.(string)); n == 0 || n > 64 {
+				return errno.ErrInvalidArgument.WithMessage("name must be between 1 and 64 characters")
+			}
+			return nil
+		},
+		"Actions": func(value any) error {
+			actions := value.([]string)
+			if len(actions) == 0 {
+				return errno.ErrInvalidArgument.WithMessage("actions cannot be empty")
+			}
+			// 个人访问令牌只能授予RPC声明的权限, 与请求使用的传输协议无关
+			for _, action := range actions {
+				if !v.permissions.Has(action) {
+					return errno.ErrInvalidArgument.WithMessage("invalid action %q, must be a declared permission such as post:list", action)
+				}
+			}
+			if len(strings.Join(actions, ",")) > maxAccessTokenActionsLength {
+				return errno.ErrInvalidArgument.WithMessage("actions cannot exceed %d characters in total", maxAccessTokenActionsLength)
+			}
+			return nil
+		},
+		"ExpiresIn": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.ErrInvalidArgument.WithMessage("expiresIn must be greater than 0")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateCreateAccessTokenRequest 校验 CreateAccessTokenRequest 结构体的有效性.
+func (v *Validator) ValidateCreateAccessTokenRequest(ctx context.Context, rq *apiv1.CreateAccessTokenRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateAccessTokenRules())
+}
+
+// ValidateListAccessTokenRequest 校验 ListAccessTokenRequest 结构体的有效性.
+func (v *Validator) ValidateListAccessTokenRequest(ctx context.Context, rq *apiv1.ListAccessTokenRequest) error {
+	return nil
+}
+
+// ValidateRevokeAccessTokenRequest 校验 RevokeAccessTokenRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeAccessTokenRequest(ctx context.Context, rq *apiv1.RevokeAccessTokenRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateAccessTokenRules())
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func (v *Validator) ValidateOIDCRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"Provider": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("provider cannot be empty")
+			}
+			return nil
+		},
+		"Code": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("code cannot be empty")
+			}
+			return nil
+		},
+		"State": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("state cannot be empty")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateStartOIDCLoginRequest 校验 StartOIDCLoginRequest 结构体的有效性.
+func (v *Validator) ValidateStartOIDCLoginRequest(ctx context.Context, rq *apiv1.StartOIDCLoginRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOIDCRules())
+}
+
+// ValidateOIDCCallbackRequest 校验 OIDCCallbackRequest 结构体的有效性.
+func (v *Validator) ValidateOIDCCallbackRequest(ctx context.Context, rq *apiv1.OIDCCallbackRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOIDCRules())
+}
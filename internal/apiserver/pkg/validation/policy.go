@@ -0,0 +1,113 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// 策略字段是否符合授权模型在Biz层通过授权器校验, 这里只校验请求结构.
+func (v *Validator) ValidatePolicyRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"UserID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("userID cannot be empty")
+			}
+			return nil
+		},
+		"Subject": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("subject cannot be empty")
+			}
+			return nil
+		},
+		"Object": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("object cannot be empty")
+			}
+			return nil
+		},
+		"Action": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("action cannot be empty")
+			}
+			return nil
+		},
+		"Role": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("role cannot be empty")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateListPoliciesRequest 校验 ListPoliciesRequest 结构体的有效性.
+func (v *Validator) ValidateListPoliciesRequest(ctx context.Context, rq *apiv1.ListPoliciesRequest) error {
+	return nil
+}
+
+// ValidateAddPolicyRequest 校验 AddPolicyRequest 结构体的有效性.
+func (v *Validator) ValidateAddPolicyRequest(ctx context.Context, rq *apiv1.AddPolicyRequest) error {
+	if rq.GetPolicy() == nil {
+		return errno.ErrInvalidArgument.WithMessage("policy cannot be empty")
+	}
+	return nil
+}
+
+// ValidateRemovePolicyRequest 校验 RemovePolicyRequest 结构体的有效性.
+func (v *Validator) ValidateRemovePolicyRequest(ctx context.Context, rq *apiv1.RemovePolicyRequest) error {
+	if rq.GetPolicy() == nil {
+		return errno.ErrInvalidArgument.WithMessage("policy cannot be empty")
+	}
+	return nil
+}
+
+// ValidateListGroupingPoliciesRequest 校验 ListGroupingPoliciesRequest 结构体的有效性.
+func (v *Validator) ValidateListGroupingPoliciesRequest(ctx context.Context, rq *apiv1.ListGroupingPoliciesRequest) error {
+	return nil
+}
+
+// ValidateAddGroupingPolicyRequest 校验 AddGroupingPolicyRequest 结构体的有效性.
+func (v *Validator) ValidateAddGroupingPolicyRequest(ctx context.Context, rq *apiv1.AddGroupingPolicyRequest) error {
+	if rq.GetGroupingPolicy() == nil {
+		return errno.ErrInvalidArgument.WithMessage("groupingPolicy cannot be empty")
+	}
+	return nil
+}
+
+// ValidateRemoveGroupingPolicyRequest 校验 RemoveGroupingPolicyRequest 结构体的有效性.
+func (v *Validator) ValidateRemoveGroupingPolicyRequest(ctx context.Context, rq *apiv1.RemoveGroupingPolicyRequest) error {
+	if rq.GetGroupingPolicy() == nil {
+		return errno.ErrInvalidArgument.WithMessage("groupingPolicy cannot be empty")
+	}
+	return nil
+}
+
+// ValidateListUserRolesRequest 校验 ListUserRolesRequest 结构体的有效性.
+func (v *Validator) ValidateListUserRolesRequest(ctx context.Context, rq *apiv1.ListUserRolesRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateAssignRoleRequest 校验 AssignRoleRequest 结构体的有效性.
+func (v *Validator) ValidateAssignRoleRequest(ctx context.Context, rq *apiv1.AssignRoleRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateRevokeRoleRequest 校验 RevokeRoleRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeRoleRequest(ctx context.Context, rq *apiv1.RevokeRoleRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateExplainAuthorizationRequest 校验 ExplainAuthorizationRequest 结构体的有效性.
+func (v *Validator) ValidateExplainAuthorizationRequest(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
@@ -58,6 +58,11 @@ func (v *Validator) ValidateDeletePostRequest(ctx context.Context, rq *apiv1.Del
 	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
 }
 
+// ValidateRestorePostRequest 校验 RestorePostRequest 结构体的有效性.
+func (v *Validator) ValidateRestorePostRequest(ctx context.Context, rq *apiv1.RestorePostRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
+}
+
 // ValidateGetPostRequest 校验 GetPostRequest 结构体的有效性.
 func (v *Validator) ValidateGetPostRequest(ctx context.Context, rq *apiv1.GetPostRequest) error {
 	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
@@ -0,0 +1,52 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func (v *Validator) ValidateSessionRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"UserID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("userID cannot be empty")
+			}
+			return nil
+		},
+		"SessionID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("sessionID cannot be empty")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateListSessionsRequest 校验 ListSessionsRequest 结构体的有效性.
+func (v *Validator) ValidateListSessionsRequest(ctx context.Context, rq *apiv1.ListSessionsRequest) error {
+	return nil
+}
+
+// ValidateRevokeSessionRequest 校验 RevokeSessionRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeSessionRequest(ctx context.Context, rq *apiv1.RevokeSessionRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateSessionRules())
+}
+
+// ValidateListUserSessionsRequest 校验 ListUserSessionsRequest 结构体的有效性.
+func (v *Validator) ValidateListUserSessionsRequest(ctx context.Context, rq *apiv1.ListUserSessionsRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateSessionRules())
+}
+
+// ValidateRevokeUserSessionRequest 校验 RevokeUserSessionRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeUserSessionRequest(ctx context.Context, rq *apiv1.RevokeUserSessionRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateSessionRules())
+}
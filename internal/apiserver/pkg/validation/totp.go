@@ -0,0 +1,53 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func (v *Validator) ValidateTOTPRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"ChallengeToken": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("challengeToken cannot be empty")
+			}
+			return nil
+		},
+		"Code": func(value any) error {
+			// 一次性密码为6位数字, 恢复码为11个字符
+			if n := len(value.(string)); n == 0 || n > 32 {
+				return errno.ErrInvalidArgument.WithMessage("code must be between 1 and 32 characters")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateVerifyLoginRequest 校验 VerifyLoginRequest 结构体的有效性.
+func (v *Validator) ValidateVerifyLoginRequest(ctx context.Context, rq *apiv1.VerifyLoginRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTOTPRules())
+}
+
+// ValidateEnrollTOTPRequest 校验 EnrollTOTPRequest 结构体的有效性.
+func (v *Validator) ValidateEnrollTOTPRequest(ctx context.Context, rq *apiv1.EnrollTOTPRequest) error {
+	return nil
+}
+
+// ValidateConfirmTOTPRequest 校验 ConfirmTOTPRequest 结构体的有效性.
+func (v *Validator) ValidateConfirmTOTPRequest(ctx context.Context, rq *apiv1.ConfirmTOTPRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTOTPRules())
+}
+
+// ValidateDisableTOTPRequest 校验 DisableTOTPRequest 结构体的有效性.
+func (v *Validator) ValidateDisableTOTPRequest(ctx context.Context, rq *apiv1.DisableTOTPRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTOTPRules())
+}
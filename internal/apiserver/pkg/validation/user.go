@@ -7,8 +7,9 @@ package validation
 
 import (
 	"context"
-	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"time"
 
 	apiv1 "miniblog/pkg/api/apiserver/v1"
 
@@ -50,6 +51,12 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 		"Phone": func(value any) error {
 			return isValidPhone(value.(string))
 		},
+		"TenantID": func(value any) error {
+			if !tenantRegex.MatchString(value.(string)) {
+				return errno.ErrInvalidArgument.WithMessage("tenantID must consist of lowercase letters, digits and hyphens, and be at most 36 characters")
+			}
+			return nil
+		},
 		"Limit": func(value any) error {
 			if value.(int64) <= 0 {
 				return errno.ErrInvalidArgument.WithMessage("limit must be greater than 0")
@@ -59,6 +66,78 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 		"Offset": func(value any) error {
 			return nil
 		},
+		"UsernamePrefix": func(value any) error {
+			if prefix := value.(string); prefix == "" || len(prefix) > 20 || !validRegex.MatchString(prefix) {
+				return errno.ErrInvalidArgument.WithMessage("usernamePrefix must be 1 to 20 letters, digits or underscores")
+			}
+			return nil
+		},
+		"EmailDomain": func(value any) error {
+			if !domainRegex.MatchString(value.(string)) {
+				return errno.ErrInvalidArgument.WithMessage("emailDomain must be a domain such as example.com")
+			}
+			return nil
+		},
+		"CreatedAfter": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("createdAfter must be a non-negative unix timestamp")
+			}
+			return nil
+		},
+		"CreatedBefore": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("createdBefore must be a non-negative unix timestamp")
+			}
+			return nil
+		},
+		"Format": func(value any) error {
+			switch value.(string) {
+			case known.DataExportFormatJSON, known.DataExportFormatZIP:
+				return nil
+			}
+			return errno.ErrInvalidArgument.WithMessage("format must be one of %s, %s", known.DataExportFormatJSON, known.DataExportFormatZIP)
+		},
+		"Status": func(value any) error {
+			switch value.(string) {
+			case known.UserStatusActive, known.UserStatusDisabled, known.UserStatusBanned:
+				return nil
+			}
+			return errno.ErrInvalidArgument.WithMessage("status must be one of %s, %s, %s",
+				known.UserStatusActive, known.UserStatusDisabled, known.UserStatusBanned)
+		},
+		"Reason": func(value any) error {
+			if len(value.(string)) > 255 {
+				return errno.ErrInvalidArgument.WithMessage("reason must be at most 255 characters")
+			}
+			return nil
+		},
+		"SortBy": func(value any) error {
+			switch value.(string) {
+			case known.UserSortByCreatedAt, known.UserSortByUsername, known.UserSortByPostCount:
+				return nil
+			}
+			return errno.ErrInvalidArgument.WithMessage("sortBy must be one of %s, %s, %s",
+				known.UserSortByCreatedAt, known.UserSortByUsername, known.UserSortByPostCount)
+		},
+		"SortOrder": func(value any) error {
+			switch value.(string) {
+			case known.SortOrderAsc, known.SortOrderDesc:
+				return nil
+			}
+			return errno.ErrInvalidArgument.WithMessage("sortOrder must be one of %s, %s", known.SortOrderAsc, known.SortOrderDesc)
+		},
+		"RefreshToken": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("refreshToken cannot be empty")
+			}
+			return nil
+		},
+		"Token": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("token cannot be empty")
+			}
+			return nil
+		},
 	}
 }
 
@@ -66,22 +145,88 @@ func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *apiv1.LoginReq
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
-func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, rq *apiv1.ChangePasswordRequest) error {
-	if rq.GetUserID() != contextx.UserID(ctx) {
-		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+// ValidateRefreshTokenRequest 校验 RefreshTokenRequest 结构体的有效性.
+func (v *Validator) ValidateRefreshTokenRequest(ctx context.Context, rq *apiv1.RefreshTokenRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateLogoutRequest 校验 LogoutRequest 结构体的有效性.
+func (v *Validator) ValidateLogoutRequest(ctx context.Context, rq *apiv1.LogoutRequest) error {
+	return nil
+}
+
+// ValidateRevokeTokensRequest 校验 RevokeTokensRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeTokensRequest(ctx context.Context, rq *apiv1.RevokeTokensRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateImpersonateRequest 校验 ImpersonateRequest 结构体的有效性.
+func (v *Validator) ValidateImpersonateRequest(ctx context.Context, rq *apiv1.ImpersonateRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateUnlockUserRequest 校验 UnlockUserRequest 结构体的有效性.
+func (v *Validator) ValidateUnlockUserRequest(ctx context.Context, rq *apiv1.UnlockUserRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateUpdateUserStatusRequest 校验 UpdateUserStatusRequest 结构体的有效性.
+func (v *Validator) ValidateUpdateUserStatusRequest(ctx context.Context, rq *apiv1.UpdateUserStatusRequest) error {
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
 	}
+
+	// 只有封禁可以设置截止时间, 截止时间必须晚于当前时间
+	if rq.Until != nil {
+		if rq.GetStatus() != known.UserStatusBanned {
+			return errno.ErrInvalidArgument.WithMessage("until can only be set when banning a user")
+		}
+		if rq.GetUntil() <= time.Now().Unix() {
+			return errno.ErrInvalidArgument.WithMessage("until must be in the future")
+		}
+	}
+	return nil
+}
+
+func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, rq *apiv1.ChangePasswordRequest) error {
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
-func (v *Validator) ValidateCreateUserRequest(ctx context.Context, rq *apiv1.CreateUserRequest) error {
+// ValidateRequestPasswordResetRequest 校验 RequestPasswordResetRequest 结构体的有效性.
+func (v *Validator) ValidateRequestPasswordResetRequest(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateResetPasswordRequest 校验 ResetPasswordRequest 结构体的有效性.
+func (v *Validator) ValidateResetPasswordRequest(ctx context.Context, rq *apiv1.ResetPasswordRequest) error {
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
+// ValidateVerifyEmailRequest 校验 VerifyEmailRequest 结构体的有效性.
+func (v *Validator) ValidateVerifyEmailRequest(ctx context.Context, rq *apiv1.VerifyEmailRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateSendVerificationEmailRequest 校验 SendVerificationEmailRequest 结构体的有效性.
+func (v *Validator) ValidateSendVerificationEmailRequest(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) error {
+	return nil
+}
+
+func (v *Validator) ValidateCreateUserRequest(ctx context.Context, rq *apiv1.CreateUserRequest) error {
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+
+	// 注册用户不经过认证, 只能选择配置中开放注册的租户, 防止在任意租户中创建账号
+	if rq.TenantID != nil && !v.signupTenants.Has(rq.GetTenantID()) {
+		return errno.ErrInvalidArgument.WithMessage("tenant %q is not open for signup", rq.GetTenantID())
+	}
+
+	return nil
+}
+
 // ValidateUpdateUserRequest 校验更新用户请求.
 func (v *Validator) ValidateUpdateUserRequest(ctx context.Context, rq *apiv1.UpdateUserRequest) error {
-	if rq.GetUserID() != contextx.UserID(ctx) {
-		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
-	}
 	return genericvalidation.ValidateSelectedFields(rq, v.ValidateUserRules(), "UserID")
 }
 
@@ -90,15 +235,38 @@ func (v *Validator) ValidateDeleteUserRequest(ctx context.Context, rq *apiv1.Del
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
+// ValidateRestoreUserRequest 校验 RestoreUserRequest 结构体的有效性.
+func (v *Validator) ValidateRestoreUserRequest(ctx context.Context, rq *apiv1.RestoreUserRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateExportMyDataRequest 校验 ExportMyDataRequest 结构体的有效性.
+func (v *Validator) ValidateExportMyDataRequest(ctx context.Context, rq *apiv1.ExportMyDataRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateExportUserDataRequest 校验 ExportUserDataRequest 结构体的有效性.
+func (v *Validator) ValidateExportUserDataRequest(ctx context.Context, rq *apiv1.ExportUserDataRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
 // ValidateGetUserRequest 校验 GetUserRequest 结构体的有效性.
 func (v *Validator) ValidateGetUserRequest(ctx context.Context, rq *apiv1.GetUserRequest) error {
-	if rq.GetUserID() != contextx.UserID(ctx) {
-		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
-	}
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
 // ValidateListUserRequest 校验 ListUserRequest 结构体的有效性.
 func (v *Validator) ValidateListUserRequest(ctx context.Context, rq *apiv1.ListUserRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+
+	// 同时指定开始和结束时间时, 时间范围不能为空
+	if rq.CreatedAfter != nil && rq.CreatedBefore != nil && rq.GetCreatedAfter() >= rq.GetCreatedBefore() {
+		return errno.ErrInvalidArgument.WithMessage("createdAfter must be earlier than createdBefore")
+	}
+	if rq.SortOrder != nil && rq.SortBy == nil {
+		return errno.ErrInvalidArgument.WithMessage("sortOrder requires sortBy")
+	}
+	return nil
 }
@@ -6,11 +6,14 @@
 package validation
 
 import (
+	"miniblog/internal/apiserver/pkg/permission"
 	"miniblog/internal/apiserver/store"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"regexp"
 
 	"github.com/google/wire"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // 验证逻辑的实现结构体.
@@ -19,6 +22,16 @@ type Validator struct {
 	// 这里只是一个举例, 如果验证时, 有其他依赖的客户端/服务/资源等
 	// 都可以一并注入进来
 	store store.IStore
+	// 权限注册表, 用于校验个人访问令牌的权限
+	permissions *permission.Registry
+	// 注册用户时允许选择的租户, 总是包含默认租户
+	signupTenants sets.Set[string]
+}
+
+// Options 定义了请求校验相关的配置.
+type Options struct {
+	// 注册用户时除默认租户外允许选择的租户
+	SignupTenants []string
 }
 
 // 预编译正则表达式(全局变量).
@@ -29,14 +42,21 @@ var (
 	numberRegex = regexp.MustCompile(`\d`)                                               // 至少包含一个数字
 	emailRegex  = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`) // 邮箱格式
 	phoneRegex  = regexp.MustCompile(`^1[3-9]\d{9}$`)
+	tenantRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,35}$`)      // 小写字母, 数字和连字符, 不超过36个字符
+	domainRegex = regexp.MustCompile(`^[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`) // 邮箱域名格式
 )
 
 // ProviderSet 是一个 Wire 的 Provider 集合，用于声明依赖注入的规则.
 // 包含 New 构造函数，用于生成 Validator 实例.
 var ProviderSet = wire.NewSet(New)
 
-func New(store store.IStore) *Validator {
-	return &Validator{store: store}
+func New(store store.IStore, permissions *permission.Registry, opts *Options) *Validator {
+	signupTenants := sets.New(known.DefaultTenant)
+	if opts != nil {
+		signupTenants.Insert(opts.SignupTenants...)
+	}
+
+	return &Validator{store: store, permissions: permissions, signupTenants: signupTenants}
 }
 
 func isValidUsername(username string) bool {
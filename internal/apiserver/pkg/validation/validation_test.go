@@ -6,7 +6,15 @@
 package validation
 
 import (
+	"context"
+	"strings"
 	"testing"
+
+	"miniblog/internal/apiserver/pkg/permission"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 // 性能测试用例
@@ -31,3 +39,114 @@ func BenchmarkIsValidUsername(b *testing.B) {
 		}
 	}
 }
+
+func TestValidateListUserRequest(t *testing.T) {
+	v := New(nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		rq      *apiv1.ListUserRequest
+		wantErr bool
+	}{
+		{"no filters", &apiv1.ListUserRequest{Limit: 10}, false},
+		{"all filters", &apiv1.ListUserRequest{
+			Limit:          10,
+			UsernamePrefix: proto.String("colin_"),
+			EmailDomain:    proto.String("example.com"),
+			CreatedAfter:   proto.Int64(1700000000),
+			CreatedBefore:  proto.Int64(1800000000),
+			Status:         proto.String("banned"),
+			EmailVerified:  proto.Bool(true),
+			SortBy:         proto.String("postCount"),
+			SortOrder:      proto.String("desc"),
+		}, false},
+		{"invalid username prefix", &apiv1.ListUserRequest{Limit: 10, UsernamePrefix: proto.String("a%")}, true},
+		{"invalid email domain", &apiv1.ListUserRequest{Limit: 10, EmailDomain: proto.String("@example.com")}, true},
+		{"empty date range", &apiv1.ListUserRequest{Limit: 10, CreatedAfter: proto.Int64(100), CreatedBefore: proto.Int64(100)}, true},
+		{"invalid status", &apiv1.ListUserRequest{Limit: 10, Status: proto.String("deleted")}, true},
+		{"invalid sort field", &apiv1.ListUserRequest{Limit: 10, SortBy: proto.String("password")}, true},
+		{"invalid sort order", &apiv1.ListUserRequest{Limit: 10, SortBy: proto.String("username"), SortOrder: proto.String("up")}, true},
+		{"sort order without field", &apiv1.ListUserRequest{Limit: 10, SortOrder: proto.String("desc")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateListUserRequest(context.Background(), tt.rq)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateAccessTokenRequest(t *testing.T) {
+	v := New(nil, permission.NewRegistry(), nil)
+
+	tests := []struct {
+		name    string
+		actions []string
+		wantErr bool
+	}{
+		{"declared permissions", []string{"post:list", "post:get"}, false},
+		{"no permissions", nil, true},
+		// 传输协议相关的动作不再是有效的权限
+		{"http method", []string{"GET"}, true},
+		{"grpc call", []string{"CALL"}, true},
+		{"undeclared permission", []string{"post:publish"}, true},
+		{"too long", strings.Split(strings.Repeat("post:list,", 30), ",")[:30], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateCreateAccessTokenRequest(context.Background(), &apiv1.CreateAccessTokenRequest{Name: "ci", Actions: tt.actions})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateUserRequestTenant(t *testing.T) {
+	v := New(nil, nil, &Options{SignupTenants: []string{"acme"}})
+	newRequest := func(tenantID *string) *apiv1.CreateUserRequest {
+		return &apiv1.CreateUserRequest{
+			Username: "colin",
+			Password: "miniblog1234",
+			Email:    "colin@example.com",
+			Phone:    "18110000000",
+			TenantID: tenantID,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tenantID *string
+		wantErr  bool
+	}{
+		{"no tenant", nil, false},
+		{"default tenant", proto.String("default"), false},
+		{"signup tenant", proto.String("acme"), false},
+		{"unlisted tenant", proto.String("globex"), true},
+		{"invalid tenant", proto.String("Acme Corp"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateCreateUserRequest(context.Background(), newRequest(tt.tenantID))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// 没有配置开放注册的租户时只能注册到默认租户
+	v = New(nil, nil, nil)
+	assert.Error(t, v.ValidateCreateUserRequest(context.Background(), newRequest(proto.String("acme"))))
+	assert.NoError(t, v.ValidateCreateUserRequest(context.Background(), newRequest(proto.String("default"))))
+}
@@ -8,24 +8,36 @@ package apiserver
 import (
 	"context"
 	"miniblog/internal/apiserver/biz"
+	postv1 "miniblog/internal/apiserver/biz/v1/post"
+	userv1 "miniblog/internal/apiserver/biz/v1/user"
 	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/pkg/denylist"
+	"miniblog/internal/apiserver/pkg/lockout"
+	"miniblog/internal/apiserver/pkg/permission"
+	"miniblog/internal/apiserver/pkg/purge"
+	"miniblog/internal/apiserver/pkg/session"
 	"miniblog/internal/apiserver/pkg/validation"
 	"miniblog/internal/apiserver/store"
 	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
 	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	"miniblog/internal/pkg/server"
+	"miniblog/pkg/auth"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/oidc"
 	"miniblog/pkg/token"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/onexstack/onexstack/pkg/ptr"
 	"gorm.io/driver/sqlite"
 
-	"github.com/onexstack/onexstack/pkg/authz"
-
 	mw "miniblog/internal/pkg/middleware/gin"
 
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
@@ -49,14 +61,33 @@ const (
 
 // 存储应用相关配置.
 type Config struct {
-	ServerMode        string
-	JWTKey            string
-	Expiration        time.Duration
-	EnableMemoryStore bool
-	HTTPOptions       *genericoptions.HTTPOptions
-	GRPCOptions       *genericoptions.GRPCOptions
-	MySQLOptions      *genericoptions.MySQLOptions
-	TLSOptions        *genericoptions.TLSOptions
+	ServerMode                  string
+	JWTKey                      string
+	JWTKeyring                  *token.Keyring
+	Expiration                  time.Duration
+	RefreshExpiration           time.Duration
+	ImpersonationExpiration     time.Duration
+	JWTIssuer                   string
+	JWTAudience                 string
+	ClockSkew                   time.Duration
+	EnableMemoryStore           bool
+	Mailer                      mail.Mailer
+	PasswordResetURL            string
+	PasswordResetExpiration     time.Duration
+	EmailVerificationURL        string
+	EmailVerificationExpiration time.Duration
+	RequireVerifiedEmail        bool
+	OIDCProviders               map[string]*oidc.Provider
+	OIDCLoginExpiration         time.Duration
+	PolicySyncInterval          time.Duration
+	PurgeRetention              time.Duration
+	PurgeInterval               time.Duration
+	SignupTenants               []string
+	TrustedProxies              []string
+	HTTPOptions                 *genericoptions.HTTPOptions
+	GRPCOptions                 *genericoptions.GRPCOptions
+	MySQLOptions                *genericoptions.MySQLOptions
+	TLSOptions                  *genericoptions.TLSOptions
 }
 
 // UnionServer 定义一个联合服务器. 根据 ServerMode 决定要启动的服务器类型.
@@ -73,11 +104,16 @@ type UnionServer struct {
 }
 
 type ServerConfig struct {
-	cfg       *Config
-	biz       biz.IBiz
-	val       *validation.Validator
-	retriever mw.UserRetriever
-	authz     *authz.Authz
+	cfg          *Config
+	biz          biz.IBiz
+	val          *validation.Validator
+	retriever    mw.UserRetriever
+	authz        *auth.Authz
+	permissions  *permission.Registry
+	denylist     denylist.Denylist
+	accessTokens mw.AccessTokenAuthenticator
+	sessions     *session.Tracker
+	purger       *purge.Purger
 }
 
 // NewUnionServer 根据配置创建联合服务器.
@@ -87,9 +123,23 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 	where.RegisterTenant("userID", func(ctx context.Context) string {
 		return contextx.UserID(ctx)
 	})
+	// 注册按租户隔离数据的列, 所有包含该列的模型都只能访问当前用户所属租户的数据
+	store.RegisterTenant("tenantID", func(ctx context.Context) string {
+		return contextx.TenantID(ctx)
+	})
 
 	// 初始化 token 包的签名密钥、认证 Key 及 Token 默认过期时间
-	token.Init(cfg.JWTKey, known.XUserID, cfg.Expiration)
+	token.Init(
+		cfg.JWTKey,
+		known.XUserID,
+		cfg.Expiration,
+		token.WithRefreshExpiration(cfg.RefreshExpiration),
+		token.WithImpersonationExpiration(cfg.ImpersonationExpiration),
+		token.WithIssuer(cfg.JWTIssuer),
+		token.WithAudience(cfg.JWTAudience),
+		token.WithLeeway(cfg.ClockSkew),
+		token.WithKeyring(cfg.JWTKeyring),
+	)
 
 	// 创建服务配置
 	// serverConfig, err := cfg.NewServerConfig()
@@ -105,7 +155,7 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 	// var srv server.Server
 	// switch cfg.ServerMode {
 	// case GinServerMode:
-	// 	srv, err = serverConfig.NewGinServer(), nil
+	// 	srv, err = serverConfig.NewGinServer()
 	// default:
 	// 	srv, err = serverConfig.NewGRPCServerOr()
 	// }
@@ -151,7 +201,17 @@ func (cfg *Config) NewDB() (*gorm.DB, error) {
 	// return cfg.MySQLOptions.NewDB()
 	if !cfg.EnableMemoryStore {
 		log.Infow("Initializing database connection", "type", "mysql", "addr", cfg.MySQLOptions.Addr)
-		return cfg.MySQLOptions.NewDB()
+		db, err := cfg.MySQLOptions.NewDB()
+		if err != nil {
+			return nil, err
+		}
+
+		// 将不带租户的旧策略迁移为适用于全部租户的策略, 需要在授权器加载策略之前完成
+		if err := auth.MigrateDomains(db); err != nil {
+			log.Errorw("Failed to migrate casbin policies to domains", "err", err)
+			return nil, err
+		}
+		return db, nil
 	}
 
 	log.Infow("Initializing database connection", "type", "memory", "engine", "SQLite")
@@ -166,7 +226,7 @@ func (cfg *Config) NewDB() (*gorm.DB, error) {
 	}
 
 	// 自动迁移数据库结构
-	if err := db.AutoMigrate(&model.UserM{}, &model.PostM{}, &model.CasbinRuleM{}); err != nil {
+	if err := db.AutoMigrate(&model.UserM{}, &model.PostM{}, &model.CasbinRuleM{}, &model.RefreshTokenM{}, &model.RevokedTokenM{}, &model.AccessTokenM{}, &model.UserTOTPM{}, &model.RecoveryCodeM{}, &model.LoginAttemptM{}, &model.PasswordResetM{}, &model.EmailVerificationM{}, &model.SessionM{}, &model.OIDCLoginM{}, &model.UserIdentityM{}); err != nil {
 		log.Errorw("Failed to migrate database schema", "err", err)
 		return nil, err
 	}
@@ -175,15 +235,7 @@ func (cfg *Config) NewDB() (*gorm.DB, error) {
 	// 在真实企业开发中, 不能再代码中硬编码这些初始化配置
 	// 尤其是硬编码密码, 密钥之类的信息.
 	// 插入 casbin_rule 表记录
-	adminR, userR := "role::admin", "role::user"
-	casbinRules := []model.CasbinRuleM{
-		{PType: ptr.To("g"), V0: ptr.To("user-000000"), V1: &adminR},
-		{PType: ptr.To("p"), V0: &adminR, V1: ptr.To("*"), V2: ptr.To("*"), V3: ptr.To("allow")},
-		{PType: ptr.To("p"), V0: &userR, V1: ptr.To("/v1.MiniBlog/DeleteUser"), V2: ptr.To("CALL"), V3: ptr.To("deny")},
-		{PType: ptr.To("p"), V0: &userR, V1: ptr.To("/v1.MiniBlog/ListUser"), V2: ptr.To("CALL"), V3: ptr.To("deny")},
-		{PType: ptr.To("p"), V0: &userR, V1: ptr.To("/v1/users"), V2: ptr.To("GET"), V3: ptr.To("deny")},
-		{PType: ptr.To("p"), V0: &userR, V1: ptr.To("/v1/users/*"), V2: ptr.To("DELETE"), V3: ptr.To("deny")},
-	}
+	casbinRules := defaultCasbinRules()
 
 	if err := db.Create(&casbinRules).Error; err != nil {
 		log.Fatalw("Failed to insert casbin_rule records", "err", err)
@@ -192,14 +244,17 @@ func (cfg *Config) NewDB() (*gorm.DB, error) {
 
 	// 插入默认用户(root用户)
 	user := model.UserM{
-		UserID:    "user-000000",
-		Username:  "root",
-		Password:  "miniblog1234",
-		Nickname:  "administrator",
-		Email:     "colin404@foxmail.com",
-		Phone:     "18110000000",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UserID:        "user-000000",
+		TenantID:      known.DefaultTenant,
+		Username:      "root",
+		Password:      "miniblog1234",
+		Nickname:      "administrator",
+		Email:         "colin404@foxmail.com",
+		Phone:         ptr.To("18110000000"),
+		EmailVerified: true,
+		VerifiedAt:    ptr.To(time.Now()),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
 	}
 
 	if err := db.Create(&user).Error; err != nil {
@@ -210,6 +265,28 @@ func (cfg *Config) NewDB() (*gorm.DB, error) {
 	return db, nil
 }
 
+// 返回内存数据库中初始化的授权策略: root用户是全局管理员, 普通用户不能执行管理操作.
+func defaultCasbinRules() []model.CasbinRuleM {
+	adminR, userR, allD := "role::admin", "role::user", auth.AllDomains
+	return []model.CasbinRuleM{
+		{PType: ptr.To("g"), V0: ptr.To("user-000000"), V1: &adminR, V2: &allD},
+		{PType: ptr.To("p"), V0: &adminR, V1: &allD, V2: ptr.To("*"), V3: ptr.To("*"), V4: ptr.To("allow")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("delete"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("list"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("revoke-tokens"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("unlock"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("impersonate"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("update-status"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user"), V3: ptr.To("restore"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("post"), V3: ptr.To("restore"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user-session"), V3: ptr.To("*"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user-data-export"), V3: ptr.To("*"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("policy"), V3: ptr.To("*"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("grouping-policy"), V3: ptr.To("*"), V4: ptr.To("deny")},
+		{PType: ptr.To("p"), V0: &userR, V1: &allD, V2: ptr.To("user-role"), V3: ptr.To("*"), V4: ptr.To("deny")},
+	}
+}
+
 // 后续可以使用依赖注入的方式.
 // func (cfg *Config) NewServerConfig() (*ServerConfig, error) {
 // 	db, err := cfg.NewDB()
@@ -239,9 +316,57 @@ type UserRetriever struct {
 	store store.IStore
 }
 
-// GetUser 根据用户 ID 获取用户信息.
+// GetUser 根据用户 ID 获取用户信息, 被禁用或处于封禁期的用户返回对应的错误.
 func (r *UserRetriever) GetUser(ctx context.Context, userID string) (*model.UserM, error) {
-	return r.store.User().Get(ctx, where.F("userID", userID))
+	userM, err := r.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, err
+	}
+	if err := userv1.CheckStatus(userM); err != nil {
+		return nil, err
+	}
+
+	return userM, nil
+}
+
+// 个人访问令牌最后使用时间的更新间隔, 避免每个请求都写数据库.
+const accessTokenTouchInterval = time.Minute
+
+// 早期的个人访问令牌按HTTP方法或grpc调用(CALL)限制动作, 这些动作无法对应到具体的权限.
+var legacyAccessTokenActions = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, "CALL"}
+
+// AccessTokenAuthenticator 定义一个个人访问令牌校验器.
+type AccessTokenAuthenticator struct {
+	store store.IStore
+}
+
+// AuthenticateAccessToken 根据令牌摘要查找个人访问令牌, 校验有效期并记录最后使用时间.
+func (a *AccessTokenAuthenticator) AuthenticateAccessToken(ctx context.Context, tokenString string) (*model.AccessTokenM, error) {
+	patM, err := a.store.AccessToken().Get(ctx, where.F("tokenHash", token.HashPersonal(tokenString)))
+	if err != nil {
+		return nil, errno.ErrAccessTokenInvalid
+	}
+
+	now := time.Now()
+	if patM.ExpiresAt != nil && !now.Before(*patM.ExpiresAt) {
+		return nil, errno.ErrAccessTokenExpired
+	}
+
+	// 旧令牌按当前的权限校验会拒绝所有请求, 明确提示用户重新创建令牌
+	for _, action := range strings.Split(patM.Actions, ",") {
+		if slices.Contains(legacyAccessTokenActions, action) {
+			return nil, errno.ErrAccessTokenLegacy
+		}
+	}
+
+	if patM.LastUsedAt == nil || now.Sub(*patM.LastUsedAt) >= accessTokenTouchInterval {
+		// 最后使用时间只用于展示, 更新失败不影响本次请求
+		if err := a.store.AccessToken().Touch(ctx, patM.TokenID, now); err == nil {
+			patM.LastUsedAt = &now
+		}
+	}
+
+	return patM, nil
 }
 
 // ProvideDB 根据配置提供一个数据库实例.
@@ -249,16 +374,171 @@ func ProvideDB(cfg *Config) (*gorm.DB, error) {
 	return cfg.NewDB()
 }
 
+// ProvideDenylist 根据配置提供一个令牌黑名单实例.
+// 内存数据库模式下只会运行单个实例, 使用内存黑名单即可, 否则使用数据库黑名单在多个实例之间共享吊销记录.
+func ProvideDenylist(cfg *Config, store store.IStore) denylist.Denylist {
+	if cfg.EnableMemoryStore {
+		return denylist.NewMemory()
+	}
+
+	// 用户级别的吊销记录需要保留到吊销前签发的令牌全部过期
+	return denylist.NewDB(store, cfg.Expiration+cfg.ClockSkew)
+}
+
+// ProvideLoginGuard 根据配置提供一个按用户名和客户端IP限制登录尝试的限制器.
+// 失败次数需要在所有实例之间累计, 否则攻击者可以轮流请求不同的实例绕过限制, 只有单实例的内存数据库模式才使用内存计数.
+func ProvideLoginGuard(cfg *Config, store store.IStore) *lockout.Guard {
+	if cfg.EnableMemoryStore {
+		return lockout.New(lockout.NewMemory())
+	}
+
+	return lockout.New(lockout.NewDB(store))
+}
+
+// 策略变更记录的保留时间, 落后超过该时间的实例会重新加载全部策略.
+const policyChangeRetention = 24 * time.Hour
+
+// ProvideAuthzOptions 根据配置提供授权器的配置.
+// 与令牌黑名单相同, 内存数据库模式下只会运行单个实例, 使用进程内广播器即可, 否则通过数据库在多个实例之间增量同步策略变更.
+func ProvideAuthzOptions(cfg *Config, db *gorm.DB) ([]auth.Option, error) {
+	if cfg.EnableMemoryStore {
+		return append(auth.DefaultOptions(), auth.WithWatcher(auth.NewLocalHub().NewWatcher())), nil
+	}
+
+	watcher, err := auth.NewDBWatcher(db, &auth.DBWatcherOptions{
+		PollInterval: cfg.PolicySyncInterval,
+		Retention:    policyChangeRetention,
+		OnError: func(err error) {
+			log.Errorw("Failed to sync casbin policy changes", "err", err)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(auth.DefaultOptions(), auth.WithWatcher(watcher)), nil
+}
+
+// 会话最后活跃时间的批量写入间隔.
+const sessionFlushInterval = 30 * time.Second
+
+// ProvideSessionTracker 提供一个会话活跃时间记录器.
+func ProvideSessionTracker(store store.IStore) *session.Tracker {
+	return session.NewTracker(store.Session(), sessionFlushInterval)
+}
+
+// ProvidePurger 根据配置提供一个已删除数据的清理器.
+func ProvidePurger(cfg *Config, store store.IStore, authz *auth.Authz) *purge.Purger {
+	return purge.NewPurger(store, authz, cfg.PurgeRetention, cfg.PurgeInterval)
+}
+
+// ProvideUserOptions 根据配置提供用户业务中发送邮件和外部身份提供方登录相关的配置.
+func ProvideUserOptions(cfg *Config) *userv1.Options {
+	return &userv1.Options{
+		Mailer:                      cfg.Mailer,
+		PasswordResetURL:            cfg.PasswordResetURL,
+		PasswordResetExpiration:     cfg.PasswordResetExpiration,
+		EmailVerificationURL:        cfg.EmailVerificationURL,
+		EmailVerificationExpiration: cfg.EmailVerificationExpiration,
+		OIDCProviders:               cfg.OIDCProviders,
+		OIDCLoginExpiration:         cfg.OIDCLoginExpiration,
+	}
+}
+
+// ProvideValidationOptions 根据配置提供请求校验的配置.
+func ProvideValidationOptions(cfg *Config) *validation.Options {
+	return &validation.Options{SignupTenants: cfg.SignupTenants}
+}
+
+// ProvidePostOptions 根据配置提供博客业务的配置.
+func ProvidePostOptions(cfg *Config) *postv1.Options {
+	return &postv1.Options{RequireVerifiedEmail: cfg.RequireVerifiedEmail}
+}
+
 func NewWebServer(serverMode string, serverConfig *ServerConfig) (server.Server, error) {
 	// 根据服务模式创建对应的服务实例
 	// 实际企业开发中, 可以根据需要只选择一种服务器模式.
 	// 这里为了方便给你展示, 通过 cfg.ServerMode 同时支持了 Gin 和 GRPC 2 种服务器模式.
 	// 默认为 gRPC 服务器模式.
+	var (
+		srv server.Server
+		err error
+	)
 	switch serverMode {
 	case GinServerMode:
-		return serverConfig.NewGinServer(), nil
+		srv, err = serverConfig.NewGinServer()
 	default:
-		return serverConfig.NewGRPCServerOr()
+		srv, err = serverConfig.NewGRPCServerOr()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migratePolicies(serverConfig.authz, serverConfig.permissions); err != nil {
+		return nil, err
+	}
+
+	serverConfig.sessions.Start()
+	serverConfig.purger.Start()
+	return &backgroundTaskServer{Server: srv, sessions: serverConfig.sessions, purger: serverConfig.purger}, nil
+}
+
+// migratePolicies 将按 gRPC 方法或 URL 路径编写的旧策略迁移为按权限编写的策略.
+// 迁移通过授权器完成, 变更会同时通知其他实例. 无法对应任何权限的旧策略不再生效, 只记录日志, 需要管理员手动处理.
+func migratePolicies(authz *auth.Authz, permissions *permission.Registry) error {
+	rules, err := authz.GetPolicy()
+	if err != nil {
+		return err
+	}
+
+	// 授权器返回的是内部的策略列表, 删除策略会改变列表内容, 需要先复制出待迁移的策略
+	var legacyRules [][]string
+	for _, rule := range rules {
+		if permission.IsLegacyRule(rule) {
+			legacyRules = append(legacyRules, rule)
+		}
+	}
+
+	for _, rule := range legacyRules {
+		migrated, ok := permissions.MigrateRule(rule)
+		if !ok {
+			log.Warnw("Legacy policy does not match any permission, please remove it manually", "policy", rule)
+			continue
+		}
+
+		if _, err := authz.RemovePolicy(rule); err != nil {
+			return err
+		}
+		for _, newRule := range migrated {
+			// 多条旧策略可能迁移为同一条策略
+			if exists, _ := authz.HasPolicy(newRule); exists {
+				continue
+			}
+			if _, err := authz.AddPolicy(newRule); err != nil {
+				return err
+			}
+		}
+		log.Infow("Migrated legacy policy", "policy", rule, "migrated", migrated)
+	}
+
+	return nil
+}
+
+// backgroundTaskServer 在服务器关停后停止后台任务, 并写入内存中剩余的会话活跃时间.
+type backgroundTaskServer struct {
+	server.Server
+	sessions *session.Tracker
+	purger   *purge.Purger
+}
+
+// GracefulStop 先关停服务器, 确保不再有请求更新会话活跃时间, 再写入剩余的记录并停止清理已删除的数据.
+func (s *backgroundTaskServer) GracefulStop(ctx context.Context) {
+	s.Server.GracefulStop(ctx)
+	if err := s.sessions.Stop(ctx); err != nil {
+		log.Errorw("Failed to flush session last seen time", "err", err)
+	}
+	if err := s.purger.Stop(ctx); err != nil {
+		log.Errorw("Failed to stop purging deleted users and posts", "err", err)
 	}
 }
 
@@ -0,0 +1,157 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package apiserver
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/pkg/permission"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	ginmw "miniblog/internal/pkg/middleware/gin"
+	grpcmw "miniblog/internal/pkg/middleware/grpc"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/auth"
+	"miniblog/pkg/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"k8s.io/utils/ptr"
+)
+
+// 创建基于SQLite内存数据库的存储层实例, store.NewStore只会初始化一次, 各个测试共享同一个数据库.
+func newTestStore(t *testing.T) store.IStore {
+	db, err := gorm.Open(sqlite.Open("file:apiserver_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	s := store.NewStore(db)
+	require.NoError(t, s.DB(context.Background()).AutoMigrate(&model.UserM{}, &model.AccessTokenM{}))
+
+	return s
+}
+
+func TestUserRetriever(t *testing.T) {
+	s := newTestStore(t)
+	db := s.DB(context.Background())
+
+	users := []*model.UserM{
+		{UserID: "user-active", Username: "active", Phone: ptr.To("1"), Status: known.UserStatusActive},
+		{UserID: "user-disabled", Username: "disabled", Phone: ptr.To("2"), Status: known.UserStatusDisabled},
+		{UserID: "user-banned", Username: "banned", Phone: ptr.To("3"), Status: known.UserStatusBanned},
+		{UserID: "user-ban-expired", Username: "ban-expired", Phone: ptr.To("4"), Status: known.UserStatusBanned, StatusUntil: ptr.To(time.Now().Add(-time.Minute))},
+	}
+	require.NoError(t, db.Create(&users).Error)
+
+	// 已有会话在每次认证时都会检查用户状态, 禁用或封禁立即生效
+	retriever := &UserRetriever{store: s}
+	ctx := context.Background()
+	_, err := retriever.GetUser(ctx, "user-active")
+	assert.NoError(t, err)
+	_, err = retriever.GetUser(ctx, "user-disabled")
+	assert.ErrorIs(t, err, errno.ErrUserDisabled)
+	_, err = retriever.GetUser(ctx, "user-banned")
+	assert.ErrorIs(t, err, errno.ErrUserBanned)
+	_, err = retriever.GetUser(ctx, "user-ban-expired")
+	assert.NoError(t, err)
+
+	// 重新启用后恢复访问
+	require.NoError(t, db.Model(&model.UserM{}).Where("userID = ?", "user-disabled").Update("status", known.UserStatusActive).Error)
+	_, err = retriever.GetUser(ctx, "user-disabled")
+	assert.NoError(t, err)
+}
+
+func TestAccessTokenAuthenticator(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	authenticator := &AccessTokenAuthenticator{store: s}
+
+	// 创建令牌记录, 返回令牌明文
+	createToken := func(actions string, expiresAt *time.Time) string {
+		tokenStr, err := token.SignPersonal()
+		require.NoError(t, err)
+		require.NoError(t, s.AccessToken().Create(ctx, &model.AccessTokenM{
+			UserID:    "user-pat",
+			Name:      actions,
+			TokenHash: token.HashPersonal(tokenStr),
+			Actions:   actions,
+			ExpiresAt: expiresAt,
+		}))
+		return tokenStr
+	}
+
+	valid := createToken("post:list,post:get", ptr.To(time.Now().Add(time.Hour)))
+	patM, err := authenticator.AuthenticateAccessToken(ctx, valid)
+	require.NoError(t, err)
+	assert.Equal(t, "user-pat", patM.UserID)
+	assert.NotNil(t, patM.LastUsedAt)
+
+	_, err = authenticator.AuthenticateAccessToken(ctx, createToken("post:list", ptr.To(time.Now().Add(-time.Minute))))
+	assert.ErrorIs(t, err, errno.ErrAccessTokenExpired)
+
+	// 按HTTP方法或grpc调用限制动作的旧令牌需要重新创建
+	for _, actions := range []string{"GET", "GET,POST", "CALL", "post:list,DELETE"} {
+		_, err = authenticator.AuthenticateAccessToken(ctx, createToken(actions, nil))
+		assert.ErrorIs(t, err, errno.ErrAccessTokenLegacy, actions)
+	}
+
+	_, err = authenticator.AuthenticateAccessToken(ctx, token.PersonalPrefix+"unknown")
+	assert.ErrorIs(t, err, errno.ErrAccessTokenInvalid)
+}
+
+func TestDataExportAuthorization(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:apiserver_authz_test?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.CasbinRuleM{}))
+	rules := defaultCasbinRules()
+	require.NoError(t, db.Create(&rules).Error)
+	authz, err := auth.NewAuthz(db)
+	require.NoError(t, err)
+	_, err = authz.AddGroupingPolicy("user-000001", known.RoleUser, known.DefaultTenant)
+	require.NoError(t, err)
+	registry := permission.NewRegistry()
+
+	// gRPC: 按初始化的授权策略, 普通用户只能导出自己的数据, 导出指定用户的数据只允许管理员使用
+	interceptor := grpcmw.AuthzInterceptor(authz, registry)
+	call := func(userID string, fullMethod string) error {
+		ctx := contextx.WithTenantID(contextx.WithUserID(context.Background(), userID), known.DefaultTenant)
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+		return err
+	}
+	assert.NoError(t, call("user-000001", apiv1.MiniBlog_ExportMyData_FullMethodName))
+	assert.ErrorIs(t, call("user-000001", apiv1.MiniBlog_ExportUserData_FullMethodName), errno.ErrPermissionDenied)
+	assert.NoError(t, call("user-000000", apiv1.MiniBlog_ExportUserData_FullMethodName))
+
+	// HTTP: 与gRPC使用相同的权限
+	gin.SetMode(gin.TestMode)
+	serve := func(userID string, path string) int {
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			ctx := contextx.WithTenantID(contextx.WithUserID(c.Request.Context(), userID), known.DefaultTenant)
+			c.Request = c.Request.WithContext(ctx)
+		}, ginmw.AuthzMiddleware(authz, registry))
+		ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+		engine.GET("/v1/data-export", ok)
+		engine.GET("/v1/user-data-exports/:userID", ok)
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, serve("user-000001", "/v1/data-export"))
+	assert.Equal(t, http.StatusForbidden, serve("user-000001", "/v1/user-data-exports/user-000002"))
+	assert.Equal(t, http.StatusOK, serve("user-000000", "/v1/user-data-exports/user-000002"))
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// AccessTokenStore 定义了个人访问令牌在 store 层所实现的方法.
+type AccessTokenStore interface {
+	Create(ctx context.Context, obj *model.AccessTokenM) error
+	Update(ctx context.Context, obj *model.AccessTokenM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AccessTokenM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AccessTokenM, error)
+
+	AccessTokenExpansion
+}
+
+// AccessTokenExpansion 定义了个人访问令牌操作的附加方法.
+type AccessTokenExpansion interface {
+	// Touch 更新访问令牌的最后使用时间.
+	Touch(ctx context.Context, tokenID string, usedAt time.Time) error
+	// DeleteByUserID 删除用户的全部访问令牌, 返回删除的数量.
+	DeleteByUserID(ctx context.Context, userID string) (int64, error)
+}
+
+// accessTokenStore 是 AccessTokenStore 接口的实现.
+type accessTokenStore struct {
+	store *datastore
+	*genericstore.Store[model.AccessTokenM]
+}
+
+// 确保 accessTokenStore 实现了 AccessTokenStore 接口.
+var _ AccessTokenStore = (*accessTokenStore)(nil)
+
+// newAccessTokenStore 创建 accessTokenStore 的实例.
+func newAccessTokenStore(store *datastore) *accessTokenStore {
+	return &accessTokenStore{
+		store: store,
+		Store: genericstore.NewStore[model.AccessTokenM](store, NewLogger()),
+	}
+}
+
+// Touch 只更新lastUsedAt列, 避免覆盖并发修改的其他字段.
+func (s *accessTokenStore) Touch(ctx context.Context, tokenID string, usedAt time.Time) error {
+	err := s.store.DB(ctx).Model(&model.AccessTokenM{}).
+		Where("tokenID = ?", tokenID).
+		Update("lastUsedAt", usedAt).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to update last used time of access token", "tokenID", tokenID)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByUserID 删除用户的全部访问令牌, 用于删除用户时清理令牌.
+func (s *accessTokenStore) DeleteByUserID(ctx context.Context, userID string) (int64, error) {
+	result := s.store.DB(ctx).Where("userID = ?", userID).Delete(&model.AccessTokenM{})
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to delete access tokens of user", "userID", userID)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
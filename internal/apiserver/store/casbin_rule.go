@@ -0,0 +1,60 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// CasbinRuleStore 定义了casbin策略在 store 层所实现的方法.
+// 策略通常通过授权器修改, 只有需要与其他数据在同一个事务中修改时才直接操作策略表.
+type CasbinRuleStore interface {
+	Create(ctx context.Context, obj *model.CasbinRuleM) error
+	Update(ctx context.Context, obj *model.CasbinRuleM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.CasbinRuleM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.CasbinRuleM, error)
+
+	CasbinRuleExpansion
+}
+
+// CasbinRuleExpansion 定义了casbin策略操作的附加方法.
+type CasbinRuleExpansion interface {
+	// DeleteBySubject 删除以subject为主体的策略和角色继承规则, 返回删除的数量.
+	DeleteBySubject(ctx context.Context, subject string) (int64, error)
+}
+
+// casbinRuleStore 是 CasbinRuleStore 接口的实现.
+type casbinRuleStore struct {
+	store *datastore
+	*genericstore.Store[model.CasbinRuleM]
+}
+
+// 确保 casbinRuleStore 实现了 CasbinRuleStore 接口.
+var _ CasbinRuleStore = (*casbinRuleStore)(nil)
+
+// newCasbinRuleStore 创建 casbinRuleStore 的实例.
+func newCasbinRuleStore(store *datastore) *casbinRuleStore {
+	return &casbinRuleStore{
+		store: store,
+		Store: genericstore.NewStore[model.CasbinRuleM](store, NewLogger()),
+	}
+}
+
+// DeleteBySubject 只删除数据库中的策略, 调用方需要在事务提交后同步授权器.
+func (s *casbinRuleStore) DeleteBySubject(ctx context.Context, subject string) (int64, error) {
+	result := s.store.DB(ctx).Where("ptype IN ? AND v0 = ?", []string{"p", "g"}, subject).Delete(&model.CasbinRuleM{})
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to delete casbin rules of subject", "subject", subject)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
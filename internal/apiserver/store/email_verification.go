@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// EmailVerificationStore 定义了电子邮箱验证令牌在 store 层所实现的方法.
+type EmailVerificationStore interface {
+	Create(ctx context.Context, obj *model.EmailVerificationM) error
+	Update(ctx context.Context, obj *model.EmailVerificationM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.EmailVerificationM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.EmailVerificationM, error)
+
+	EmailVerificationExpansion
+}
+
+// EmailVerificationExpansion 定义了电子邮箱验证令牌操作的附加方法.
+type EmailVerificationExpansion interface {
+	// MarkUsed 将未使用且未过期的验证令牌标记为已使用, 返回值表示本次调用是否真正完成了标记.
+	MarkUsed(ctx context.Context, tokenHash string, now time.Time) (bool, error)
+}
+
+// emailVerificationStore 是 EmailVerificationStore 接口的实现.
+type emailVerificationStore struct {
+	store *datastore
+	*genericstore.Store[model.EmailVerificationM]
+}
+
+// 确保 emailVerificationStore 实现了 EmailVerificationStore 接口.
+var _ EmailVerificationStore = (*emailVerificationStore)(nil)
+
+// newEmailVerificationStore 创建 emailVerificationStore 的实例.
+func newEmailVerificationStore(store *datastore) *emailVerificationStore {
+	return &emailVerificationStore{
+		store: store,
+		Store: genericstore.NewStore[model.EmailVerificationM](store, NewLogger()),
+	}
+}
+
+// MarkUsed 使用带条件的更新语句标记验证令牌, 并发请求中只有一个能够使用同一个令牌.
+func (s *emailVerificationStore) MarkUsed(ctx context.Context, tokenHash string, now time.Time) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.EmailVerificationM{}).
+		Where("tokenHash = ? AND usedAt IS NULL AND expiresAt > ?", tokenHash, now).
+		Update("usedAt", now)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to mark email verification token as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
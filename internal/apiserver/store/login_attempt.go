@@ -0,0 +1,99 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptStore 定义了登录失败计数在 store 层所实现的方法.
+type LoginAttemptStore interface {
+	Create(ctx context.Context, obj *model.LoginAttemptM) error
+	Update(ctx context.Context, obj *model.LoginAttemptM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.LoginAttemptM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.LoginAttemptM, error)
+
+	LoginAttemptExpansion
+}
+
+// LoginAttemptExpansion 定义了登录失败计数操作的附加方法.
+type LoginAttemptExpansion interface {
+	// Increment 将subject的失败次数加一并记录失败时间, 最近一次失败早于staleBefore的记录从1重新计数.
+	// 返回累加之前的记录, 没有记录时返回nil. 多个实例同时记录同一个subject时, 依赖数据库保证计数不会丢失.
+	Increment(ctx context.Context, subject string, failedAt time.Time, staleBefore time.Time) (*model.LoginAttemptM, error)
+	// Decrement 将subject的失败次数减一, 用于撤销一次没有失败的尝试.
+	Decrement(ctx context.Context, subject string) error
+}
+
+// loginAttemptStore 是 LoginAttemptStore 接口的实现.
+type loginAttemptStore struct {
+	store *datastore
+	*genericstore.Store[model.LoginAttemptM]
+}
+
+// 确保 loginAttemptStore 实现了 LoginAttemptStore 接口.
+var _ LoginAttemptStore = (*loginAttemptStore)(nil)
+
+// newLoginAttemptStore 创建 loginAttemptStore 的实例.
+func newLoginAttemptStore(store *datastore) *loginAttemptStore {
+	return &loginAttemptStore{
+		store: store,
+		Store: genericstore.NewStore[model.LoginAttemptM](store, NewLogger()),
+	}
+}
+
+// Increment 在事务中锁定旧的记录, 再使用 upsert 语句原子地累加失败次数.
+// 注意 MySQL 按顺序执行赋值, 因此必须先根据旧的 lastFailedAt 计算 failures, 再更新 lastFailedAt.
+func (s *loginAttemptStore) Increment(ctx context.Context, subject string, failedAt time.Time, staleBefore time.Time) (*model.LoginAttemptM, error) {
+	var previous *model.LoginAttemptM
+	err := s.store.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		var attemptM model.LoginAttemptM
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("subject = ?", subject).Take(&attemptM).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			previous = &attemptM
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "subject"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "failures"}, Value: gorm.Expr("CASE WHEN lastFailedAt < ? THEN 1 ELSE failures + 1 END", staleBefore)},
+				{Column: clause.Column{Name: "lastFailedAt"}, Value: failedAt},
+				{Column: clause.Column{Name: "updatedAt"}, Value: failedAt},
+			},
+		}).Create(&model.LoginAttemptM{Subject: subject, Failures: 1, LastFailedAt: failedAt}).Error
+	})
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to increment login attempts", "subject", subject)
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// Decrement 使用带条件的更新语句减少失败次数, 失败次数不会小于0.
+func (s *loginAttemptStore) Decrement(ctx context.Context, subject string) error {
+	err := s.store.DB(ctx).Model(&model.LoginAttemptM{}).
+		Where("subject = ? AND failures > 0", subject).
+		Update("failures", gorm.Expr("failures - 1")).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to decrement login attempts", "subject", subject)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// OIDCLoginStore 定义了OIDC授权请求在 store 层所实现的方法.
+type OIDCLoginStore interface {
+	Create(ctx context.Context, obj *model.OIDCLoginM) error
+	Update(ctx context.Context, obj *model.OIDCLoginM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.OIDCLoginM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.OIDCLoginM, error)
+
+	OIDCLoginExpansion
+}
+
+// OIDCLoginExpansion 定义了OIDC授权请求操作的附加方法.
+type OIDCLoginExpansion interface {
+	// MarkUsed 将未使用且未过期的授权请求标记为已使用, 返回值表示本次调用是否真正完成了标记.
+	MarkUsed(ctx context.Context, stateHash string, now time.Time) (bool, error)
+}
+
+// oidcLoginStore 是 OIDCLoginStore 接口的实现.
+type oidcLoginStore struct {
+	store *datastore
+	*genericstore.Store[model.OIDCLoginM]
+}
+
+// 确保 oidcLoginStore 实现了 OIDCLoginStore 接口.
+var _ OIDCLoginStore = (*oidcLoginStore)(nil)
+
+// newOIDCLoginStore 创建 oidcLoginStore 的实例.
+func newOIDCLoginStore(store *datastore) *oidcLoginStore {
+	return &oidcLoginStore{
+		store: store,
+		Store: genericstore.NewStore[model.OIDCLoginM](store, NewLogger()),
+	}
+}
+
+// MarkUsed 使用带条件的更新语句标记授权请求, 同一个state只能完成一次回调.
+func (s *oidcLoginStore) MarkUsed(ctx context.Context, stateHash string, now time.Time) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.OIDCLoginM{}).
+		Where("stateHash = ? AND usedAt IS NULL AND expiresAt > ?", stateHash, now).
+		Update("usedAt", now)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to mark oidc login as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
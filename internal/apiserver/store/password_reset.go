@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// PasswordResetStore 定义了密码重置令牌在 store 层所实现的方法.
+type PasswordResetStore interface {
+	Create(ctx context.Context, obj *model.PasswordResetM) error
+	Update(ctx context.Context, obj *model.PasswordResetM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.PasswordResetM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PasswordResetM, error)
+
+	PasswordResetExpansion
+}
+
+// PasswordResetExpansion 定义了密码重置令牌操作的附加方法.
+type PasswordResetExpansion interface {
+	// MarkUsed 将未使用且未过期的重置令牌标记为已使用, 返回值表示本次调用是否真正完成了标记.
+	MarkUsed(ctx context.Context, tokenHash string, now time.Time) (bool, error)
+}
+
+// passwordResetStore 是 PasswordResetStore 接口的实现.
+type passwordResetStore struct {
+	store *datastore
+	*genericstore.Store[model.PasswordResetM]
+}
+
+// 确保 passwordResetStore 实现了 PasswordResetStore 接口.
+var _ PasswordResetStore = (*passwordResetStore)(nil)
+
+// newPasswordResetStore 创建 passwordResetStore 的实例.
+func newPasswordResetStore(store *datastore) *passwordResetStore {
+	return &passwordResetStore{
+		store: store,
+		Store: genericstore.NewStore[model.PasswordResetM](store, NewLogger()),
+	}
+}
+
+// MarkUsed 使用带条件的更新语句标记重置令牌, 并发请求中只有一个能够使用同一个令牌.
+func (s *passwordResetStore) MarkUsed(ctx context.Context, tokenHash string, now time.Time) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.PasswordResetM{}).
+		Where("tokenHash = ? AND usedAt IS NULL AND expiresAt > ?", tokenHash, now).
+		Update("usedAt", now)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to mark password reset token as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
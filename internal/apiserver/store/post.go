@@ -7,10 +7,13 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"miniblog/internal/apiserver/model"
+	"time"
 
 	genericstore "github.com/onexstack/onexstack/pkg/store"
 	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
 )
 
 type PostStore interface {
@@ -24,12 +27,25 @@ type PostStore interface {
 }
 
 // 自定义帖子操作等附加方法.
-type PostExpansion interface{}
+type PostExpansion interface {
+	// CountByUserIDs 统计多个用户的博客数, key为用户ID, 没有博客的用户不在结果中.
+	CountByUserIDs(ctx context.Context, userIDs []string) (map[string]int64, error)
+	// Restore 恢复已删除的博客, 博客不存在, 没有被删除或者作者已被删除时返回gorm.ErrRecordNotFound.
+	Restore(ctx context.Context, postID string) error
+	// DeleteByUserID 删除用户的全部博客, 返回删除的数量.
+	DeleteByUserID(ctx context.Context, userID string) (int64, error)
+	// RestoreByUserID 恢复随用户一起删除的博客, 需要在恢复用户之前调用, 返回恢复的数量.
+	RestoreByUserID(ctx context.Context, userID string) (int64, error)
+	// PurgeByUserID 永久删除用户的全部博客, 包括未删除的博客, 返回删除的数量.
+	PurgeByUserID(ctx context.Context, userID string) (int64, error)
+	// PurgeDeleted 永久删除删除时间早于before的博客, 返回删除的数量.
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
 
 // 使用标准Store类型
 // PostStore的接口实现.
 type postStore struct {
-	// store *datastore
+	store *datastore
 	*genericstore.Store[model.PostM]
 }
 
@@ -37,10 +53,104 @@ var _ PostStore = (*postStore)(nil)
 
 func newPostStore(store *datastore) *postStore {
 	return &postStore{
+		store: store,
 		Store: genericstore.NewStore[model.PostM](store, NewLogger()),
 	}
 }
 
+// CountByUserIDs 使用一次分组查询统计博客数, 不需要加载博客内容.
+func (s *postStore) CountByUserIDs(ctx context.Context, userIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		UserID string `gorm:"column:userID"`
+		Count  int64  `gorm:"column:count"`
+	}
+	err := s.store.DB(ctx).Model(&model.PostM{}).
+		Select("userID, COUNT(*) AS count").
+		Where("userID IN ?", userIDs).
+		Group("userID").
+		Find(&rows).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to count posts of users", "userIDs", userIDs)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
+// 作者未被删除的条件, 作者被删除后博客只能随作者一起恢复.
+var postOwnerExistsSQL = fmt.Sprintf("userID IN (SELECT userID FROM `%s` WHERE deletedAt IS NULL)", model.TableNameUserM)
+
+// Restore 清除博客的删除时间, Unscoped使查询包含已删除的博客.
+func (s *postStore) Restore(ctx context.Context, postID string) error {
+	result := s.store.DB(ctx).Unscoped().Model(&model.PostM{}).
+		Where("postID = ? AND deletedAt IS NOT NULL", postID).
+		Where(postOwnerExistsSQL).
+		Update("deletedAt", nil)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to restore post", "postID", postID)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID 删除用户的博客, 与删除用户相同只记录删除时间.
+func (s *postStore) DeleteByUserID(ctx context.Context, userID string) (int64, error) {
+	result := s.store.DB(ctx).Where("userID = ?", userID).Delete(&model.PostM{})
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to delete posts of user", "userID", userID)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// 随用户一起删除的博客的条件, 这些博客在用户之后删除, 删除时间不早于用户的删除时间.
+// 用户删除前已经单独删除的博客不会被恢复.
+var postDeletedWithOwnerSQL = fmt.Sprintf("deletedAt >= (SELECT deletedAt FROM `%s` WHERE userID = ?)", model.TableNameUserM)
+
+// RestoreByUserID 清除随用户一起删除的博客的删除时间.
+func (s *postStore) RestoreByUserID(ctx context.Context, userID string) (int64, error) {
+	result := s.store.DB(ctx).Unscoped().Model(&model.PostM{}).
+		Where("userID = ? AND deletedAt IS NOT NULL", userID).
+		Where(postDeletedWithOwnerSQL, userID).
+		Update("deletedAt", nil)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to restore posts of user", "userID", userID)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgeByUserID 使用Unscoped永久删除用户的博客, 用于清理已删除的用户.
+func (s *postStore) PurgeByUserID(ctx context.Context, userID string) (int64, error) {
+	result := s.store.DB(ctx).Unscoped().Where("userID = ?", userID).Delete(&model.PostM{})
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to purge posts of user", "userID", userID)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgeDeleted 使用Unscoped永久删除过期的已删除博客.
+func (s *postStore) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := s.store.DB(ctx).Unscoped().Where("deletedAt IS NOT NULL AND deletedAt < ?", before).Delete(&model.PostM{})
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to purge deleted posts", "before", before)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 // func newPostStore(store *datastore) *postStore {
 // 	return &postStore{store: store}
 // }
@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// RecoveryCodeStore 定义了两步验证恢复码在 store 层所实现的方法.
+type RecoveryCodeStore interface {
+	Create(ctx context.Context, obj *model.RecoveryCodeM) error
+	Update(ctx context.Context, obj *model.RecoveryCodeM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.RecoveryCodeM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.RecoveryCodeM, error)
+
+	RecoveryCodeExpansion
+}
+
+// RecoveryCodeExpansion 定义了恢复码操作的附加方法.
+type RecoveryCodeExpansion interface {
+	// MarkUsed 将用户未使用的恢复码标记为已使用, 返回值表示本次调用是否真正完成了标记.
+	MarkUsed(ctx context.Context, userID string, codeHash string) (bool, error)
+}
+
+// recoveryCodeStore 是 RecoveryCodeStore 接口的实现.
+type recoveryCodeStore struct {
+	store *datastore
+	*genericstore.Store[model.RecoveryCodeM]
+}
+
+// 确保 recoveryCodeStore 实现了 RecoveryCodeStore 接口.
+var _ RecoveryCodeStore = (*recoveryCodeStore)(nil)
+
+// newRecoveryCodeStore 创建 recoveryCodeStore 的实例.
+func newRecoveryCodeStore(store *datastore) *recoveryCodeStore {
+	return &recoveryCodeStore{
+		store: store,
+		Store: genericstore.NewStore[model.RecoveryCodeM](store, NewLogger()),
+	}
+}
+
+// MarkUsed 使用带条件的更新语句标记恢复码, 每个恢复码只能使用一次.
+func (s *recoveryCodeStore) MarkUsed(ctx context.Context, userID string, codeHash string) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.RecoveryCodeM{}).
+		Where("userID = ? AND codeHash = ? AND usedAt IS NULL", userID, codeHash).
+		Update("usedAt", time.Now())
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to mark recovery code as used", "userID", userID)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
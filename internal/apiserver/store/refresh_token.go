@@ -0,0 +1,93 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// RefreshTokenStore 定义了刷新令牌在 store 层所实现的方法.
+type RefreshTokenStore interface {
+	Create(ctx context.Context, obj *model.RefreshTokenM) error
+	Update(ctx context.Context, obj *model.RefreshTokenM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.RefreshTokenM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.RefreshTokenM, error)
+
+	RefreshTokenExpansion
+}
+
+// RefreshTokenExpansion 定义了刷新令牌操作的附加方法.
+type RefreshTokenExpansion interface {
+	// MarkUsed 将未使用的刷新令牌标记为已使用, 返回值表示本次调用是否真正完成了标记.
+	// 并发请求使用同一个刷新令牌时, 只有一个请求能够标记成功.
+	MarkUsed(ctx context.Context, tokenHash string) (bool, error)
+	// RevokeFamily 吊销同一令牌族中所有尚未失效的刷新令牌.
+	RevokeFamily(ctx context.Context, familyID string) error
+	// RevokeByUser 吊销用户所有尚未失效的刷新令牌, 返回吊销的数量.
+	RevokeByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// refreshTokenStore 是 RefreshTokenStore 接口的实现.
+type refreshTokenStore struct {
+	store *datastore
+	*genericstore.Store[model.RefreshTokenM]
+}
+
+// 确保 refreshTokenStore 实现了 RefreshTokenStore 接口.
+var _ RefreshTokenStore = (*refreshTokenStore)(nil)
+
+// newRefreshTokenStore 创建 refreshTokenStore 的实例.
+func newRefreshTokenStore(store *datastore) *refreshTokenStore {
+	return &refreshTokenStore{
+		store: store,
+		Store: genericstore.NewStore[model.RefreshTokenM](store, NewLogger()),
+	}
+}
+
+// MarkUsed 使用带条件的更新语句标记刷新令牌, 依赖数据库保证标记操作的原子性.
+func (s *refreshTokenStore) MarkUsed(ctx context.Context, tokenHash string) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.RefreshTokenM{}).
+		Where("tokenHash = ? AND revokedAt IS NULL", tokenHash).
+		Update("revokedAt", time.Now())
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to mark refresh token as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily 吊销令牌族中所有尚未失效的刷新令牌.
+func (s *refreshTokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	err := s.store.DB(ctx).Model(&model.RefreshTokenM{}).
+		Where("familyID = ? AND revokedAt IS NULL", familyID).
+		Update("revokedAt", time.Now()).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to revoke refresh token family", "familyID", familyID)
+		return err
+	}
+
+	return nil
+}
+
+// RevokeByUser 吊销用户所有尚未失效的刷新令牌.
+func (s *refreshTokenStore) RevokeByUser(ctx context.Context, userID string) (int64, error) {
+	result := s.store.DB(ctx).Model(&model.RefreshTokenM{}).
+		Where("userID = ? AND revokedAt IS NULL", userID).
+		Update("revokedAt", time.Now())
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to revoke refresh tokens of user", "userID", userID)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedTokenStore 定义了令牌黑名单在 store 层所实现的方法.
+type RevokedTokenStore interface {
+	Create(ctx context.Context, obj *model.RevokedTokenM) error
+	Update(ctx context.Context, obj *model.RevokedTokenM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.RevokedTokenM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.RevokedTokenM, error)
+
+	RevokedTokenExpansion
+}
+
+// RevokedTokenExpansion 定义了令牌黑名单操作的附加方法.
+type RevokedTokenExpansion interface {
+	// IsRevoked 判断令牌是否被单独吊销, 或者签发时间早于该用户最近一次全部吊销的时间.
+	IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error)
+	// CreateIfAbsent 写入吊销记录, 返回值表示记录是否由本次调用写入, jti已存在时不做任何修改.
+	CreateIfAbsent(ctx context.Context, obj *model.RevokedTokenM) (bool, error)
+}
+
+// revokedTokenStore 是 RevokedTokenStore 接口的实现.
+type revokedTokenStore struct {
+	store *datastore
+	*genericstore.Store[model.RevokedTokenM]
+}
+
+// 确保 revokedTokenStore 实现了 RevokedTokenStore 接口.
+var _ RevokedTokenStore = (*revokedTokenStore)(nil)
+
+// newRevokedTokenStore 创建 revokedTokenStore 的实例.
+func newRevokedTokenStore(store *datastore) *revokedTokenStore {
+	return &revokedTokenStore{
+		store: store,
+		Store: genericstore.NewStore[model.RevokedTokenM](store, NewLogger()),
+	}
+}
+
+// IsRevoked 使用一条查询同时检查单个令牌和用户级别的吊销记录.
+// iat 只精确到秒, 因此与吊销操作处于同一秒内签发的令牌不会被视为已吊销.
+func (s *revokedTokenStore) IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error) {
+	var count int64
+	err := s.store.DB(ctx).Model(&model.RevokedTokenM{}).
+		Where("jti = ? OR (jti IS NULL AND userID = ? AND createdAt >= ?)", jti, userID, issuedAt.Add(time.Second)).
+		Count(&count).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to check revoked token", "jti", jti)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CreateIfAbsent 使用 ON CONFLICT DO NOTHING 写入记录, 并发写入同一个jti时只有一条语句影响了记录.
+func (s *revokedTokenStore) CreateIfAbsent(ctx context.Context, obj *model.RevokedTokenM) (bool, error) {
+	result := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(obj)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to create revoked token", "userID", obj.UserID)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"time"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// SessionStore 定义了登录会话在 store 层所实现的方法.
+type SessionStore interface {
+	Create(ctx context.Context, obj *model.SessionM) error
+	Update(ctx context.Context, obj *model.SessionM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.SessionM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.SessionM, error)
+
+	SessionExpansion
+}
+
+// SessionExpansion 定义了登录会话操作的附加方法.
+type SessionExpansion interface {
+	// Touch 批量更新会话的最后活跃时间, key为会话ID.
+	Touch(ctx context.Context, lastSeen map[string]time.Time) error
+	// Extend 更新会话的过期时间.
+	Extend(ctx context.Context, sessionID string, expiresAt time.Time) error
+	// Revoke 吊销尚未失效的会话, 返回值表示本次调用是否真正完成了吊销.
+	Revoke(ctx context.Context, sessionID string) (bool, error)
+	// RevokeByUser 吊销用户所有尚未失效的会话, 返回吊销的数量.
+	RevokeByUser(ctx context.Context, userID string) (int64, error)
+}
+
+// sessionStore 是 SessionStore 接口的实现.
+type sessionStore struct {
+	store *datastore
+	*genericstore.Store[model.SessionM]
+}
+
+// 确保 sessionStore 实现了 SessionStore 接口.
+var _ SessionStore = (*sessionStore)(nil)
+
+// newSessionStore 创建 sessionStore 的实例.
+func newSessionStore(store *datastore) *sessionStore {
+	return &sessionStore{
+		store: store,
+		Store: genericstore.NewStore[model.SessionM](store, NewLogger()),
+	}
+}
+
+// Touch 在一个事务中更新多个会话的lastSeenAt列, 只会将最后活跃时间向后推移.
+func (s *sessionStore) Touch(ctx context.Context, lastSeen map[string]time.Time) error {
+	if len(lastSeen) == 0 {
+		return nil
+	}
+
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		for sessionID, seenAt := range lastSeen {
+			err := s.store.DB(ctx).Model(&model.SessionM{}).
+				Where("sessionID = ? AND lastSeenAt < ?", sessionID, seenAt).
+				UpdateColumn("lastSeenAt", seenAt).Error
+			if err != nil {
+				NewLogger().Error(ctx, err, "Failed to update last seen time of session", "sessionID", sessionID)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Extend 只更新expiresAt列, 避免覆盖并发写入的最后活跃时间.
+func (s *sessionStore) Extend(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	err := s.store.DB(ctx).Model(&model.SessionM{}).
+		Where("sessionID = ?", sessionID).
+		Update("expiresAt", expiresAt).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to extend session", "sessionID", sessionID)
+		return err
+	}
+
+	return nil
+}
+
+// Revoke 使用带条件的更新语句吊销会话.
+func (s *sessionStore) Revoke(ctx context.Context, sessionID string) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.SessionM{}).
+		Where("sessionID = ? AND revokedAt IS NULL", sessionID).
+		Update("revokedAt", time.Now())
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to revoke session", "sessionID", sessionID)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeByUser 吊销用户所有尚未失效的会话.
+func (s *sessionStore) RevokeByUser(ctx context.Context, userID string) (int64, error) {
+	result := s.store.DB(ctx).Model(&model.SessionM{}).
+		Where("userID = ? AND revokedAt IS NULL", userID).
+		Update("revokedAt", time.Now())
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to revoke sessions of user", "userID", userID)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
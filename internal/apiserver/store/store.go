@@ -35,6 +35,18 @@ type IStore interface {
 
 	User() UserStore
 	Post() PostStore
+	RefreshToken() RefreshTokenStore
+	RevokedToken() RevokedTokenStore
+	AccessToken() AccessTokenStore
+	UserTOTP() UserTOTPStore
+	RecoveryCode() RecoveryCodeStore
+	LoginAttempt() LoginAttemptStore
+	PasswordReset() PasswordResetStore
+	EmailVerification() EmailVerificationStore
+	Session() SessionStore
+	OIDCLogin() OIDCLoginStore
+	UserIdentity() UserIdentityStore
+	CasbinRule() CasbinRuleStore
 	// ConcretePosts 是一个示例 store 实现, 用来演示在 Go 中如何直接与 DB 交互.
 	ConcretePost() ConcretePostStore
 }
@@ -55,18 +67,24 @@ var _ IStore = (*datastore)(nil)
 func NewStore(db *gorm.DB) *datastore {
 	// 单例模式保证全局共享一个数据库连接池, 减少资源开销, 同时方便其他模块直接访问 store.S
 	once.Do(func() {
+		// 回调注册在数据库实例上, 单例模式保证只注册一次
+		if err := registerTenantCallbacks(db); err != nil {
+			panic(err)
+		}
 		S = &datastore{db}
 	})
 	return S
 }
 
 // 如果未传入任何条件, 则返回上下文中的数据库实例(事务实例或核心数据库实例).
+// 返回的实例携带了ctx, 按租户隔离数据的回调从中获取当前租户.
 func (store *datastore) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
 	db := store.core
 	// 从上下文中提取事务实例
 	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
 		db = tx
 	}
+	db = db.WithContext(ctx)
 	// 遍历所有传入的条件并逐一叠加到数据库查询对象上
 	for _, whr := range wheres {
 		db = whr.Where(db)
@@ -94,6 +112,66 @@ func (store *datastore) Post() PostStore {
 	return newPostStore(store)
 }
 
+// 返回一个实现了RefreshTokenStore接口的实例.
+func (store *datastore) RefreshToken() RefreshTokenStore {
+	return newRefreshTokenStore(store)
+}
+
+// 返回一个实现了RevokedTokenStore接口的实例.
+func (store *datastore) RevokedToken() RevokedTokenStore {
+	return newRevokedTokenStore(store)
+}
+
+// 返回一个实现了AccessTokenStore接口的实例.
+func (store *datastore) AccessToken() AccessTokenStore {
+	return newAccessTokenStore(store)
+}
+
+// 返回一个实现了UserTOTPStore接口的实例.
+func (store *datastore) UserTOTP() UserTOTPStore {
+	return newUserTOTPStore(store)
+}
+
+// 返回一个实现了RecoveryCodeStore接口的实例.
+func (store *datastore) RecoveryCode() RecoveryCodeStore {
+	return newRecoveryCodeStore(store)
+}
+
+// 返回一个实现了LoginAttemptStore接口的实例.
+func (store *datastore) LoginAttempt() LoginAttemptStore {
+	return newLoginAttemptStore(store)
+}
+
+// 返回一个实现了PasswordResetStore接口的实例.
+func (store *datastore) PasswordReset() PasswordResetStore {
+	return newPasswordResetStore(store)
+}
+
+// 返回一个实现了EmailVerificationStore接口的实例.
+func (store *datastore) EmailVerification() EmailVerificationStore {
+	return newEmailVerificationStore(store)
+}
+
+// 返回一个实现了SessionStore接口的实例.
+func (store *datastore) Session() SessionStore {
+	return newSessionStore(store)
+}
+
+// 返回一个实现了OIDCLoginStore接口的实例.
+func (store *datastore) OIDCLogin() OIDCLoginStore {
+	return newOIDCLoginStore(store)
+}
+
+// 返回一个实现了UserIdentityStore接口的实例.
+func (store *datastore) UserIdentity() UserIdentityStore {
+	return newUserIdentityStore(store)
+}
+
+// 返回一个实现了CasbinRuleStore接口的实例.
+func (store *datastore) CasbinRule() CasbinRuleStore {
+	return newCasbinRuleStore(store)
+}
+
 // ConcretePosts 返回一个实现了 ConcretePostStore 接口的实例.
 func (store *datastore) ConcretePost() ConcretePostStore {
 	return newConcretePostStore(store)
@@ -0,0 +1,109 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// 注册的gorm回调的名称.
+const tenantCallbackName = "miniblog:tenant"
+
+// 按租户隔离数据时使用的列和租户解析函数.
+var registeredTenant struct {
+	column    string
+	valueFunc func(ctx context.Context) string
+}
+
+// RegisterTenant 注册按租户隔离数据的列, 以及从上下文中获取当前租户的函数.
+// 注册后, 包含该列的模型在查询, 更新和删除时会自动添加当前租户的条件, 创建时自动填充当前租户.
+// 上下文中没有租户时(例如登录和注册等未认证的请求)不做限制.
+// where.RegisterTenant只能注册一个租户, 已经用于where.T按用户过滤数据, 因此租户隔离在存储层单独注册.
+func RegisterTenant(column string, valueFunc func(ctx context.Context) string) {
+	registeredTenant.column = column
+	registeredTenant.valueFunc = valueFunc
+}
+
+// 上下文中标记不按租户隔离数据的键.
+type withoutTenantKey struct{}
+
+// WithoutTenant 返回不按租户隔离数据的上下文, 通过该上下文执行的操作可以访问全部租户的数据.
+// 只能用于已经确认被授权管理全部租户的请求, 例如在全部租户*中拥有管理员角色的全局管理员.
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutTenantKey{}, true)
+}
+
+// 在数据库实例上注册按租户隔离数据的回调, 通过该实例执行的所有操作都会经过回调.
+func registerTenantCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register(tenantCallbackName, fillTenant); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register(tenantCallbackName, scopeTenant); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register(tenantCallbackName, scopeTenant); err != nil {
+		return err
+	}
+	// Row和Scan等方法使用Row回调, 同样需要限制租户
+	if err := callback.Row().Before("gorm:row").Register(tenantCallbackName, scopeTenant); err != nil {
+		return err
+	}
+	return callback.Delete().Before("gorm:delete").Register(tenantCallbackName, scopeTenant)
+}
+
+// 返回模型中的租户字段和上下文中的当前租户, 模型没有租户字段, 上下文中没有租户或不按租户隔离时返回false.
+func currentTenant(db *gorm.DB) (*schema.Field, string, bool) {
+	if registeredTenant.valueFunc == nil || db.Statement.Schema == nil {
+		return nil, "", false
+	}
+	if without, _ := db.Statement.Context.Value(withoutTenantKey{}).(bool); without {
+		return nil, "", false
+	}
+
+	field := db.Statement.Schema.LookUpField(registeredTenant.column)
+	if field == nil {
+		return nil, "", false
+	}
+
+	tenant := registeredTenant.valueFunc(db.Statement.Context)
+	return field, tenant, tenant != ""
+}
+
+// 为查询, 更新, 删除和Row添加当前租户的条件.
+func scopeTenant(db *gorm.DB) {
+	field, tenant, ok := currentTenant(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenant},
+	}})
+}
+
+// 创建记录时使用当前租户, 已登录的用户不能在其他租户中创建记录.
+func fillTenant(db *gorm.DB) {
+	field, tenant, ok := currentTenant(db)
+	if !ok {
+		return
+	}
+
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			db.AddError(field.Set(ctx, reflect.Indirect(rv.Index(i)), tenant))
+		}
+	case reflect.Struct:
+		db.AddError(field.Set(ctx, rv, tenant))
+	}
+}
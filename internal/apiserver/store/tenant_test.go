@@ -0,0 +1,165 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 创建注册了租户回调的数据库, 每个测试使用独立的内存数据库.
+func newTenantTestDB(t *testing.T) *gorm.DB {
+	RegisterTenant("tenantID", contextx.TenantID)
+
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, registerTenantCallbacks(db))
+	require.NoError(t, db.AutoMigrate(&model.PostM{}))
+
+	return db
+}
+
+// 在指定租户中创建博文, 租户为空时不限制租户.
+// 博文ID在创建后生成, 测试中使用标题区分博文.
+func createTenantTestPost(t *testing.T, db *gorm.DB, title string, tenantID string) {
+	ctx := contextx.WithTenantID(context.Background(), tenantID)
+	require.NoError(t, db.WithContext(ctx).Create(&model.PostM{UserID: "user-tenant", TenantID: tenantID, Title: title}).Error)
+}
+
+// 返回不限制租户时博文所属的租户.
+func tenantOfTestPost(t *testing.T, db *gorm.DB, title string) string {
+	var postM model.PostM
+	require.NoError(t, db.Where("title = ?", title).Take(&postM).Error)
+
+	return postM.TenantID
+}
+
+func TestTenantCreate(t *testing.T) {
+	db := newTenantTestDB(t)
+	acme := contextx.WithTenantID(context.Background(), "acme")
+
+	// 已登录的用户只能在自己的租户中创建记录
+	require.NoError(t, db.WithContext(acme).Create(&model.PostM{Title: "post-1", UserID: "user-tenant", TenantID: "other"}).Error)
+	require.NoError(t, db.WithContext(acme).Create([]*model.PostM{
+		{PostID: "post-2", Title: "post-2", UserID: "user-tenant", TenantID: "other"},
+		{PostID: "post-3", Title: "post-3", UserID: "user-tenant"},
+	}).Error)
+	for _, title := range []string{"post-1", "post-2", "post-3"} {
+		assert.Equal(t, "acme", tenantOfTestPost(t, db, title))
+	}
+
+	// 上下文中没有租户时保留记录中的租户
+	require.NoError(t, db.Create(&model.PostM{Title: "post-4", UserID: "user-tenant", TenantID: "other"}).Error)
+	assert.Equal(t, "other", tenantOfTestPost(t, db, "post-4"))
+}
+
+func TestTenantQuery(t *testing.T) {
+	db := newTenantTestDB(t)
+	createTenantTestPost(t, db, "post-acme", "acme")
+	createTenantTestPost(t, db, "post-other", "other")
+	acme := contextx.WithTenantID(context.Background(), "acme")
+
+	var posts []*model.PostM
+	require.NoError(t, db.WithContext(acme).Find(&posts).Error)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "post-acme", posts[0].Title)
+
+	err := db.WithContext(acme).Where("title = ?", "post-other").Take(&model.PostM{}).Error
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	var count int64
+	require.NoError(t, db.WithContext(acme).Model(&model.PostM{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	// 上下文中没有租户时不做限制
+	require.NoError(t, db.Model(&model.PostM{}).Count(&count).Error)
+	assert.EqualValues(t, 2, count)
+}
+
+func TestTenantRow(t *testing.T) {
+	db := newTenantTestDB(t)
+	createTenantTestPost(t, db, "post-acme", "acme")
+	createTenantTestPost(t, db, "post-other", "other")
+	acme := contextx.WithTenantID(context.Background(), "acme")
+
+	var count int64
+	require.NoError(t, db.WithContext(acme).Model(&model.PostM{}).Select("COUNT(*)").Row().Scan(&count))
+	assert.EqualValues(t, 1, count)
+
+	rows, err := db.WithContext(acme).Model(&model.PostM{}).Select("title").Rows()
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var titles []string
+	for rows.Next() {
+		var title string
+		require.NoError(t, rows.Scan(&title))
+		titles = append(titles, title)
+	}
+	assert.Equal(t, []string{"post-acme"}, titles)
+}
+
+func TestTenantUpdate(t *testing.T) {
+	db := newTenantTestDB(t)
+	createTenantTestPost(t, db, "post-acme", "acme")
+	other := contextx.WithTenantID(context.Background(), "other")
+
+	// 不能修改其他租户的记录
+	result := db.WithContext(other).Model(&model.PostM{}).Where("title = ?", "post-acme").Update("title", "changed")
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+
+	acme := contextx.WithTenantID(context.Background(), "acme")
+	result = db.WithContext(acme).Model(&model.PostM{}).Where("title = ?", "post-acme").Update("title", "changed")
+	require.NoError(t, result.Error)
+	assert.EqualValues(t, 1, result.RowsAffected)
+}
+
+func TestTenantDelete(t *testing.T) {
+	db := newTenantTestDB(t)
+	createTenantTestPost(t, db, "post-acme", "acme")
+	other := contextx.WithTenantID(context.Background(), "other")
+
+	// 不能删除其他租户的记录
+	result := db.WithContext(other).Where("title = ?", "post-acme").Delete(&model.PostM{})
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+	assert.Equal(t, "acme", tenantOfTestPost(t, db, "post-acme"))
+
+	acme := contextx.WithTenantID(context.Background(), "acme")
+	result = db.WithContext(acme).Where("title = ?", "post-acme").Delete(&model.PostM{})
+	require.NoError(t, result.Error)
+	assert.EqualValues(t, 1, result.RowsAffected)
+}
+
+func TestWithoutTenant(t *testing.T) {
+	db := newTenantTestDB(t)
+	createTenantTestPost(t, db, "post-acme", "acme")
+	createTenantTestPost(t, db, "post-other", "other")
+	ctx := WithoutTenant(contextx.WithTenantID(context.Background(), "acme"))
+
+	// 不按租户隔离时可以访问全部租户的记录
+	var count int64
+	require.NoError(t, db.WithContext(ctx).Model(&model.PostM{}).Count(&count).Error)
+	assert.EqualValues(t, 2, count)
+
+	result := db.WithContext(ctx).Model(&model.PostM{}).Where("title = ?", "post-other").Update("content", "changed")
+	require.NoError(t, result.Error)
+	assert.EqualValues(t, 1, result.RowsAffected)
+
+	result = db.WithContext(ctx).Where("title = ?", "post-other").Delete(&model.PostM{})
+	require.NoError(t, result.Error)
+	assert.EqualValues(t, 1, result.RowsAffected)
+}
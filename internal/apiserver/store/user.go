@@ -8,9 +8,11 @@ package store
 import (
 	"context"
 	"miniblog/internal/apiserver/model"
+	"time"
 
 	genericstore "github.com/onexstack/onexstack/pkg/store"
 	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
 )
 
 // 2. 扩展方法: UserExpansion, 用于添加特定的业务逻辑方法.
@@ -25,11 +27,17 @@ type UserStore interface {
 }
 
 // 用户操作的附加方法.
-// nolint: iface
-type UserExpansion interface{}
+type UserExpansion interface {
+	// Restore 恢复已删除的用户, 用户不存在或没有被删除时返回gorm.ErrRecordNotFound.
+	Restore(ctx context.Context, userID string) error
+	// ListDeleted 返回删除时间早于before的用户.
+	ListDeleted(ctx context.Context, before time.Time) ([]*model.UserM, error)
+	// Purge 永久删除用户.
+	Purge(ctx context.Context, userID string) error
+}
 
 type userStore struct {
-	// store *datastore
+	store *datastore
 	*genericstore.Store[model.UserM]
 }
 
@@ -39,10 +47,50 @@ var _ UserStore = (*userStore)(nil)
 // newUserStore 创建 userStore 的实例.
 func newUserStore(store *datastore) *userStore {
 	return &userStore{
+		store: store,
 		Store: genericstore.NewStore[model.UserM](store, NewLogger()),
 	}
 }
 
+// Restore 清除用户的删除时间, Unscoped使查询包含已删除的用户.
+func (s *userStore) Restore(ctx context.Context, userID string) error {
+	result := s.store.DB(ctx).Unscoped().Model(&model.UserM{}).
+		Where("userID = ? AND deletedAt IS NOT NULL", userID).
+		Update("deletedAt", nil)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to restore user", "userID", userID)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListDeleted 查询删除时间早于before的用户, 用于清理过期的已删除用户.
+func (s *userStore) ListDeleted(ctx context.Context, before time.Time) ([]*model.UserM, error) {
+	var users []*model.UserM
+	err := s.store.DB(ctx).Unscoped().
+		Where("deletedAt IS NOT NULL AND deletedAt < ?", before).
+		Order("id").
+		Find(&users).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to list deleted users", "before", before)
+		return nil, err
+	}
+	return users, nil
+}
+
+// Purge 使用Unscoped永久删除用户, 在事务中调用时与用户博客和策略的删除一起提交或回滚.
+func (s *userStore) Purge(ctx context.Context, userID string) error {
+	err := s.store.DB(ctx).Unscoped().Where("userID = ?", userID).Delete(&model.UserM{}).Error
+	if err != nil {
+		NewLogger().Error(ctx, err, "Failed to purge user", "userID", userID)
+		return err
+	}
+	return nil
+}
+
 // func newUserStore(store *datastore) *userStore {
 // 	return &userStore{store: store}
 // }
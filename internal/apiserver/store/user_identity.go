@@ -0,0 +1,43 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// UserIdentityStore 定义了外部身份关联在 store 层所实现的方法.
+type UserIdentityStore interface {
+	Create(ctx context.Context, obj *model.UserIdentityM) error
+	Update(ctx context.Context, obj *model.UserIdentityM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.UserIdentityM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.UserIdentityM, error)
+
+	UserIdentityExpansion
+}
+
+// UserIdentityExpansion 定义了外部身份关联操作的附加方法.
+type UserIdentityExpansion interface{}
+
+// userIdentityStore 是 UserIdentityStore 接口的实现.
+type userIdentityStore struct {
+	*genericstore.Store[model.UserIdentityM]
+}
+
+// 确保 userIdentityStore 实现了 UserIdentityStore 接口.
+var _ UserIdentityStore = (*userIdentityStore)(nil)
+
+// newUserIdentityStore 创建 userIdentityStore 的实例.
+func newUserIdentityStore(store *datastore) *userIdentityStore {
+	return &userIdentityStore{
+		Store: genericstore.NewStore[model.UserIdentityM](store, NewLogger()),
+	}
+}
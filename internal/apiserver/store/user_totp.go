@@ -0,0 +1,62 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+
+	genericstore "github.com/onexstack/onexstack/pkg/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// UserTOTPStore 定义了用户两步验证在 store 层所实现的方法.
+type UserTOTPStore interface {
+	Create(ctx context.Context, obj *model.UserTOTPM) error
+	Update(ctx context.Context, obj *model.UserTOTPM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.UserTOTPM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.UserTOTPM, error)
+
+	UserTOTPExpansion
+}
+
+// UserTOTPExpansion 定义了用户两步验证操作的附加方法.
+type UserTOTPExpansion interface {
+	// UseStep 记录用户最近一次使用的一次性密码的时间步, 返回值表示该时间步是否未被使用过.
+	// 同一个一次性密码只能使用一次, 并发请求使用同一个密码时, 只有一个请求能够记录成功.
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+}
+
+// userTOTPStore 是 UserTOTPStore 接口的实现.
+type userTOTPStore struct {
+	store *datastore
+	*genericstore.Store[model.UserTOTPM]
+}
+
+// 确保 userTOTPStore 实现了 UserTOTPStore 接口.
+var _ UserTOTPStore = (*userTOTPStore)(nil)
+
+// newUserTOTPStore 创建 userTOTPStore 的实例.
+func newUserTOTPStore(store *datastore) *userTOTPStore {
+	return &userTOTPStore{
+		store: store,
+		Store: genericstore.NewStore[model.UserTOTPM](store, NewLogger()),
+	}
+}
+
+// UseStep 使用带条件的更新语句记录时间步, 只有大于已记录时间步的密码才能使用.
+func (s *userTOTPStore) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.UserTOTPM{}).
+		Where("userID = ? AND lastUsedStep < ?", userID, step).
+		Update("lastUsedStep", step)
+	if result.Error != nil {
+		NewLogger().Error(ctx, result.Error, "Failed to record used totp step", "userID", userID)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -10,12 +10,12 @@ package apiserver
 
 import (
 	"miniblog/internal/apiserver/biz"
+	"miniblog/internal/apiserver/pkg/permission"
 	"miniblog/internal/apiserver/pkg/validation"
 	"miniblog/internal/apiserver/store"
 	ginmw "miniblog/internal/pkg/middleware/gin"
 	"miniblog/internal/pkg/server"
-
-	"github.com/onexstack/onexstack/pkg/authz"
+	"miniblog/pkg/auth"
 
 	"github.com/google/wire"
 )
@@ -28,12 +28,25 @@ func InitializeWebServer(*Config) (server.Server, error) {
 		wire.Struct(new(ServerConfig), "*"),
 		wire.NewSet(store.ProviderSet, biz.ProviderSet),
 		ProvideDB,
+		ProvideDenylist,
+		ProvideLoginGuard,
+		ProvideSessionTracker,
+		ProvidePurger,
+		ProvideUserOptions,
+		ProvidePostOptions,
+		ProvideValidationOptions,
+		ProvideAuthzOptions,
 		validation.ProviderSet,
 		wire.NewSet(
 			wire.Struct(new(UserRetriever), "*"),
 			wire.Bind(new(ginmw.UserRetriever), new(*UserRetriever)),
 		),
-		authz.ProviderSet,
+		wire.NewSet(
+			wire.Struct(new(AccessTokenAuthenticator), "*"),
+			wire.Bind(new(ginmw.AccessTokenAuthenticator), new(*AccessTokenAuthenticator)),
+		),
+		auth.ProviderSet,
+		permission.ProviderSet,
 	)
 	return nil, nil
 }
@@ -12,11 +12,12 @@
 package apiserver
 
 import (
-	"github.com/onexstack/onexstack/pkg/authz"
 	"miniblog/internal/apiserver/biz"
+	"miniblog/internal/apiserver/pkg/permission"
 	"miniblog/internal/apiserver/pkg/validation"
 	"miniblog/internal/apiserver/store"
 	"miniblog/internal/pkg/server"
+	"miniblog/pkg/auth"
 )
 
 // Injectors from wire.go:
@@ -30,22 +31,41 @@ func InitializeWebServer(config *Config) (server.Server, error) {
 		return nil, err
 	}
 	datastore := store.NewStore(db)
-	v := authz.DefaultOptions()
-	authzAuthz, err := authz.NewAuthz(db, v...)
+	v, err := ProvideAuthzOptions(config, db)
 	if err != nil {
 		return nil, err
 	}
-	bizBiz := biz.NewBiz(datastore, authzAuthz)
-	validator := validation.New(datastore)
+	authz, err := auth.NewAuthz(db, v...)
+	if err != nil {
+		return nil, err
+	}
+	denylist := ProvideDenylist(config, datastore)
+	guard := ProvideLoginGuard(config, datastore)
+	options := ProvideUserOptions(config)
+	postOptions := ProvidePostOptions(config)
+	bizBiz := biz.NewBiz(datastore, authz, denylist, guard, options, postOptions)
+	registry := permission.NewRegistry()
+	validationOptions := ProvideValidationOptions(config)
+	validator := validation.New(datastore, registry, validationOptions)
 	userRetriever := &UserRetriever{
 		store: datastore,
 	}
+	accessTokenAuthenticator := &AccessTokenAuthenticator{
+		store: datastore,
+	}
+	tracker := ProvideSessionTracker(datastore)
+	purger := ProvidePurger(config, datastore, authz)
 	serverConfig := &ServerConfig{
-		cfg:       config,
-		biz:       bizBiz,
-		val:       validator,
-		retriever: userRetriever,
-		authz:     authzAuthz,
+		cfg:          config,
+		biz:          bizBiz,
+		val:          validator,
+		retriever:    userRetriever,
+		authz:        authz,
+		permissions:  registry,
+		denylist:     denylist,
+		accessTokens: accessTokenAuthenticator,
+		sessions:     tracker,
+		purger:       purger,
 	}
 	serverServer, err := NewWebServer(string2, serverConfig)
 	if err != nil {
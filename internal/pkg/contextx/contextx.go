@@ -5,19 +5,40 @@
 
 package contextx
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// 用户id的上下文键.
 	userIDKey struct{}
 
+	// 模拟登录时实际操作者的用户id的上下文键.
+	actorIDKey struct{}
+
 	// 用户name的上下文键.
 	usernameKey struct{}
 
+	// 用户所属租户的上下文键.
+	tenantIDKey struct{}
+
 	// 访问令牌的上下文键.
 	accessTokenKey struct{}
+	// 访问令牌唯一标识的上下文键.
+	tokenIDKey struct{}
+	// 访问令牌过期时间的上下文键.
+	tokenExpireAtKey struct{}
+	// 个人访问令牌允许的动作的上下文键.
+	tokenActionsKey struct{}
 	// 请求id的上下文键.
 	requestIDKey struct{}
+	// 客户端IP的上下文键.
+	clientIPKey struct{}
+	// 客户端User-Agent的上下文键.
+	userAgentKey struct{}
+	// 会话id的上下文键.
+	sessionIDKey struct{}
 )
 
 // 将用户ID存放到上下文中.
@@ -32,6 +53,30 @@ func UserID(ctx context.Context) string {
 	return userID
 }
 
+// 将模拟登录的实际操作者的用户ID存放到上下文中.
+func WithActorID(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorIDKey{}, actorID)
+}
+
+// 从上下文中提取模拟登录的实际操作者的用户ID, 不是模拟登录时返回空字符串.
+func ActorID(ctx context.Context) string {
+	actorID, _ := ctx.Value(actorIDKey{}).(string)
+	return actorID
+}
+
+// 判断当前请求是否来自模拟登录的会话.
+func Impersonated(ctx context.Context) bool {
+	return ActorID(ctx) != ""
+}
+
+// 从上下文中提取实际发起请求的用户ID, 模拟登录时为实际操作者, 否则为当前用户.
+func RealUserID(ctx context.Context) string {
+	if actorID := ActorID(ctx); actorID != "" {
+		return actorID
+	}
+	return UserID(ctx)
+}
+
 // 将用户名放到上下文中.
 func WithUsername(ctx context.Context, username string) context.Context {
 	return context.WithValue(ctx, usernameKey{}, username)
@@ -43,6 +88,17 @@ func Username(ctx context.Context) string {
 	return username
 }
 
+// 将用户所属的租户ID存放到上下文中.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// 从上下文中提取用户所属的租户ID, 未认证的请求返回空字符串.
+func TenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDKey{}).(string)
+	return tenantID
+}
+
 // 将accessToken放到上下文中.
 func WithAccessToken(ctx context.Context, accessToken string) context.Context {
 	return context.WithValue(ctx, accessTokenKey{}, accessToken)
@@ -54,6 +110,39 @@ func AccessToken(ctx context.Context) string {
 	return accessToken
 }
 
+// 将访问令牌的唯一标识(jti)放到上下文中.
+func WithTokenID(ctx context.Context, tokenID string) context.Context {
+	return context.WithValue(ctx, tokenIDKey{}, tokenID)
+}
+
+// 从上下文中读取访问令牌的唯一标识(jti).
+func TokenID(ctx context.Context) string {
+	tokenID, _ := ctx.Value(tokenIDKey{}).(string)
+	return tokenID
+}
+
+// 将访问令牌的过期时间放到上下文中.
+func WithTokenExpireAt(ctx context.Context, expireAt time.Time) context.Context {
+	return context.WithValue(ctx, tokenExpireAtKey{}, expireAt)
+}
+
+// 从上下文中读取访问令牌的过期时间.
+func TokenExpireAt(ctx context.Context) time.Time {
+	expireAt, _ := ctx.Value(tokenExpireAtKey{}).(time.Time)
+	return expireAt
+}
+
+// 将个人访问令牌允许的casbin动作放到上下文中.
+func WithTokenActions(ctx context.Context, actions []string) context.Context {
+	return context.WithValue(ctx, tokenActionsKey{}, actions)
+}
+
+// 从上下文中读取个人访问令牌允许的casbin动作, 使用JWT认证的请求返回nil, 表示不限制动作.
+func TokenActions(ctx context.Context) []string {
+	actions, _ := ctx.Value(tokenActionsKey{}).([]string)
+	return actions
+}
+
 // 将请求ID存放到上下文中.
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey{}, requestID)
@@ -64,3 +153,36 @@ func RequestID(ctx context.Context) string {
 	requestID, _ := ctx.Value(requestIDKey{}).(string)
 	return requestID
 }
+
+// 将客户端IP存放到上下文中.
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, clientIP)
+}
+
+// 从上下文中提取客户端IP.
+func ClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey{}).(string)
+	return clientIP
+}
+
+// 将客户端User-Agent存放到上下文中.
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentKey{}, userAgent)
+}
+
+// 从上下文中提取客户端User-Agent.
+func UserAgent(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey{}).(string)
+	return userAgent
+}
+
+// 将当前访问令牌所属的会话ID存放到上下文中.
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, sessionID)
+}
+
+// 从上下文中提取当前访问令牌所属的会话ID.
+func SessionID(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionIDKey{}).(string)
+	return sessionID
+}
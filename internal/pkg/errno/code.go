@@ -44,6 +44,42 @@ var (
 	// ErrTokenInvalid 表示 JWT Token 格式无效.
 	ErrTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalid", Message: "Token was invalid."}
 
+	// ErrTokenExpired 表示 JWT Token 已过期.
+	ErrTokenExpired = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenExpired", Message: "Token has expired."}
+
+	// ErrTokenNotValidYet 表示 JWT Token 尚未生效.
+	ErrTokenNotValidYet = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenNotValidYet", Message: "Token is not valid yet."}
+
+	// ErrTokenInvalidAudience 表示 JWT Token 的接收方不是本服务.
+	ErrTokenInvalidAudience = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalidAudience", Message: "Token audience was invalid."}
+
+	// ErrTokenRevoked 表示 JWT Token 已被吊销.
+	ErrTokenRevoked = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenRevoked", Message: "Token has been revoked."}
+
+	// ErrRefreshTokenInvalid 表示刷新令牌不存在或格式无效.
+	ErrRefreshTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.RefreshTokenInvalid", Message: "Refresh token was invalid."}
+
+	// ErrRefreshTokenExpired 表示刷新令牌已过期.
+	ErrRefreshTokenExpired = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.RefreshTokenExpired", Message: "Refresh token has expired."}
+
+	// ErrRefreshTokenReused 表示已使用过的刷新令牌被再次使用, 整个令牌族已被吊销.
+	ErrRefreshTokenReused = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.RefreshTokenReused", Message: "Refresh token has already been used."}
+
+	// ErrChallengeTokenInvalid 表示两步登录的挑战令牌无效, 已过期或已被使用.
+	ErrChallengeTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.ChallengeTokenInvalid", Message: "Challenge token was invalid."}
+
+	// ErrAccessTokenInvalid 表示个人访问令牌不存在或格式无效.
+	ErrAccessTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.AccessTokenInvalid", Message: "Access token was invalid."}
+
+	// ErrAccessTokenExpired 表示个人访问令牌已过期.
+	ErrAccessTokenExpired = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.AccessTokenExpired", Message: "Access token has expired."}
+
+	// ErrAccessTokenLegacy 表示个人访问令牌仍按HTTP方法限制动作, 需要重新创建按权限限制的令牌.
+	ErrAccessTokenLegacy = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.AccessTokenLegacy", Message: "Access token is scoped by HTTP methods which are no longer supported, please create a new access token."}
+
+	// ErrAccessTokenNotFound 表示未找到指定的个人访问令牌.
+	ErrAccessTokenNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.AccessTokenNotFound", Message: "Access token not found."}
+
 	// ErrDBRead 表示数据库读取失败.
 	ErrDBRead = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBRead", Message: "Database read failure."}
 
@@ -0,0 +1,22 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package errno
+
+import (
+	"miniblog/internal/pkg/errorsx"
+	"net/http"
+)
+
+var (
+	// ErrPolicyInvalid 表示授权策略或角色继承规则不符合授权模型的定义.
+	ErrPolicyInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.PolicyInvalid", Message: "Policy does not match the authorization model."}
+
+	// ErrPolicyAlreadyExists 表示授权策略或角色继承规则已经存在.
+	ErrPolicyAlreadyExists = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.PolicyAlreadyExists", Message: "Policy already exists."}
+
+	// ErrPolicyNotFound 表示未找到指定的授权策略或角色继承规则.
+	ErrPolicyNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PolicyNotFound", Message: "Policy not found."}
+)
@@ -0,0 +1,26 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package errno
+
+import (
+	"errors"
+	"miniblog/internal/pkg/errorsx"
+	"miniblog/pkg/token"
+)
+
+// FromTokenError 将 token 包返回的解析错误转换为对应的错误码, 便于客户端区分令牌过期等情况.
+func FromTokenError(err error) *errorsx.ErrorX {
+	switch {
+	case errors.Is(err, token.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, token.ErrTokenNotValidYet):
+		return ErrTokenNotValidYet
+	case errors.Is(err, token.ErrTokenInvalidAudience):
+		return ErrTokenInvalidAudience
+	default:
+		return ErrTokenInvalid.WithMessage("%s", err.Error())
+	}
+}
@@ -25,9 +25,57 @@ var (
 		Message: "Password is incorrect.",
 	}
 
+	// ErrInvalidCredentials 表示用户名或密码错误, 登录时不区分用户不存在和密码错误, 防止用户名被枚举.
+	ErrInvalidCredentials = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.InvalidCredentials", Message: "Username or password is incorrect."}
+
+	// ErrTooManyLoginAttempts 表示登录失败次数过多, 暂时不允许继续尝试.
+	ErrTooManyLoginAttempts = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.TooManyLoginAttempts", Message: "Too many failed login attempts, please try again later."}
+
 	// ErrUserAlreadyExists 表示用户已存在.
 	ErrUserAlreadyExists = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.UserAlreadyExists", Message: "User already exists."}
 
 	// ErrUserNotFound 表示未找到指定用户.
 	ErrUserNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.UserNotFound", Message: "User not found."}
+
+	// ErrUserDisabled 表示用户已被管理员禁用, 不能登录和访问接口.
+	ErrUserDisabled = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.UserDisabled", Message: "User account is disabled."}
+
+	// ErrUserBanned 表示用户已被管理员封禁, 封禁期间不能登录和访问接口.
+	ErrUserBanned = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.UserBanned", Message: "User account is banned."}
+
+	// ErrTOTPAlreadyEnabled 表示用户已经开启了两步验证.
+	ErrTOTPAlreadyEnabled = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.TOTPAlreadyEnabled", Message: "Two-factor authentication is already enabled."}
+
+	// ErrTOTPNotEnabled 表示用户没有绑定或没有开启两步验证.
+	ErrTOTPNotEnabled = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "FailedPrecondition.TOTPNotEnabled", Message: "Two-factor authentication is not enabled."}
+
+	// ErrTOTPCodeInvalid 表示一次性密码或恢复码错误, 或者已经被使用过.
+	ErrTOTPCodeInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TOTPCodeInvalid", Message: "Verification code is incorrect."}
+
+	// ErrResetTokenInvalid 表示密码重置令牌不存在, 已过期或者已经被使用过.
+	ErrResetTokenInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.ResetTokenInvalid", Message: "Password reset token is invalid or has expired."}
+
+	// ErrVerificationTokenInvalid 表示电子邮箱验证令牌不存在, 已过期, 已经被使用过, 或者对应的邮箱已经被修改.
+	ErrVerificationTokenInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.VerificationTokenInvalid", Message: "Email verification token is invalid or has expired."}
+
+	// ErrEmailAlreadyVerified 表示用户的电子邮箱已经验证过.
+	ErrEmailAlreadyVerified = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "FailedPrecondition.EmailAlreadyVerified", Message: "Email is already verified."}
+
+	// ErrEmailNotVerified 表示用户的电子邮箱尚未验证, 不允许执行当前操作.
+	ErrEmailNotVerified = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.EmailNotVerified", Message: "Email must be verified before performing this action."}
+
+	// ErrSessionNotFound 表示未找到指定的登录会话, 或者会话已经失效.
+	ErrSessionNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.SessionNotFound", Message: "Session not found."}
+
+	// ErrImpersonationForbidden 表示模拟登录的会话不允许执行当前操作, 例如修改密码和删除用户.
+	ErrImpersonationForbidden = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.ImpersonationForbidden", Message: "This action is not allowed while impersonating a user."}
+
+	// ErrOIDCProviderNotFound 表示请求的外部身份提供方没有配置.
+	ErrOIDCProviderNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.OIDCProviderNotFound", Message: "Identity provider not found."}
+
+	// ErrOIDCStateInvalid 表示OIDC回调中的state参数不存在, 已过期或者已经被使用过.
+	ErrOIDCStateInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.OIDCStateInvalid", Message: "Login state is invalid or has expired."}
+
+	// ErrOIDCLoginFailed 表示无法通过外部身份提供方完成认证, 例如授权码无效或者ID Token校验失败.
+	ErrOIDCLoginFailed = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.OIDCLoginFailed", Message: "Failed to login with the identity provider."}
 )
@@ -22,16 +22,77 @@ const (
 	// 定义上下文中的键, 代表请求用户ID, UserID整个用户生命周期唯一.
 	XUserID = "x-user-id"
 
+	// XActorID 定义上下文中的键, 代表模拟登录时实际操作的用户ID.
+	XActorID = "x-actor-id"
+
+	// XTenantID 定义上下文中的键, 代表请求用户所属的租户ID.
+	XTenantID = "x-tenant-id"
+
 	// XUsername 用来定义上下文的键，代表请求用户名.
 	XUsername = "x-username"
+
+	// XForwardedFor 定义代理转发请求时携带客户端地址的键, grpc-gateway 会将其写入 gRPC 元数据.
+	XForwardedFor = "x-forwarded-for"
+
+	// XUserAgent 定义 gRPC 客户端的 User-Agent 键.
+	XUserAgent = "user-agent"
+
+	// XGatewayUserAgent 定义 grpc-gateway 转发的 HTTP 客户端 User-Agent 键.
+	XGatewayUserAgent = "grpcgateway-user-agent"
+
+	// ContentDisposition 定义附件响应头的键, grpc-gateway 会将同名的 gRPC 元数据作为 HTTP 响应头返回.
+	ContentDisposition = "content-disposition"
 )
 
 const (
 	// Admin 用户名.
 	AdminUsername = "root"
 
-	// MaxErrGroupConcurrency 定义了 errgroup 的最大并发任务数量.
-	// 用于限制 errgroup 中同时执行的 Goroutine 数量, 从而防止资源耗尽，提升程序的稳定性.
-	// 根据场景需求, 可以调整该值大小.
-	MaxErrGroupConcurrency = 100
+	// DefaultTenant 是没有指定租户时使用的默认租户, 升级前已有的用户和博客都属于该租户.
+	DefaultTenant = "default"
 )
+
+// 定义用户列表支持的排序字段, 排序方向和用户状态.
+const (
+	// UserSortByCreatedAt 按用户创建时间排序.
+	UserSortByCreatedAt = "createdAt"
+	// UserSortByUsername 按用户名排序.
+	UserSortByUsername = "username"
+	// UserSortByPostCount 按用户的博客数排序.
+	UserSortByPostCount = "postCount"
+
+	// SortOrderAsc 表示升序排列.
+	SortOrderAsc = "asc"
+	// SortOrderDesc 表示降序排列.
+	SortOrderDesc = "desc"
+
+	// UserStatusActive 表示正常状态的用户.
+	UserStatusActive = "active"
+	// UserStatusDisabled 表示被管理员禁用的用户.
+	UserStatusDisabled = "disabled"
+	// UserStatusBanned 表示被管理员封禁的用户, 封禁可以设置截止时间.
+	UserStatusBanned = "banned"
+
+	// DataExportFormatJSON 表示以单个JSON文档导出个人数据.
+	DataExportFormatJSON = "json"
+	// DataExportFormatZIP 表示以zip归档导出个人数据, 每类数据一个文件.
+	DataExportFormatZIP = "zip"
+)
+
+// ImpersonationForbiddenPermissions 是模拟登录的会话不允许使用的权限, 由授权中间件统一拒绝.
+// 包括创建访问令牌, 修改用户信息和密码, 开启和关闭两步验证, 撤销会话和令牌, 删除用户以及再次模拟登录.
+// 申请重置密码的接口不需要认证, 没有声明权限, 由业务层拒绝模拟登录的请求.
+var ImpersonationForbiddenPermissions = []string{
+	"access-token:create",
+	"access-token:revoke",
+	"session:revoke",
+	"totp:confirm",
+	"totp:disable",
+	"totp:enroll",
+	"user-session:revoke",
+	"user:change-password",
+	"user:delete",
+	"user:impersonate",
+	"user:revoke-tokens",
+	"user:update",
+}
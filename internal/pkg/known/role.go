@@ -11,3 +11,6 @@ const (
 	// Role for administrators.
 	RoleAdmin = "role::admin"
 )
+
+// Prefix of all role names, used to tell roles apart from user IDs in casbin rules.
+const RolePrefix = "role::"
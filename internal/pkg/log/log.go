@@ -209,6 +209,8 @@ func (l *zapLogger) W(ctx context.Context) Logger {
 	contextExtractors := map[string]func(context.Context) string{
 		known.XRequestID: contextx.RequestID,
 		known.XUserID:    contextx.UserID,
+		known.XActorID:   contextx.ActorID,
+		known.XTenantID:  contextx.TenantID,
 	}
 
 	// 变量映射, 从context中提取值并添加到日志中
@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // 用于测试自定义的Logger
@@ -60,6 +61,24 @@ func TestSync(t *testing.T) {
 	}, "Sync should not panic")
 }
 
+func TestW(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := &zapLogger{z: zap.New(core)}
+
+	ctx := contextx.WithRequestID(context.Background(), "request-id-12345")
+	ctx = contextx.WithUserID(ctx, "user-id-67890")
+	logger.W(ctx).Infow("normal request")
+
+	// 模拟登录的请求同时记录被模拟的用户和实际操作者
+	ctx = contextx.WithActorID(ctx, "user-id-00000")
+	logger.W(ctx).Infow("impersonated request")
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, map[string]any{"x-request-id": "request-id-12345", "x-user-id": "user-id-67890"}, entries[0].ContextMap())
+	assert.Equal(t, map[string]any{"x-request-id": "request-id-12345", "x-user-id": "user-id-67890", "x-actor-id": "user-id-00000"}, entries[1].ContextMap())
+}
+
 // 性能测试用例
 func BenchmarkZapLoggerW(b *testing.B) {
 	// 创建一个 zapLogger 实例（使用 zap.NewNop() 模拟 logger）
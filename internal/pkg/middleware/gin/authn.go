@@ -7,43 +7,137 @@ package gin
 
 import (
 	"context"
+	"errors"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
 	"miniblog/internal/pkg/log"
-
-	"github.com/onexstack/onexstack/pkg/token"
+	"miniblog/pkg/token"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/onexstack/onexstack/pkg/core"
 )
 
 type UserRetriever interface {
-	// GetUser 根据用户ID获取用户信息
+	// GetUser 根据用户ID获取用户信息, 被禁用或封禁的用户返回errno.ErrUserDisabled或errno.ErrUserBanned
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
 }
 
-func AuthnMiddleware(retriever UserRetriever) gin.HandlerFunc {
+// 判断令牌是否已被吊销的接口.
+type TokenDenylist interface {
+	// IsRevoked 根据令牌的jti和签发时间判断令牌是否已被吊销
+	IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error)
+}
+
+// 记录登录会话活跃时间的接口.
+type SessionTracker interface {
+	// Touch 记录会话在seenAt时刻处于活跃状态
+	Touch(sessionID string, seenAt time.Time)
+}
+
+// 校验个人访问令牌的接口.
+type AccessTokenAuthenticator interface {
+	// AuthenticateAccessToken 校验个人访问令牌, 返回令牌记录并更新最后使用时间
+	AuthenticateAccessToken(ctx context.Context, tokenString string) (*model.AccessTokenM, error)
+}
+
+func AuthnMiddleware(retriever UserRetriever, denylist TokenDenylist, accessTokens AccessTokenAuthenticator, sessions SessionTracker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		userID, err := token.ParseRequest(ctx)
+		tokenString, err := token.RequestToken(ctx)
 		if err != nil {
-			core.WriteResponse(ctx, nil, errno.ErrTokenInvalid.WithMessage(err.Error()))
+			core.WriteResponse(ctx, nil, errno.FromTokenError(err))
 			// 如果授权失败, abort会阻止调用待处理的处理程序
 			ctx.Abort()
 			return
 		}
 
+		var (
+			userID  string
+			claims  *token.Claims
+			actions []string
+		)
+		if token.IsPersonal(tokenString) {
+			// 个人访问令牌只允许执行创建时指定的动作
+			pat, err := accessTokens.AuthenticateAccessToken(ctx, tokenString)
+			if err != nil {
+				core.WriteResponse(ctx, nil, err)
+				ctx.Abort()
+				return
+			}
+			userID, actions = pat.UserID, strings.Split(pat.Actions, ",")
+		} else {
+			claims, err = token.Verify(tokenString)
+			if err != nil {
+				core.WriteResponse(ctx, nil, errno.FromTokenError(err))
+				ctx.Abort()
+				return
+			}
+			userID = claims.Identity
+
+			revoked, err := denylist.IsRevoked(ctx, userID, claims.ID, claims.IssuedAt)
+			// 会话被吊销后, 该会话中签发的访问令牌全部失效
+			if err == nil && !revoked && claims.SessionID != "" {
+				revoked, err = denylist.IsRevoked(ctx, userID, claims.SessionID, claims.IssuedAt)
+			}
+			// 实际操作者的令牌被全部吊销后, 其签发的模拟登录令牌同样失效
+			if err == nil && !revoked && claims.ActorID != "" {
+				revoked, err = denylist.IsRevoked(ctx, claims.ActorID, claims.ID, claims.IssuedAt)
+			}
+			if err != nil {
+				log.Errorw("Failed to check token denylist", "err", err)
+				core.WriteResponse(ctx, nil, errno.ErrInternal)
+				ctx.Abort()
+				return
+			}
+			if revoked {
+				core.WriteResponse(ctx, nil, errno.ErrTokenRevoked)
+				ctx.Abort()
+				return
+			}
+		}
+
 		log.Debugw("Token parsing successful", "userID", userID)
 
 		user, err := retriever.GetUser(ctx, userID)
 		if err != nil {
-			core.WriteResponse(ctx, nil, errno.ErrUnauthenticated.WithMessage(err.Error()))
+			// 被禁用或封禁的用户返回对应的错误, 便于客户端提示用户
+			if errors.Is(err, errno.ErrUserDisabled) || errors.Is(err, errno.ErrUserBanned) {
+				core.WriteResponse(ctx, nil, err)
+				ctx.Abort()
+				return
+			}
+			core.WriteResponse(ctx, nil, errno.ErrUnauthenticated.WithMessage("%s", err.Error()))
+			ctx.Abort()
+			return
+		}
+
+		// 令牌中的租户必须与用户所属的租户一致, 没有租户声明的令牌(例如个人访问令牌)使用用户所属的租户
+		if claims != nil && claims.TenantID != "" && claims.TenantID != user.TenantID {
+			core.WriteResponse(ctx, nil, errno.ErrUnauthenticated.WithMessage("token tenant does not match the user"))
 			ctx.Abort()
 			return
 		}
 
 		c := contextx.WithUserID(ctx.Request.Context(), userID)
 		c = contextx.WithUsername(c, user.Username)
+		c = contextx.WithTenantID(c, user.TenantID)
+		if claims != nil {
+			c = contextx.WithTokenID(c, claims.ID)
+			c = contextx.WithTokenExpireAt(c, claims.ExpiresAt)
+			if claims.ActorID != "" {
+				c = contextx.WithActorID(c, claims.ActorID)
+				// 模拟登录会话中的每个请求都记录审计日志
+				log.W(c).Infow("Handling impersonated request", "method", ctx.Request.Method, "path", ctx.Request.URL.Path)
+			}
+			if claims.SessionID != "" {
+				c = contextx.WithSessionID(c, claims.SessionID)
+				sessions.Touch(claims.SessionID, time.Now())
+			}
+		} else {
+			c = contextx.WithTokenActions(c, actions)
+		}
 		ctx.Request = ctx.Request.WithContext(c)
 
 		ctx.Next()
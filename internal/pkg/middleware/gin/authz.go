@@ -8,7 +8,10 @@ package gin
 import (
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -17,26 +20,52 @@ import (
 
 // Authorizer 用于定义授权接口的实现.
 type Authorizer interface {
-	Authorize(subject, object, action string) (bool, error)
+	Authorize(subject, domain, object, action string) (bool, error)
+}
+
+// PermissionResolver 用于将 HTTP 路由解析为与传输协议无关的权限名称, 权限格式为 resource:verb.
+type PermissionResolver interface {
+	RoutePermission(method string, route string) (string, bool)
 }
 
 // AuthzMiddleware 是一个 Gin 中间件, 用于进行请求授权.
-func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
+// 请求的路由先被解析为权限, 再使用权限中的资源和动作进行授权, 没有声明权限的路由一律拒绝.
+func AuthzMiddleware(authorizer Authorizer, resolver PermissionResolver) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		subject := contextx.UserID(c.Request.Context())
-		object := c.Request.URL.Path
-		action := c.Request.Method
+		subject, domain := contextx.UserID(c.Request.Context()), contextx.TenantID(c.Request.Context())
+
+		permission, ok := resolver.RoutePermission(c.Request.Method, c.FullPath())
+		if !ok {
+			core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage("access denied: no permission declared for %s %s", c.Request.Method, c.FullPath()))
+			c.Abort()
+			return
+		}
+		object, action, _ := strings.Cut(permission, ":")
 
 		// 记录授权上下文信息
-		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
+		log.Debugw("Build authorize context", "subject", subject, "domain", domain, "permission", permission)
+
+		// 模拟登录只用于复现问题, 不允许修改被模拟用户的凭据和安全设置
+		if contextx.Impersonated(c.Request.Context()) && slices.Contains(known.ImpersonationForbiddenPermissions, permission) {
+			core.WriteResponse(c, nil, errno.ErrImpersonationForbidden.WithMessage("access denied: permission %s is not allowed while impersonating", permission))
+			c.Abort()
+			return
+		}
+
+		// 个人访问令牌只能使用创建时指定的权限
+		if actions := contextx.TokenActions(c.Request.Context()); actions != nil && !slices.Contains(actions, permission) {
+			core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage("access denied: permission %s is not allowed by the access token", permission))
+			c.Abort()
+			return
+		}
 
 		// 调用授权接口进行验证
-		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+		if allowed, err := authorizer.Authorize(subject, domain, object, action); err != nil || !allowed {
 			core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage(
-				"access denied: subject=%s, object=%s, action=%s, reason=%v",
+				"access denied: subject=%s, domain=%s, permission=%s, reason=%v",
 				subject,
-				object,
-				action,
+				domain,
+				permission,
 				err,
 			))
 			c.Abort()
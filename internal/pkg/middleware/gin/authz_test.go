@@ -0,0 +1,115 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package gin
+
+import (
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/known"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// allowAll 允许所有请求, 只验证个人访问令牌的权限限制.
+type allowAll struct{}
+
+func (allowAll) Authorize(subject, domain, object, action string) (bool, error) {
+	return true, nil
+}
+
+// routePermissions 将HTTP方法和路由映射为权限.
+type routePermissions map[string]string
+
+func (m routePermissions) RoutePermission(method string, route string) (string, bool) {
+	permission, ok := m[method+" "+route]
+	return permission, ok
+}
+
+func TestAuthzMiddlewareAccessTokenPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newEngine := func(actions []string) *gin.Engine {
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			if actions != nil {
+				c.Request = c.Request.WithContext(contextx.WithTokenActions(c.Request.Context(), actions))
+			}
+		})
+		engine.Use(AuthzMiddleware(allowAll{}, routePermissions{
+			"GET /v1/posts":            "post:list",
+			"DELETE /v1/users/:userID": "user:delete",
+		}))
+		ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+		engine.GET("/v1/posts", ok)
+		engine.DELETE("/v1/users/:userID", ok)
+		engine.GET("/v1/unknown", ok)
+		return engine
+	}
+	serve := func(engine *gin.Engine, method string, path string) int {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w.Code
+	}
+
+	engine := newEngine([]string{"post:list"})
+	assert.Equal(t, http.StatusOK, serve(engine, http.MethodGet, "/v1/posts"))
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodDelete, "/v1/users/user-000001"))
+
+	// 旧的传输协议动作不再授予任何权限
+	engine = newEngine([]string{"GET"})
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodGet, "/v1/posts"))
+
+	// 不是个人访问令牌的请求不受限制, 没有声明权限的路由一律拒绝
+	engine = newEngine(nil)
+	assert.Equal(t, http.StatusOK, serve(engine, http.MethodDelete, "/v1/users/user-000001"))
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodGet, "/v1/unknown"))
+}
+
+// 返回测试中权限对应的路由, 例如 user:delete 对应 /v1/user/delete.
+func permissionPath(permission string) string {
+	return "/v1/" + strings.ReplaceAll(permission, ":", "/")
+}
+
+func TestAuthzMiddlewareImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	routes := routePermissions{"GET /v1/posts": "post:list"}
+	for _, permission := range known.ImpersonationForbiddenPermissions {
+		routes["POST "+permissionPath(permission)] = permission
+	}
+
+	newEngine := func(actorID string) *gin.Engine {
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			if actorID != "" {
+				c.Request = c.Request.WithContext(contextx.WithActorID(c.Request.Context(), actorID))
+			}
+		})
+		engine.Use(AuthzMiddleware(allowAll{}, routes))
+		for route := range routes {
+			method, path, _ := strings.Cut(route, " ")
+			engine.Handle(method, path, func(c *gin.Context) { c.Status(http.StatusOK) })
+		}
+		return engine
+	}
+	serve := func(engine *gin.Engine, method string, path string) int {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w.Code
+	}
+
+	// 模拟登录的会话不能修改凭据和安全设置, 其他权限不受影响
+	impersonated, normal := newEngine("user-000001"), newEngine("")
+	for _, permission := range known.ImpersonationForbiddenPermissions {
+		assert.Equal(t, http.StatusForbidden, serve(impersonated, http.MethodPost, permissionPath(permission)), permission)
+		assert.Equal(t, http.StatusOK, serve(normal, http.MethodPost, permissionPath(permission)), permission)
+	}
+	assert.Equal(t, http.StatusOK, serve(impersonated, http.MethodGet, "/v1/posts"))
+}
@@ -21,10 +21,16 @@ func AuthnBypasswMiddleware() gin.HandlerFunc {
 			userID = val
 		}
 
-		log.Debugw("Simulated authentication successful", "userID", userID)
+		tenantID := known.DefaultTenant
+		if val := c.GetHeader(known.XTenantID); val != "" {
+			tenantID = val
+		}
+
+		log.Debugw("Simulated authentication successful", "userID", userID, "tenantID", tenantID)
 
-		// 将用户ID和用户名注入到上下文中
+		// 将用户ID和租户ID注入到上下文中
 		ctx := contextx.WithUserID(c.Request.Context(), userID)
+		ctx = contextx.WithTenantID(ctx, tenantID)
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
@@ -0,0 +1,23 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package gin
+
+import (
+	"miniblog/internal/pkg/contextx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gin中间件, 用于将客户端IP和User-Agent保存到请求的上下文中, 供登录限流和会话记录等逻辑使用.
+func ClientInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := contextx.WithClientIP(c.Request.Context(), c.ClientIP())
+		ctx = contextx.WithUserAgent(ctx, c.Request.UserAgent())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package gin
+
+import (
+	"miniblog/internal/pkg/contextx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientInfoMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	clientIP := func(trustedProxies []string, remoteAddr string) string {
+		engine := gin.New()
+		require.NoError(t, engine.SetTrustedProxies(trustedProxies))
+		engine.Use(ClientInfoMiddleware())
+
+		var ip string
+		engine.GET("/", func(c *gin.Context) {
+			ip = contextx.ClientIP(c.Request.Context())
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-For", "203.0.113.1, 198.51.100.1")
+		engine.ServeHTTP(httptest.NewRecorder(), r)
+		return ip
+	}
+
+	// 默认不信任任何代理, 忽略客户端伪造的X-Forwarded-For
+	assert.Equal(t, "192.0.2.10", clientIP(nil, "192.0.2.10:40000"))
+
+	// 来自可信代理的请求使用代理追加的客户端地址
+	assert.Equal(t, "198.51.100.1", clientIP([]string{"192.0.2.0/24"}, "192.0.2.10:40000"))
+	assert.Equal(t, "192.0.2.20", clientIP([]string{"192.0.2.10"}, "192.0.2.20:40000"))
+}
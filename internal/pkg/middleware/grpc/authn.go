@@ -7,30 +7,90 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
 	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
+	"miniblog/pkg/token"
+	"strings"
+	"time"
 
-	"github.com/onexstack/onexstack/pkg/token"
 	"google.golang.org/grpc"
 )
 
 // 根据用户名获取用户信息的接口.
 type UserRetriever interface {
-	// 根据用户ID获取用户信息
+	// 根据用户ID获取用户信息, 被禁用或封禁的用户返回errno.ErrUserDisabled或errno.ErrUserBanned
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
 }
 
+// 判断令牌是否已被吊销的接口.
+type TokenDenylist interface {
+	// 根据令牌的jti和签发时间判断令牌是否已被吊销
+	IsRevoked(ctx context.Context, userID string, jti string, issuedAt time.Time) (bool, error)
+}
+
+// 记录登录会话活跃时间的接口.
+type SessionTracker interface {
+	// 记录会话在seenAt时刻处于活跃状态
+	Touch(sessionID string, seenAt time.Time)
+}
+
+// 校验个人访问令牌的接口.
+type AccessTokenAuthenticator interface {
+	// 校验个人访问令牌, 返回令牌记录并更新最后使用时间
+	AuthenticateAccessToken(ctx context.Context, tokenString string) (*model.AccessTokenM, error)
+}
+
 // 一个grpc拦截器, 用于认证.
-func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		// 解析JWT
-		userID, err := token.ParseRequest(ctx)
+func AuthnInterceptor(retriever UserRetriever, denylist TokenDenylist, accessTokens AccessTokenAuthenticator, sessions SessionTracker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		tokenString, err := token.RequestToken(ctx)
 		if err != nil {
 			log.Errorw("Failed to parse request", "err", err)
-			return nil, errno.ErrTokenInvalid.WithMessage(err.Error())
+			return nil, errno.FromTokenError(err)
+		}
+
+		var (
+			userID  string
+			claims  *token.Claims
+			actions []string
+		)
+		if token.IsPersonal(tokenString) {
+			// 个人访问令牌只允许执行创建时指定的动作
+			pat, err := accessTokens.AuthenticateAccessToken(ctx, tokenString)
+			if err != nil {
+				return nil, err
+			}
+			userID, actions = pat.UserID, strings.Split(pat.Actions, ",")
+		} else {
+			// 解析JWT
+			claims, err = token.Verify(tokenString)
+			if err != nil {
+				log.Errorw("Failed to parse request", "err", err)
+				return nil, errno.FromTokenError(err)
+			}
+			userID = claims.Identity
+
+			// 检查令牌是否已被吊销
+			revoked, err := denylist.IsRevoked(ctx, userID, claims.ID, claims.IssuedAt)
+			// 会话被吊销后, 该会话中签发的访问令牌全部失效
+			if err == nil && !revoked && claims.SessionID != "" {
+				revoked, err = denylist.IsRevoked(ctx, userID, claims.SessionID, claims.IssuedAt)
+			}
+			// 实际操作者的令牌被全部吊销后, 其签发的模拟登录令牌同样失效
+			if err == nil && !revoked && claims.ActorID != "" {
+				revoked, err = denylist.IsRevoked(ctx, claims.ActorID, claims.ID, claims.IssuedAt)
+			}
+			if err != nil {
+				log.Errorw("Failed to check token denylist", "err", err)
+				return nil, errno.ErrInternal
+			}
+			if revoked {
+				return nil, errno.ErrTokenRevoked
+			}
 		}
 
 		log.Debugw("Token parsing successful", "userID", userID)
@@ -39,11 +99,20 @@ func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
 
 		user, err := retriever.GetUser(ctx, userID)
 		if err != nil {
-			return nil, errno.ErrUnauthenticated.WithMessage(err.Error())
+			// 被禁用或封禁的用户返回对应的错误, 便于客户端提示用户
+			if errors.Is(err, errno.ErrUserDisabled) || errors.Is(err, errno.ErrUserBanned) {
+				return nil, err
+			}
+			return nil, errno.ErrUnauthenticated.WithMessage("%s", err.Error())
 		}
 
 		log.Infow("GetUser result", "user", user != nil, "err", err, "userID", userID)
 
+		// 令牌中的租户必须与用户所属的租户一致, 没有租户声明的令牌(例如个人访问令牌)使用用户所属的租户
+		if claims != nil && claims.TenantID != "" && claims.TenantID != user.TenantID {
+			return nil, errno.ErrUnauthenticated.WithMessage("token tenant does not match the user")
+		}
+
 		// 将用户信息存入上下文
 		//nolint: staticcheck
 		ctx = context.WithValue(ctx, known.XUsername, user.Username)
@@ -53,6 +122,22 @@ func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
 		// 供 log 和 contextx 使用
 		ctx = contextx.WithUserID(ctx, user.UserID)
 		ctx = contextx.WithUsername(ctx, user.Username)
+		ctx = contextx.WithTenantID(ctx, user.TenantID)
+		if claims != nil {
+			ctx = contextx.WithTokenID(ctx, claims.ID)
+			ctx = contextx.WithTokenExpireAt(ctx, claims.ExpiresAt)
+			if claims.ActorID != "" {
+				ctx = contextx.WithActorID(ctx, claims.ActorID)
+				// 模拟登录会话中的每个请求都记录审计日志
+				log.W(ctx).Infow("Handling impersonated request", "method", info.FullMethod)
+			}
+			if claims.SessionID != "" {
+				ctx = contextx.WithSessionID(ctx, claims.SessionID)
+				sessions.Touch(claims.SessionID, time.Now())
+			}
+		} else {
+			ctx = contextx.WithTokenActions(ctx, actions)
+		}
 
 		// 继续处理请求
 		return handler(ctx, req)
@@ -9,31 +9,54 @@ import (
 	"context"
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
+	"slices"
+	"strings"
 
 	"google.golang.org/grpc"
 )
 
 type Authorize interface {
-	Authorize(subject, object, action string) (bool, error)
+	Authorize(subject, domain, object, action string) (bool, error)
 }
 
-func AuthzInterceptor(authorize Authorize) grpc.UnaryServerInterceptor {
+// PermissionResolver 用于将 gRPC 方法解析为与传输协议无关的权限名称, 权限格式为 resource:verb.
+type PermissionResolver interface {
+	MethodPermission(fullMethod string) (string, bool)
+}
+
+func AuthzInterceptor(authorize Authorize, resolver PermissionResolver) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		subject := contextx.UserID(ctx)
-		object := info.FullMethod
-		action := "CALL"
+		subject, domain := contextx.UserID(ctx), contextx.TenantID(ctx)
+
+		// 方法先被解析为权限, 再使用权限中的资源和动作进行授权, 没有声明权限的方法一律拒绝
+		permission, ok := resolver.MethodPermission(info.FullMethod)
+		if !ok {
+			return nil, errno.ErrPermissionDenied.WithMessage("access denied: no permission declared for %s", info.FullMethod)
+		}
+		object, action, _ := strings.Cut(permission, ":")
 
 		// 记录授权上下文信息
-		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
+		log.Debugw("Build authorize context", "subject", subject, "domain", domain, "permission", permission)
+
+		// 模拟登录只用于复现问题, 不允许修改被模拟用户的凭据和安全设置
+		if contextx.Impersonated(ctx) && slices.Contains(known.ImpersonationForbiddenPermissions, permission) {
+			return nil, errno.ErrImpersonationForbidden.WithMessage("access denied: permission %s is not allowed while impersonating", permission)
+		}
+
+		// 个人访问令牌只能使用创建时指定的权限
+		if actions := contextx.TokenActions(ctx); actions != nil && !slices.Contains(actions, permission) {
+			return nil, errno.ErrPermissionDenied.WithMessage("access denied: permission %s is not allowed by the access token", permission)
+		}
 
 		// 调用授权接口进行认证
-		if allowed, err := authorize.Authorize(subject, object, action); err != nil || !allowed {
+		if allowed, err := authorize.Authorize(subject, domain, object, action); err != nil || !allowed {
 			return nil, errno.ErrPermissionDenied.WithMessage(
-				"access denied: subject=%s, object=%s, action=%s, reason=%v",
+				"access denied: subject=%s, domain=%s, permission=%s, reason=%v",
 				subject,
-				object,
-				action,
+				domain,
+				permission,
 				err,
 			)
 		}
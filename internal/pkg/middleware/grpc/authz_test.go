@@ -0,0 +1,78 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// allowAll 允许所有请求, 只验证个人访问令牌的权限限制.
+type allowAll struct{}
+
+func (allowAll) Authorize(subject, domain, object, action string) (bool, error) {
+	return true, nil
+}
+
+// methodPermissions 将方法映射为权限.
+type methodPermissions map[string]string
+
+func (m methodPermissions) MethodPermission(fullMethod string) (string, bool) {
+	permission, ok := m[fullMethod]
+	return permission, ok
+}
+
+func TestAuthzInterceptorAccessTokenPermissions(t *testing.T) {
+	interceptor := AuthzInterceptor(allowAll{}, methodPermissions{
+		"/v1.MiniBlog/ListPost":   "post:list",
+		"/v1.MiniBlog/DeleteUser": "user:delete",
+	})
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	call := func(ctx context.Context, fullMethod string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+		return err
+	}
+
+	ctx := contextx.WithTokenActions(context.Background(), []string{"post:list"})
+	assert.NoError(t, call(ctx, "/v1.MiniBlog/ListPost"))
+	assert.Error(t, call(ctx, "/v1.MiniBlog/DeleteUser"))
+
+	// 旧的传输协议动作不再授予任何权限
+	ctx = contextx.WithTokenActions(context.Background(), []string{"CALL"})
+	assert.Error(t, call(ctx, "/v1.MiniBlog/ListPost"))
+
+	// 不是个人访问令牌的请求不受限制
+	assert.NoError(t, call(context.Background(), "/v1.MiniBlog/DeleteUser"))
+	// 没有声明权限的方法一律拒绝
+	assert.Error(t, call(context.Background(), "/v1.MiniBlog/Unknown"))
+}
+
+func TestAuthzInterceptorImpersonation(t *testing.T) {
+	methods := methodPermissions{"/v1.MiniBlog/ListPost": "post:list"}
+	for _, permission := range known.ImpersonationForbiddenPermissions {
+		methods["/v1.MiniBlog/"+permission] = permission
+	}
+	interceptor := AuthzInterceptor(allowAll{}, methods)
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	call := func(ctx context.Context, fullMethod string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+		return err
+	}
+
+	// 模拟登录的会话不能修改凭据和安全设置, 其他权限不受影响
+	impersonated := contextx.WithActorID(context.Background(), "user-000001")
+	for _, permission := range known.ImpersonationForbiddenPermissions {
+		assert.ErrorIs(t, call(impersonated, "/v1.MiniBlog/"+permission), errno.ErrImpersonationForbidden, permission)
+		assert.NoError(t, call(context.Background(), "/v1.MiniBlog/"+permission), permission)
+	}
+	assert.NoError(t, call(impersonated, "/v1.MiniBlog/ListPost"))
+}
@@ -22,15 +22,19 @@ func AuthnBypasswInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		// 从请求头获取用户ID
 		userID := "user-000001" // 默认用户ID
+		tenantID := known.DefaultTenant
 		// rpc从metadata获取用户id, 类似http中的header?
 		if md, ok := metadata.FromIncomingContext(ctx); ok {
 			// 获取key为x-user-id的值, x-user-id保存了UserID的值
 			if values := md.Get(known.XUserID); len(values) > 0 {
 				userID = values[0]
 			}
+			if values := md.Get(known.XTenantID); len(values) > 0 {
+				tenantID = values[0]
+			}
 		}
 
-		log.Debugw("Simulated authentication successful", "userID", userID)
+		log.Debugw("Simulated authentication successful", "userID", userID, "tenantID", tenantID)
 
 		// 讲默认信息存入上下文
 		//nolint: staticcheck
@@ -38,6 +42,7 @@ func AuthnBypasswInterceptor() grpc.UnaryServerInterceptor {
 
 		// 为long和contextx提供上下文支持
 		ctx = contextx.WithUserID(ctx, userID)
+		ctx = contextx.WithTenantID(ctx, tenantID)
 
 		// 继续处理请求
 		return handler(ctx, req)
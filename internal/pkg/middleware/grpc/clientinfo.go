@@ -0,0 +1,58 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/known"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// 一个grpc拦截器, 用于将客户端IP和User-Agent保存到请求的上下文中.
+// 只有来自本机的请求(例如grpc-gateway转发的请求)才会使用x-forwarded-for中的地址, 防止客户端伪造IP.
+func ClientInfoInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var clientIP string
+		if p, ok := peer.FromContext(ctx); ok {
+			clientIP = p.Addr.String()
+			if host, _, err := net.SplitHostPort(clientIP); err == nil {
+				clientIP = host
+			}
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		userAgent := firstValue(md, known.XUserAgent)
+		if ip := net.ParseIP(clientIP); ip != nil && ip.IsLoopback() {
+			if values := md.Get(known.XForwardedFor); len(values) > 0 {
+				// grpc-gateway会将它看到的对端地址追加到x-forwarded-for末尾, 之前的地址可能由客户端伪造
+				addrs := strings.Split(values[len(values)-1], ",")
+				clientIP = strings.TrimSpace(addrs[len(addrs)-1])
+			}
+			// grpc-gateway转发的请求中, user-agent是网关自身, 原始HTTP客户端的User-Agent保存在单独的键中
+			if ua := firstValue(md, known.XGatewayUserAgent); ua != "" {
+				userAgent = ua
+			}
+		}
+
+		ctx = contextx.WithClientIP(ctx, clientIP)
+		ctx = contextx.WithUserAgent(ctx, userAgent)
+		return handler(ctx, req)
+	}
+}
+
+// 返回元数据中指定键的第一个值.
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
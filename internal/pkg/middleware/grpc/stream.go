@@ -0,0 +1,81 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// StreamInterceptor 将一元拦截器用于服务端流式RPC, 使流式方法和一元方法经过相同的认证, 授权和校验逻辑.
+// 服务端流式RPC只有一个请求, 拦截器先读取请求再执行一元拦截器, 一元拦截器对上下文和请求的修改在流中同样生效.
+// 客户端流式RPC有多个请求, 无法使用一元拦截器, 一律拒绝.
+func StreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return status.Errorf(codes.Unimplemented, "client streaming method %s is not supported", info.FullMethod)
+		}
+
+		req, err := newRequest(info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if err := ss.RecvMsg(req); err != nil {
+			return err
+		}
+
+		unaryInfo := &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}
+		_, err = interceptor(ss.Context(), req, unaryInfo, func(ctx context.Context, req any) (any, error) {
+			return nil, handler(srv, &serverStream{ServerStream: ss, ctx: ctx, req: req.(proto.Message)})
+		})
+		return err
+	}
+}
+
+// serverStream 使用一元拦截器处理后的上下文和请求.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+	req proto.Message
+}
+
+// Context 返回一元拦截器处理后的上下文.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg 返回拦截器已经读取的请求.
+func (s *serverStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+// 根据方法名创建请求消息, 方法名的格式为 /package.Service/Method.
+func newRequest(fullMethod string) (proto.Message, error) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", fullMethod)
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", fullMethod)
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unknown request type %s", method.Input().FullName())
+	}
+	return mt.New().Interface(), nil
+}
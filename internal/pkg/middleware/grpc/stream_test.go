@@ -0,0 +1,76 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package grpc
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeServerStream 模拟客户端只发送一个请求的服务端流.
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	req proto.Message
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	// 一元拦截器修改上下文和请求, userID为空时拒绝请求
+	interceptor := StreamInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rq := req.(*apiv1.ExportUserDataRequest)
+		if rq.GetUserID() == "" {
+			return nil, errno.ErrInvalidArgument
+		}
+		rq.Format = proto.String("zip")
+		return handler(contextx.WithUserID(ctx, "user-000000"), req)
+	})
+	info := &grpc.StreamServerInfo{FullMethod: apiv1.MiniBlog_ExportUserData_FullMethodName, IsServerStream: true}
+
+	var called bool
+	handler := func(srv any, stream grpc.ServerStream) error {
+		called = true
+		var rq apiv1.ExportUserDataRequest
+		require.NoError(t, stream.RecvMsg(&rq))
+		assert.Equal(t, "user-000001", rq.GetUserID())
+		assert.Equal(t, "zip", rq.GetFormat())
+		assert.Equal(t, "user-000000", contextx.UserID(stream.Context()))
+		return nil
+	}
+
+	stream := &fakeServerStream{req: &apiv1.ExportUserDataRequest{UserID: "user-000001"}}
+	require.NoError(t, interceptor(nil, stream, info, handler))
+	assert.True(t, called)
+
+	// 一元拦截器拒绝请求时不会执行流式方法
+	called = false
+	stream = &fakeServerStream{req: &apiv1.ExportUserDataRequest{}}
+	assert.ErrorIs(t, interceptor(nil, stream, info, handler), errno.ErrInvalidArgument)
+	assert.False(t, called)
+
+	// 客户端流式RPC一律拒绝
+	info = &grpc.StreamServerInfo{FullMethod: apiv1.MiniBlog_ExportUserData_FullMethodName, IsClientStream: true}
+	assert.Equal(t, codes.Unimplemented, status.Code(interceptor(nil, stream, info, handler)))
+	assert.False(t, called)
+}
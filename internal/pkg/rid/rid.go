@@ -16,6 +16,8 @@ const (
 	UserID ResourceID = "user"
 	// 定义blog资源标识符.
 	PostID ResourceID = "post"
+	// 定义个人访问令牌资源标识符.
+	AccessTokenID ResourceID = "pat"
 )
 
 // 将资源标识符转换成字符串.
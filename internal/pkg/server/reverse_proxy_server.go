@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	"net/http"
 	"time"
@@ -57,13 +58,16 @@ func NewGRPCGatewayServer(
 		return nil, err
 	}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-		MarshalOptions: protojson.MarshalOptions{
-			// 设置序列化 protobuf 数据时, 枚举类型的字段以数字格式输出.
-			// 否则，默认会以字符串格式输出, 跟枚举类型定义不一致, 带来理解成本.
-			UseEnumNumbers: true,
+	// 返回google.api.HttpBody的接口直接输出其中的数据和内容类型, 例如个人数据导出的归档
+	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &httpBodyMarshaler{&runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				// 设置序列化 protobuf 数据时, 枚举类型的字段以数字格式输出.
+				// 否则，默认会以字符串格式输出, 跟枚举类型定义不一致, 带来理解成本.
+				UseEnumNumbers: true,
+			},
 		},
-	}))
+	}}), runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher))
 	if err := registerHandler(gwmux, conn); err != nil {
 		log.Errorw("Failed to register handler", "err", err)
 		return nil, err
@@ -78,6 +82,25 @@ func NewGRPCGatewayServer(
 	}, nil
 }
 
+// httpBodyMarshaler 按原样拼接流式返回的google.api.HttpBody, 分块之间不插入换行符, 保证归档等二进制数据完整.
+// 目前只有返回HttpBody的接口使用流式响应.
+type httpBodyMarshaler struct {
+	*runtime.HTTPBodyMarshaler
+}
+
+// Delimiter 返回流式响应中分块之间的分隔符.
+func (m *httpBodyMarshaler) Delimiter() []byte {
+	return nil
+}
+
+// 将附件的响应头原样返回给HTTP客户端, 其他元数据与默认行为相同, 加上Grpc-Metadata-前缀后返回.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == known.ContentDisposition {
+		return "Content-Disposition", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 // RunOrDie 启动 GRPC 网关服务器并在出错时记录致命错误.
 func (s *GRPCGatewayServer) RunOrDie() {
 	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
@@ -0,0 +1,509 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// AccessToken API 定义, 包含个人访问令牌的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiserver/v1/access_token.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AccessToken 表示个人访问令牌, 不包含令牌明文
+type AccessToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tokenID 表示访问令牌 ID
+	TokenID string `protobuf:"bytes,1,opt,name=tokenID,proto3" json:"tokenID,omitempty"`
+	// name 表示访问令牌名称, 用于区分不同的用途
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// actions 表示访问令牌允许使用的权限, 例如 post:list, 与 RPC 声明的权限名称一致, 对 HTTP 和 gRPC 请求同样生效
+	Actions []string `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	// expiresAt 表示访问令牌的过期时间, 为空表示永不过期
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// lastUsedAt 表示访问令牌最后一次被使用的时间
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=lastUsedAt,proto3" json:"lastUsedAt,omitempty"`
+	// createdAt 表示访问令牌的创建时间
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessToken) Reset() {
+	*x = AccessToken{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessToken) ProtoMessage() {}
+
+func (x *AccessToken) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessToken.ProtoReflect.Descriptor instead.
+func (*AccessToken) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AccessToken) GetTokenID() string {
+	if x != nil {
+		return x.TokenID
+	}
+	return ""
+}
+
+func (x *AccessToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessToken) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *AccessToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AccessToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *AccessToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// CreateAccessTokenRequest 表示创建个人访问令牌请求
+type CreateAccessTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name 表示访问令牌名称
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// actions 表示访问令牌允许使用的权限, 格式为 resource:verb
+	Actions []string `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+	// expiresIn 表示访问令牌的有效期, 单位为秒, 不设置表示永不过期
+	ExpiresIn     *int64 `protobuf:"varint,3,opt,name=expiresIn,proto3,oneof" json:"expiresIn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenRequest) Reset() {
+	*x = CreateAccessTokenRequest{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreateAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAccessTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccessTokenRequest) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenRequest) GetExpiresIn() int64 {
+	if x != nil && x.ExpiresIn != nil {
+		return *x.ExpiresIn
+	}
+	return 0
+}
+
+// CreateAccessTokenResponse 表示创建个人访问令牌响应
+type CreateAccessTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// accessToken 表示创建的访问令牌
+	AccessToken *AccessToken `protobuf:"bytes,1,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	// token 表示访问令牌明文, 服务端只保存摘要, 因此只会在创建时返回一次
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenResponse) Reset() {
+	*x = CreateAccessTokenResponse{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenResponse) ProtoMessage() {}
+
+func (x *CreateAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAccessTokenResponse) GetAccessToken() *AccessToken {
+	if x != nil {
+		return x.AccessToken
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// ListAccessTokenRequest 表示列出当前用户个人访问令牌请求
+type ListAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessTokenRequest) Reset() {
+	*x = ListAccessTokenRequest{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokenRequest) ProtoMessage() {}
+
+func (x *ListAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{3}
+}
+
+// ListAccessTokenResponse 表示列出当前用户个人访问令牌响应
+type ListAccessTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// total_count 表示访问令牌总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// accessTokens 表示访问令牌列表
+	AccessTokens  []*AccessToken `protobuf:"bytes,2,rep,name=accessTokens,proto3" json:"accessTokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessTokenResponse) Reset() {
+	*x = ListAccessTokenResponse{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokenResponse) ProtoMessage() {}
+
+func (x *ListAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAccessTokenResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListAccessTokenResponse) GetAccessTokens() []*AccessToken {
+	if x != nil {
+		return x.AccessTokens
+	}
+	return nil
+}
+
+// RevokeAccessTokenRequest 表示吊销个人访问令牌请求
+type RevokeAccessTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tokenID 表示要吊销的访问令牌 ID
+	// @gotags: uri:"tokenID"
+	TokenID       string `protobuf:"bytes,1,opt,name=tokenID,proto3" json:"tokenID,omitempty" uri:"tokenID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenRequest) Reset() {
+	*x = RevokeAccessTokenRequest{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenRequest) ProtoMessage() {}
+
+func (x *RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RevokeAccessTokenRequest) GetTokenID() string {
+	if x != nil {
+		return x.TokenID
+	}
+	return ""
+}
+
+// RevokeAccessTokenResponse 表示吊销个人访问令牌响应
+type RevokeAccessTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenResponse) Reset() {
+	*x = RevokeAccessTokenResponse{}
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenResponse) ProtoMessage() {}
+
+func (x *RevokeAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_access_token_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_token_proto_rawDescGZIP(), []int{6}
+}
+
+var File_apiserver_v1_access_token_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_access_token_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapiserver/v1/access_token.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x85\x02\n" +
+	"\vAccessToken\x12\x18\n" +
+	"\atokenID\x18\x01 \x01(\tR\atokenID\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aactions\x18\x03 \x03(\tR\aactions\x128\n" +
+	"\texpiresAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12:\n" +
+	"\n" +
+	"lastUsedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"y\n" +
+	"\x18CreateAccessTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aactions\x18\x02 \x03(\tR\aactions\x12!\n" +
+	"\texpiresIn\x18\x03 \x01(\x03H\x00R\texpiresIn\x88\x01\x01B\f\n" +
+	"\n" +
+	"_expiresIn\"d\n" +
+	"\x19CreateAccessTokenResponse\x121\n" +
+	"\vaccessToken\x18\x01 \x01(\v2\x0f.v1.AccessTokenR\vaccessToken\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x18\n" +
+	"\x16ListAccessTokenRequest\"o\n" +
+	"\x17ListAccessTokenResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x123\n" +
+	"\faccessTokens\x18\x02 \x03(\v2\x0f.v1.AccessTokenR\faccessTokens\"4\n" +
+	"\x18RevokeAccessTokenRequest\x12\x18\n" +
+	"\atokenID\x18\x01 \x01(\tR\atokenID\"\x1b\n" +
+	"\x19RevokeAccessTokenResponseB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_access_token_proto_rawDescOnce sync.Once
+	file_apiserver_v1_access_token_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_access_token_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_access_token_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_access_token_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_access_token_proto_rawDesc), len(file_apiserver_v1_access_token_proto_rawDesc)))
+	})
+	return file_apiserver_v1_access_token_proto_rawDescData
+}
+
+var file_apiserver_v1_access_token_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_apiserver_v1_access_token_proto_goTypes = []any{
+	(*AccessToken)(nil),               // 0: v1.AccessToken
+	(*CreateAccessTokenRequest)(nil),  // 1: v1.CreateAccessTokenRequest
+	(*CreateAccessTokenResponse)(nil), // 2: v1.CreateAccessTokenResponse
+	(*ListAccessTokenRequest)(nil),    // 3: v1.ListAccessTokenRequest
+	(*ListAccessTokenResponse)(nil),   // 4: v1.ListAccessTokenResponse
+	(*RevokeAccessTokenRequest)(nil),  // 5: v1.RevokeAccessTokenRequest
+	(*RevokeAccessTokenResponse)(nil), // 6: v1.RevokeAccessTokenResponse
+	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+}
+var file_apiserver_v1_access_token_proto_depIdxs = []int32{
+	7, // 0: v1.AccessToken.expiresAt:type_name -> google.protobuf.Timestamp
+	7, // 1: v1.AccessToken.lastUsedAt:type_name -> google.protobuf.Timestamp
+	7, // 2: v1.AccessToken.createdAt:type_name -> google.protobuf.Timestamp
+	0, // 3: v1.CreateAccessTokenResponse.accessToken:type_name -> v1.AccessToken
+	0, // 4: v1.ListAccessTokenResponse.accessTokens:type_name -> v1.AccessToken
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_access_token_proto_init() }
+func file_apiserver_v1_access_token_proto_init() {
+	if File_apiserver_v1_access_token_proto != nil {
+		return
+	}
+	file_apiserver_v1_access_token_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_access_token_proto_rawDesc), len(file_apiserver_v1_access_token_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_access_token_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_access_token_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_access_token_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_access_token_proto = out.File
+	file_apiserver_v1_access_token_proto_goTypes = nil
+	file_apiserver_v1_access_token_proto_depIdxs = nil
+}
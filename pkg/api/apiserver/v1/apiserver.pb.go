@@ -14,6 +14,7 @@ package v1
 import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -32,114 +33,335 @@ var File_apiserver_v1_apiserver_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\n" +
-	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/post.proto\x1a\x17apiserver/v1/user.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xa0\x0e\n" +
+	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/post.proto\x1a\x17apiserver/v1/user.proto\x1a\x1fapiserver/v1/access_token.proto\x1a\x17apiserver/v1/totp.proto\x1a\x1aapiserver/v1/session.proto\x1a\x1eapiserver/v1/data_export.proto\x1a\x17apiserver/v1/oidc.proto\x1a\x19apiserver/v1/policy.proto\x1a\x1dapiserver/v1/permission.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x8cA\n" +
 	"\bMiniBlog\x12v\n" +
 	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x13.v1.HealthzResponse\">\x92A+\n" +
 	"\f服务治理\x12\x12服务健康检查*\aHealthz\x82\xd3\xe4\x93\x02\n" +
 	"\x12\b/healthz\x12e\n" +
 	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"7\x92A#\n" +
-	"\f用户管理\x12\f用户登录*\x05Login\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\x89\x01\n" +
+	"\f用户管理\x12\f用户登录*\x05Login\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\x8a\x01\n" +
+	"\vVerifyLogin\x12\x16.v1.VerifyLoginRequest\x1a\x17.v1.VerifyLoginResponse\"J\x92A/\n" +
+	"\f用户管理\x12\x12两步登录验证*\vVerifyLogin\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/verify-login\x12\x89\x01\n" +
 	"\fRefreshToken\x12\x17.v1.RefreshTokenRequest\x1a\x18.v1.RefreshTokenResponse\"F\x92A*\n" +
-	"\f用户管理\x12\f刷新令牌*\fRefreshToken\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12\xa5\x01\n" +
-	"\x0eChangePassword\x12\x19.v1.ChangePasswordRequest\x1a\x1a.v1.ChangePasswordResponse\"\\\x92A,\n" +
-	"\f用户管理\x12\f修改密码*\x0eChangePassword\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12|\n" +
+	"\f用户管理\x12\f刷新令牌*\fRefreshToken\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12j\n" +
+	"\x06Logout\x12\x11.v1.LogoutRequest\x1a\x12.v1.LogoutResponse\"9\x92A$\n" +
+	"\f用户管理\x12\f用户登出*\x06Logout\x82\xd3\xe4\x93\x02\f:\x01*\"\a/logout\x12\xbd\x01\n" +
+	"\x0eChangePassword\x12\x19.v1.ChangePasswordRequest\x1a\x1a.v1.ChangePasswordResponse\"t\x92A,\n" +
+	"\f用户管理\x12\f修改密码*\x0eChangePassword\x82\xb5\x18\x14user:change-password\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12\xa0\x01\n" +
+	"\x0eStartOIDCLogin\x12\x19.v1.StartOIDCLoginRequest\x1a\x1a.v1.StartOIDCLoginResponse\"W\x92AA\n" +
+	"\f用户管理\x12!发起外部身份提供方登录*\x0eStartOIDCLogin\x82\xd3\xe4\x93\x02\r\x12\v/oidc/login\x12\x94\x01\n" +
+	"\fOIDCCallback\x12\x17.v1.OIDCCallbackRequest\x1a\x11.v1.LoginResponse\"X\x92A?\n" +
+	"\f用户管理\x12!外部身份提供方登录回调*\fOIDCCallback\x82\xd3\xe4\x93\x02\x10\x12\x0e/oidc/callback\x12\xb8\x01\n" +
+	"\x14RequestPasswordReset\x12\x1f.v1.RequestPasswordResetRequest\x1a .v1.RequestPasswordResetResponse\"]\x92A8\n" +
+	"\f用户管理\x12\x12申请重置密码*\x14RequestPasswordReset\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/request-password-reset\x12\x8e\x01\n" +
+	"\rResetPassword\x12\x18.v1.ResetPasswordRequest\x1a\x19.v1.ResetPasswordResponse\"H\x92A+\n" +
+	"\f用户管理\x12\f重置密码*\rResetPassword\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/reset-password\x12\x8a\x01\n" +
+	"\vVerifyEmail\x12\x16.v1.VerifyEmailRequest\x1a\x17.v1.VerifyEmailResponse\"J\x92A/\n" +
+	"\f用户管理\x12\x12验证电子邮箱*\vVerifyEmail\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/verify-email\x12\xe2\x01\n" +
+	"\x15SendVerificationEmail\x12 .v1.SendVerificationEmailRequest\x1a!.v1.SendVerificationEmailResponse\"\x83\x01\x92A?\n" +
+	"\f用户管理\x12\x18重新发送验证邮件*\x15SendVerificationEmail\x82\xb5\x18\x1cuser:send-verification-email\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/verification-email\x12\x9a\x01\n" +
 	"\n" +
-	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"?\x92A(\n" +
+	"EnrollTOTP\x12\x15.v1.EnrollTOTPRequest\x1a\x16.v1.EnrollTOTPResponse\"]\x92A1\n" +
+	"\f用户管理\x12\x15绑定身份验证器*\n" +
+	"EnrollTOTP\x82\xb5\x18\vtotp:enroll\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/totp/enroll\x12\xa6\x01\n" +
+	"\vConfirmTOTP\x12\x16.v1.ConfirmTOTPRequest\x1a\x17.v1.ConfirmTOTPResponse\"f\x92A8\n" +
+	"\f用户管理\x12\x1b确认绑定身份验证器*\vConfirmTOTP\x82\xb5\x18\ftotp:confirm\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/totp/confirm\x12\x9d\x01\n" +
+	"\vDisableTOTP\x12\x16.v1.DisableTOTPRequest\x1a\x17.v1.DisableTOTPResponse\"]\x92A/\n" +
+	"\f用户管理\x12\x12关闭两步验证*\vDisableTOTP\x82\xb5\x18\ftotp:disable\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/totp/disable\x12\xb7\x01\n" +
+	"\fRevokeTokens\x12\x17.v1.RevokeTokensRequest\x1a\x18.v1.RevokeTokensResponse\"t\x92A0\n" +
+	"\f用户管理\x12\x12吊销用户令牌*\fRevokeTokens\x82\xb5\x18\x12user:revoke-tokens\x82\xd3\xe4\x93\x02%:\x01*\" /v1/users/{userID}/revoke-tokens\x12\xaf\x01\n" +
+	"\vImpersonate\x12\x16.v1.ImpersonateRequest\x1a\x17.v1.ImpersonateResponse\"o\x92A/\n" +
+	"\f用户管理\x12\x12模拟用户登录*\vImpersonate\x82\xb5\x18\x10user:impersonate\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/users/{userID}/impersonate\x12\xa7\x01\n" +
+	"\n" +
+	"UnlockUser\x12\x15.v1.UnlockUserRequest\x1a\x16.v1.UnlockUserResponse\"j\x92A4\n" +
+	"\f用户管理\x12\x18解除用户登录锁定*\n" +
+	"UnlockUser\x82\xb5\x18\vuser:unlock\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/{userID}/unlock\x12\xc0\x01\n" +
+	"\x10UpdateUserStatus\x12\x1b.v1.UpdateUserStatusRequest\x1a\x1c.v1.UpdateUserStatusResponse\"q\x92A4\n" +
+	"\f用户管理\x12\x12修改用户状态*\x10UpdateUserStatus\x82\xb5\x18\x12user:update-status\x82\xd3\xe4\x93\x02\x1e:\x01*\x1a\x19/v1/users/{userID}/status\x12\x8b\x01\n" +
+	"\n" +
+	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"N\x92A(\n" +
 	"\f用户管理\x12\f创建用户*\n" +
-	"CreateUser\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12\x8b\x01\n" +
+	"CreateUser\x82\xb5\x18\vuser:create\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12\x9a\x01\n" +
 	"\n" +
-	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"N\x92A.\n" +
+	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"]\x92A.\n" +
 	"\f用户管理\x12\x12更新用户信息*\n" +
-	"UpdateUser\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12\x82\x01\n" +
+	"UpdateUser\x82\xb5\x18\vuser:update\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12\x91\x01\n" +
 	"\n" +
-	"DeleteUser\x12\x15.v1.DeleteUserRequest\x1a\x16.v1.DeleteUserResponse\"E\x92A(\n" +
+	"DeleteUser\x12\x15.v1.DeleteUserRequest\x1a\x16.v1.DeleteUserResponse\"T\x92A(\n" +
 	"\f用户管理\x12\f删除用户*\n" +
-	"DeleteUser\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12|\n" +
-	"\aGetUser\x12\x12.v1.GetUserRequest\x1a\x13.v1.GetUserResponse\"H\x92A+\n" +
-	"\f用户管理\x12\x12获取用户信息*\aGetUser\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12w\n" +
-	"\bListUser\x12\x13.v1.ListUserRequest\x1a\x14.v1.ListUserResponse\"@\x92A,\n" +
-	"\f用户管理\x12\x12列出所有用户*\bListUser\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12|\n" +
+	"DeleteUser\x82\xb5\x18\vuser:delete\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12\xad\x01\n" +
+	"\vRestoreUser\x12\x16.v1.RestoreUserRequest\x1a\x17.v1.RestoreUserResponse\"m\x92A5\n" +
+	"\f用户管理\x12\x18恢复已删除的用户*\vRestoreUser\x82\xb5\x18\fuser:restore\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/users/{userID}/restore\x12\x88\x01\n" +
+	"\aGetUser\x12\x12.v1.GetUserRequest\x1a\x13.v1.GetUserResponse\"T\x92A+\n" +
+	"\f用户管理\x12\x12获取用户信息*\aGetUser\x82\xb5\x18\buser:get\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12\x84\x01\n" +
+	"\bListUser\x12\x13.v1.ListUserRequest\x1a\x14.v1.ListUserResponse\"M\x92A,\n" +
+	"\f用户管理\x12\x12列出所有用户*\bListUser\x82\xb5\x18\tuser:list\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12\xc3\x01\n" +
+	"\x11CreateAccessToken\x12\x1c.v1.CreateAccessTokenRequest\x1a\x1d.v1.CreateAccessTokenResponse\"q\x92A;\n" +
+	"\f用户管理\x12\x18创建个人访问令牌*\x11CreateAccessToken\x82\xb5\x18\x13access-token:create\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/access-tokens\x12\xb6\x01\n" +
+	"\x0fListAccessToken\x12\x1a.v1.ListAccessTokenRequest\x1a\x1b.v1.ListAccessTokenResponse\"j\x92A9\n" +
+	"\f用户管理\x12\x18列出个人访问令牌*\x0fListAccessToken\x82\xb5\x18\x11access-token:list\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/access-tokens\x12\xca\x01\n" +
+	"\x11RevokeAccessToken\x12\x1c.v1.RevokeAccessTokenRequest\x1a\x1d.v1.RevokeAccessTokenResponse\"x\x92A;\n" +
+	"\f用户管理\x12\x18吊销个人访问令牌*\x11RevokeAccessToken\x82\xb5\x18\x13access-token:revoke\x82\xd3\xe4\x93\x02\x1d*\x1b/v1/access-tokens/{tokenID}\x12\x9a\x01\n" +
+	"\fListSessions\x12\x17.v1.ListSessionsRequest\x1a\x18.v1.ListSessionsResponse\"W\x92A0\n" +
+	"\f用户管理\x12\x12列出登录会话*\fListSessions\x82\xb5\x18\fsession:list\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/sessions\x12\xac\x01\n" +
+	"\rRevokeSession\x12\x18.v1.RevokeSessionRequest\x1a\x19.v1.RevokeSessionResponse\"f\x92A1\n" +
+	"\f用户管理\x12\x12吊销登录会话*\rRevokeSession\x82\xb5\x18\x0esession:revoke\x82\xd3\xe4\x93\x02\x1a*\x18/v1/sessions/{sessionID}\x12\xbf\x01\n" +
+	"\x10ListUserSessions\x12\x1b.v1.ListUserSessionsRequest\x1a\x18.v1.ListSessionsResponse\"t\x92A:\n" +
+	"\f用户管理\x12\x18列出用户登录会话*\x10ListUserSessions\x82\xb5\x18\x11user-session:list\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/user-sessions/{userID}\x12\xd2\x01\n" +
+	"\x11RevokeUserSession\x12\x1c.v1.RevokeUserSessionRequest\x1a\x19.v1.RevokeSessionResponse\"\x83\x01\x92A;\n" +
+	"\f用户管理\x12\x18吊销用户登录会话*\x11RevokeUserSession\x82\xb5\x18\x13user-session:revoke\x82\xd3\xe4\x93\x02(*&/v1/user-sessions/{userID}/{sessionID}\x12\xa1\x01\n" +
+	"\fExportMyData\x12\x17.v1.ExportMyDataRequest\x1a\x14.google.api.HttpBody\"`\x92A0\n" +
+	"\f用户管理\x12\x12导出个人数据*\fExportMyData\x82\xb5\x18\x12data-export:export\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/data-export0\x01\x12\xcb\x01\n" +
+	"\x0eExportUserData\x12\x19.v1.ExportUserDataRequest\x1a\x14.google.api.HttpBody\"\x85\x01\x92AA\n" +
+	"\f用户管理\x12!导出指定用户的个人数据*\x0eExportUserData\x82\xb5\x18\x17user-data-export:export\x82\xd3\xe4\x93\x02 \x12\x1e/v1/user-data-exports/{userID}0\x01\x12\x99\x01\n" +
+	"\fListPolicies\x12\x17.v1.ListPoliciesRequest\x1a\x18.v1.ListPoliciesResponse\"V\x92A0\n" +
+	"\f权限管理\x12\x12列出授权策略*\fListPolicies\x82\xb5\x18\vpolicy:list\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/policies\x12\x8f\x01\n" +
+	"\tAddPolicy\x12\x14.v1.AddPolicyRequest\x1a\x15.v1.AddPolicyResponse\"U\x92A-\n" +
+	"\f权限管理\x12\x12添加授权策略*\tAddPolicy\x82\xb5\x18\n" +
+	"policy:add\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/policies\x12\x9e\x01\n" +
+	"\fRemovePolicy\x12\x17.v1.RemovePolicyRequest\x1a\x18.v1.RemovePolicyResponse\"[\x92A0\n" +
+	"\f权限管理\x12\x12删除授权策略*\fRemovePolicy\x82\xb5\x18\rpolicy:remove\x82\xd3\xe4\x93\x02\x11:\x01**\f/v1/policies\x12\xd1\x01\n" +
+	"\x14ListGroupingPolicies\x12\x1f.v1.ListGroupingPoliciesRequest\x1a .v1.ListGroupingPoliciesResponse\"v\x92A>\n" +
+	"\f权限管理\x12\x18列出角色继承规则*\x14ListGroupingPolicies\x82\xb5\x18\x14grouping-policy:list\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/grouping-policies\x12\xc7\x01\n" +
+	"\x11AddGroupingPolicy\x12\x1c.v1.AddGroupingPolicyRequest\x1a\x1d.v1.AddGroupingPolicyResponse\"u\x92A;\n" +
+	"\f权限管理\x12\x18添加角色继承规则*\x11AddGroupingPolicy\x82\xb5\x18\x13grouping-policy:add\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/grouping-policies\x12\xd6\x01\n" +
+	"\x14RemoveGroupingPolicy\x12\x1f.v1.RemoveGroupingPolicyRequest\x1a .v1.RemoveGroupingPolicyResponse\"{\x92A>\n" +
+	"\f权限管理\x12\x18删除角色继承规则*\x14RemoveGroupingPolicy\x82\xb5\x18\x16grouping-policy:remove\x82\xd3\xe4\x93\x02\x1a:\x01**\x15/v1/grouping-policies\x12\xab\x01\n" +
+	"\rListUserRoles\x12\x18.v1.ListUserRolesRequest\x1a\x19.v1.ListUserRolesResponse\"e\x92A1\n" +
+	"\f权限管理\x12\x12列出用户角色*\rListUserRoles\x82\xb5\x18\x0euser-role:list\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/user-roles/{userID}\x12\xa4\x01\n" +
+	"\n" +
+	"AssignRole\x12\x15.v1.AssignRoleRequest\x1a\x16.v1.AssignRoleResponse\"g\x92A.\n" +
+	"\f权限管理\x12\x12分配用户角色*\n" +
+	"AssignRole\x82\xb5\x18\x10user-role:assign\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/user-roles/{userID}\x12\xa8\x01\n" +
+	"\n" +
+	"RevokeRole\x12\x15.v1.RevokeRoleRequest\x1a\x16.v1.RevokeRoleResponse\"k\x92A.\n" +
+	"\f权限管理\x12\x12收回用户角色*\n" +
+	"RevokeRole\x82\xb5\x18\x10user-role:revoke\x82\xd3\xe4\x93\x02 *\x1e/v1/user-roles/{userID}/{role}\x12\xc7\x01\n" +
+	"\x14ExplainAuthorization\x12\x1f.v1.ExplainAuthorizationRequest\x1a .v1.ExplainAuthorizationResponse\"l\x92A8\n" +
+	"\f权限管理\x12\x12解释授权结果*\x14ExplainAuthorization\x82\xb5\x18\x0epolicy:explain\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/policies/explain\x12\x8b\x01\n" +
 	"\n" +
-	"CreatePost\x12\x15.v1.CreatePostRequest\x1a\x16.v1.CreatePostResponse\"?\x92A(\n" +
+	"CreatePost\x12\x15.v1.CreatePostRequest\x1a\x16.v1.CreatePostResponse\"N\x92A(\n" +
 	"\f博客管理\x12\f创建文章*\n" +
-	"CreatePost\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12\x85\x01\n" +
+	"CreatePost\x82\xb5\x18\vpost:create\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12\x94\x01\n" +
 	"\n" +
-	"UpdatePost\x12\x15.v1.UpdatePostRequest\x1a\x16.v1.UpdatePostResponse\"H\x92A(\n" +
+	"UpdatePost\x12\x15.v1.UpdatePostRequest\x1a\x16.v1.UpdatePostResponse\"W\x92A(\n" +
 	"\f博客管理\x12\f更新文章*\n" +
-	"UpdatePost\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/posts/{postID}\x12|\n" +
+	"UpdatePost\x82\xb5\x18\vpost:update\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/posts/{postID}\x12\x8b\x01\n" +
 	"\n" +
-	"DeletePost\x12\x15.v1.DeletePostRequest\x1a\x16.v1.DeletePostResponse\"?\x92A(\n" +
+	"DeletePost\x12\x15.v1.DeletePostRequest\x1a\x16.v1.DeletePostResponse\"N\x92A(\n" +
 	"\f博客管理\x12\f删除文章*\n" +
-	"DeletePost\x82\xd3\xe4\x93\x02\x0e:\x01**\t/v1/posts\x12|\n" +
-	"\aGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"H\x92A+\n" +
-	"\f博客管理\x12\x12获取文章信息*\aGetPost\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/posts/{postID}\x12w\n" +
-	"\bListPost\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"@\x92A,\n" +
-	"\f博客管理\x12\x12列出所有文章*\bListPost\x82\xd3\xe4\x93\x02\v\x12\t/v1/postsB\xfa\x01\x92A\xd4\x01\x12\xaa\x01\n" +
+	"DeletePost\x82\xb5\x18\vpost:delete\x82\xd3\xe4\x93\x02\x0e:\x01**\t/v1/posts\x12\xad\x01\n" +
+	"\vRestorePost\x12\x16.v1.RestorePostRequest\x1a\x17.v1.RestorePostResponse\"m\x92A5\n" +
+	"\f博客管理\x12\x18恢复已删除的文章*\vRestorePost\x82\xb5\x18\fpost:restore\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/posts/{postID}/restore\x12\x88\x01\n" +
+	"\aGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"T\x92A+\n" +
+	"\f博客管理\x12\x12获取文章信息*\aGetPost\x82\xb5\x18\bpost:get\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/posts/{postID}\x12\x84\x01\n" +
+	"\bListPost\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"M\x92A,\n" +
+	"\f博客管理\x12\x12列出所有文章*\bListPost\x82\xb5\x18\tpost:list\x82\xd3\xe4\x93\x02\v\x12\t/v1/postsB\xfa\x01\x92A\xd4\x01\x12\xaa\x01\n" +
 	"\fminiblog API\"M\n" +
 	"\x13mini blog framework\x12!https://github/Alainyan1/miniblog\x1a\x13alain.yan@yahoo.com*F\n" +
 	"\vMIT License\x127https://github.com/Alainyan1/miniblog/blob/main/LICENSE2\x031.0*\x01\x022\x10application/json:\x10application/jsonZ miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var file_apiserver_v1_apiserver_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),          // 0: google.protobuf.Empty
-	(*LoginRequest)(nil),           // 1: v1.LoginRequest
-	(*RefreshTokenRequest)(nil),    // 2: v1.RefreshTokenRequest
-	(*ChangePasswordRequest)(nil),  // 3: v1.ChangePasswordRequest
-	(*CreateUserRequest)(nil),      // 4: v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),      // 5: v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),      // 6: v1.DeleteUserRequest
-	(*GetUserRequest)(nil),         // 7: v1.GetUserRequest
-	(*ListUserRequest)(nil),        // 8: v1.ListUserRequest
-	(*CreatePostRequest)(nil),      // 9: v1.CreatePostRequest
-	(*UpdatePostRequest)(nil),      // 10: v1.UpdatePostRequest
-	(*DeletePostRequest)(nil),      // 11: v1.DeletePostRequest
-	(*GetPostRequest)(nil),         // 12: v1.GetPostRequest
-	(*ListPostRequest)(nil),        // 13: v1.ListPostRequest
-	(*HealthzResponse)(nil),        // 14: v1.HealthzResponse
-	(*LoginResponse)(nil),          // 15: v1.LoginResponse
-	(*RefreshTokenResponse)(nil),   // 16: v1.RefreshTokenResponse
-	(*ChangePasswordResponse)(nil), // 17: v1.ChangePasswordResponse
-	(*CreateUserResponse)(nil),     // 18: v1.CreateUserResponse
-	(*UpdateUserResponse)(nil),     // 19: v1.UpdateUserResponse
-	(*DeleteUserResponse)(nil),     // 20: v1.DeleteUserResponse
-	(*GetUserResponse)(nil),        // 21: v1.GetUserResponse
-	(*ListUserResponse)(nil),       // 22: v1.ListUserResponse
-	(*CreatePostResponse)(nil),     // 23: v1.CreatePostResponse
-	(*UpdatePostResponse)(nil),     // 24: v1.UpdatePostResponse
-	(*DeletePostResponse)(nil),     // 25: v1.DeletePostResponse
-	(*GetPostResponse)(nil),        // 26: v1.GetPostResponse
-	(*ListPostResponse)(nil),       // 27: v1.ListPostResponse
+	(*emptypb.Empty)(nil),                 // 0: google.protobuf.Empty
+	(*LoginRequest)(nil),                  // 1: v1.LoginRequest
+	(*VerifyLoginRequest)(nil),            // 2: v1.VerifyLoginRequest
+	(*RefreshTokenRequest)(nil),           // 3: v1.RefreshTokenRequest
+	(*LogoutRequest)(nil),                 // 4: v1.LogoutRequest
+	(*ChangePasswordRequest)(nil),         // 5: v1.ChangePasswordRequest
+	(*StartOIDCLoginRequest)(nil),         // 6: v1.StartOIDCLoginRequest
+	(*OIDCCallbackRequest)(nil),           // 7: v1.OIDCCallbackRequest
+	(*RequestPasswordResetRequest)(nil),   // 8: v1.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),          // 9: v1.ResetPasswordRequest
+	(*VerifyEmailRequest)(nil),            // 10: v1.VerifyEmailRequest
+	(*SendVerificationEmailRequest)(nil),  // 11: v1.SendVerificationEmailRequest
+	(*EnrollTOTPRequest)(nil),             // 12: v1.EnrollTOTPRequest
+	(*ConfirmTOTPRequest)(nil),            // 13: v1.ConfirmTOTPRequest
+	(*DisableTOTPRequest)(nil),            // 14: v1.DisableTOTPRequest
+	(*RevokeTokensRequest)(nil),           // 15: v1.RevokeTokensRequest
+	(*ImpersonateRequest)(nil),            // 16: v1.ImpersonateRequest
+	(*UnlockUserRequest)(nil),             // 17: v1.UnlockUserRequest
+	(*UpdateUserStatusRequest)(nil),       // 18: v1.UpdateUserStatusRequest
+	(*CreateUserRequest)(nil),             // 19: v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),             // 20: v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),             // 21: v1.DeleteUserRequest
+	(*RestoreUserRequest)(nil),            // 22: v1.RestoreUserRequest
+	(*GetUserRequest)(nil),                // 23: v1.GetUserRequest
+	(*ListUserRequest)(nil),               // 24: v1.ListUserRequest
+	(*CreateAccessTokenRequest)(nil),      // 25: v1.CreateAccessTokenRequest
+	(*ListAccessTokenRequest)(nil),        // 26: v1.ListAccessTokenRequest
+	(*RevokeAccessTokenRequest)(nil),      // 27: v1.RevokeAccessTokenRequest
+	(*ListSessionsRequest)(nil),           // 28: v1.ListSessionsRequest
+	(*RevokeSessionRequest)(nil),          // 29: v1.RevokeSessionRequest
+	(*ListUserSessionsRequest)(nil),       // 30: v1.ListUserSessionsRequest
+	(*RevokeUserSessionRequest)(nil),      // 31: v1.RevokeUserSessionRequest
+	(*ExportMyDataRequest)(nil),           // 32: v1.ExportMyDataRequest
+	(*ExportUserDataRequest)(nil),         // 33: v1.ExportUserDataRequest
+	(*ListPoliciesRequest)(nil),           // 34: v1.ListPoliciesRequest
+	(*AddPolicyRequest)(nil),              // 35: v1.AddPolicyRequest
+	(*RemovePolicyRequest)(nil),           // 36: v1.RemovePolicyRequest
+	(*ListGroupingPoliciesRequest)(nil),   // 37: v1.ListGroupingPoliciesRequest
+	(*AddGroupingPolicyRequest)(nil),      // 38: v1.AddGroupingPolicyRequest
+	(*RemoveGroupingPolicyRequest)(nil),   // 39: v1.RemoveGroupingPolicyRequest
+	(*ListUserRolesRequest)(nil),          // 40: v1.ListUserRolesRequest
+	(*AssignRoleRequest)(nil),             // 41: v1.AssignRoleRequest
+	(*RevokeRoleRequest)(nil),             // 42: v1.RevokeRoleRequest
+	(*ExplainAuthorizationRequest)(nil),   // 43: v1.ExplainAuthorizationRequest
+	(*CreatePostRequest)(nil),             // 44: v1.CreatePostRequest
+	(*UpdatePostRequest)(nil),             // 45: v1.UpdatePostRequest
+	(*DeletePostRequest)(nil),             // 46: v1.DeletePostRequest
+	(*RestorePostRequest)(nil),            // 47: v1.RestorePostRequest
+	(*GetPostRequest)(nil),                // 48: v1.GetPostRequest
+	(*ListPostRequest)(nil),               // 49: v1.ListPostRequest
+	(*HealthzResponse)(nil),               // 50: v1.HealthzResponse
+	(*LoginResponse)(nil),                 // 51: v1.LoginResponse
+	(*VerifyLoginResponse)(nil),           // 52: v1.VerifyLoginResponse
+	(*RefreshTokenResponse)(nil),          // 53: v1.RefreshTokenResponse
+	(*LogoutResponse)(nil),                // 54: v1.LogoutResponse
+	(*ChangePasswordResponse)(nil),        // 55: v1.ChangePasswordResponse
+	(*StartOIDCLoginResponse)(nil),        // 56: v1.StartOIDCLoginResponse
+	(*RequestPasswordResetResponse)(nil),  // 57: v1.RequestPasswordResetResponse
+	(*ResetPasswordResponse)(nil),         // 58: v1.ResetPasswordResponse
+	(*VerifyEmailResponse)(nil),           // 59: v1.VerifyEmailResponse
+	(*SendVerificationEmailResponse)(nil), // 60: v1.SendVerificationEmailResponse
+	(*EnrollTOTPResponse)(nil),            // 61: v1.EnrollTOTPResponse
+	(*ConfirmTOTPResponse)(nil),           // 62: v1.ConfirmTOTPResponse
+	(*DisableTOTPResponse)(nil),           // 63: v1.DisableTOTPResponse
+	(*RevokeTokensResponse)(nil),          // 64: v1.RevokeTokensResponse
+	(*ImpersonateResponse)(nil),           // 65: v1.ImpersonateResponse
+	(*UnlockUserResponse)(nil),            // 66: v1.UnlockUserResponse
+	(*UpdateUserStatusResponse)(nil),      // 67: v1.UpdateUserStatusResponse
+	(*CreateUserResponse)(nil),            // 68: v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),            // 69: v1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),            // 70: v1.DeleteUserResponse
+	(*RestoreUserResponse)(nil),           // 71: v1.RestoreUserResponse
+	(*GetUserResponse)(nil),               // 72: v1.GetUserResponse
+	(*ListUserResponse)(nil),              // 73: v1.ListUserResponse
+	(*CreateAccessTokenResponse)(nil),     // 74: v1.CreateAccessTokenResponse
+	(*ListAccessTokenResponse)(nil),       // 75: v1.ListAccessTokenResponse
+	(*RevokeAccessTokenResponse)(nil),     // 76: v1.RevokeAccessTokenResponse
+	(*ListSessionsResponse)(nil),          // 77: v1.ListSessionsResponse
+	(*RevokeSessionResponse)(nil),         // 78: v1.RevokeSessionResponse
+	(*httpbody.HttpBody)(nil),             // 79: google.api.HttpBody
+	(*ListPoliciesResponse)(nil),          // 80: v1.ListPoliciesResponse
+	(*AddPolicyResponse)(nil),             // 81: v1.AddPolicyResponse
+	(*RemovePolicyResponse)(nil),          // 82: v1.RemovePolicyResponse
+	(*ListGroupingPoliciesResponse)(nil),  // 83: v1.ListGroupingPoliciesResponse
+	(*AddGroupingPolicyResponse)(nil),     // 84: v1.AddGroupingPolicyResponse
+	(*RemoveGroupingPolicyResponse)(nil),  // 85: v1.RemoveGroupingPolicyResponse
+	(*ListUserRolesResponse)(nil),         // 86: v1.ListUserRolesResponse
+	(*AssignRoleResponse)(nil),            // 87: v1.AssignRoleResponse
+	(*RevokeRoleResponse)(nil),            // 88: v1.RevokeRoleResponse
+	(*ExplainAuthorizationResponse)(nil),  // 89: v1.ExplainAuthorizationResponse
+	(*CreatePostResponse)(nil),            // 90: v1.CreatePostResponse
+	(*UpdatePostResponse)(nil),            // 91: v1.UpdatePostResponse
+	(*DeletePostResponse)(nil),            // 92: v1.DeletePostResponse
+	(*RestorePostResponse)(nil),           // 93: v1.RestorePostResponse
+	(*GetPostResponse)(nil),               // 94: v1.GetPostResponse
+	(*ListPostResponse)(nil),              // 95: v1.ListPostResponse
 }
 var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
 	0,  // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
 	1,  // 1: v1.MiniBlog.Login:input_type -> v1.LoginRequest
-	2,  // 2: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
-	3,  // 3: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
-	4,  // 4: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
-	5,  // 5: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
-	6,  // 6: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
-	7,  // 7: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
-	8,  // 8: v1.MiniBlog.ListUser:input_type -> v1.ListUserRequest
-	9,  // 9: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
-	10, // 10: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
-	11, // 11: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
-	12, // 12: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
-	13, // 13: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
-	14, // 14: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
-	15, // 15: v1.MiniBlog.Login:output_type -> v1.LoginResponse
-	16, // 16: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
-	17, // 17: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
-	18, // 18: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
-	19, // 19: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
-	20, // 20: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
-	21, // 21: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
-	22, // 22: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
-	23, // 23: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
-	24, // 24: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
-	25, // 25: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
-	26, // 26: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
-	27, // 27: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
-	14, // [14:28] is the sub-list for method output_type
-	0,  // [0:14] is the sub-list for method input_type
+	2,  // 2: v1.MiniBlog.VerifyLogin:input_type -> v1.VerifyLoginRequest
+	3,  // 3: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
+	4,  // 4: v1.MiniBlog.Logout:input_type -> v1.LogoutRequest
+	5,  // 5: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
+	6,  // 6: v1.MiniBlog.StartOIDCLogin:input_type -> v1.StartOIDCLoginRequest
+	7,  // 7: v1.MiniBlog.OIDCCallback:input_type -> v1.OIDCCallbackRequest
+	8,  // 8: v1.MiniBlog.RequestPasswordReset:input_type -> v1.RequestPasswordResetRequest
+	9,  // 9: v1.MiniBlog.ResetPassword:input_type -> v1.ResetPasswordRequest
+	10, // 10: v1.MiniBlog.VerifyEmail:input_type -> v1.VerifyEmailRequest
+	11, // 11: v1.MiniBlog.SendVerificationEmail:input_type -> v1.SendVerificationEmailRequest
+	12, // 12: v1.MiniBlog.EnrollTOTP:input_type -> v1.EnrollTOTPRequest
+	13, // 13: v1.MiniBlog.ConfirmTOTP:input_type -> v1.ConfirmTOTPRequest
+	14, // 14: v1.MiniBlog.DisableTOTP:input_type -> v1.DisableTOTPRequest
+	15, // 15: v1.MiniBlog.RevokeTokens:input_type -> v1.RevokeTokensRequest
+	16, // 16: v1.MiniBlog.Impersonate:input_type -> v1.ImpersonateRequest
+	17, // 17: v1.MiniBlog.UnlockUser:input_type -> v1.UnlockUserRequest
+	18, // 18: v1.MiniBlog.UpdateUserStatus:input_type -> v1.UpdateUserStatusRequest
+	19, // 19: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
+	20, // 20: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
+	21, // 21: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
+	22, // 22: v1.MiniBlog.RestoreUser:input_type -> v1.RestoreUserRequest
+	23, // 23: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
+	24, // 24: v1.MiniBlog.ListUser:input_type -> v1.ListUserRequest
+	25, // 25: v1.MiniBlog.CreateAccessToken:input_type -> v1.CreateAccessTokenRequest
+	26, // 26: v1.MiniBlog.ListAccessToken:input_type -> v1.ListAccessTokenRequest
+	27, // 27: v1.MiniBlog.RevokeAccessToken:input_type -> v1.RevokeAccessTokenRequest
+	28, // 28: v1.MiniBlog.ListSessions:input_type -> v1.ListSessionsRequest
+	29, // 29: v1.MiniBlog.RevokeSession:input_type -> v1.RevokeSessionRequest
+	30, // 30: v1.MiniBlog.ListUserSessions:input_type -> v1.ListUserSessionsRequest
+	31, // 31: v1.MiniBlog.RevokeUserSession:input_type -> v1.RevokeUserSessionRequest
+	32, // 32: v1.MiniBlog.ExportMyData:input_type -> v1.ExportMyDataRequest
+	33, // 33: v1.MiniBlog.ExportUserData:input_type -> v1.ExportUserDataRequest
+	34, // 34: v1.MiniBlog.ListPolicies:input_type -> v1.ListPoliciesRequest
+	35, // 35: v1.MiniBlog.AddPolicy:input_type -> v1.AddPolicyRequest
+	36, // 36: v1.MiniBlog.RemovePolicy:input_type -> v1.RemovePolicyRequest
+	37, // 37: v1.MiniBlog.ListGroupingPolicies:input_type -> v1.ListGroupingPoliciesRequest
+	38, // 38: v1.MiniBlog.AddGroupingPolicy:input_type -> v1.AddGroupingPolicyRequest
+	39, // 39: v1.MiniBlog.RemoveGroupingPolicy:input_type -> v1.RemoveGroupingPolicyRequest
+	40, // 40: v1.MiniBlog.ListUserRoles:input_type -> v1.ListUserRolesRequest
+	41, // 41: v1.MiniBlog.AssignRole:input_type -> v1.AssignRoleRequest
+	42, // 42: v1.MiniBlog.RevokeRole:input_type -> v1.RevokeRoleRequest
+	43, // 43: v1.MiniBlog.ExplainAuthorization:input_type -> v1.ExplainAuthorizationRequest
+	44, // 44: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
+	45, // 45: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
+	46, // 46: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
+	47, // 47: v1.MiniBlog.RestorePost:input_type -> v1.RestorePostRequest
+	48, // 48: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
+	49, // 49: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
+	50, // 50: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
+	51, // 51: v1.MiniBlog.Login:output_type -> v1.LoginResponse
+	52, // 52: v1.MiniBlog.VerifyLogin:output_type -> v1.VerifyLoginResponse
+	53, // 53: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
+	54, // 54: v1.MiniBlog.Logout:output_type -> v1.LogoutResponse
+	55, // 55: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
+	56, // 56: v1.MiniBlog.StartOIDCLogin:output_type -> v1.StartOIDCLoginResponse
+	51, // 57: v1.MiniBlog.OIDCCallback:output_type -> v1.LoginResponse
+	57, // 58: v1.MiniBlog.RequestPasswordReset:output_type -> v1.RequestPasswordResetResponse
+	58, // 59: v1.MiniBlog.ResetPassword:output_type -> v1.ResetPasswordResponse
+	59, // 60: v1.MiniBlog.VerifyEmail:output_type -> v1.VerifyEmailResponse
+	60, // 61: v1.MiniBlog.SendVerificationEmail:output_type -> v1.SendVerificationEmailResponse
+	61, // 62: v1.MiniBlog.EnrollTOTP:output_type -> v1.EnrollTOTPResponse
+	62, // 63: v1.MiniBlog.ConfirmTOTP:output_type -> v1.ConfirmTOTPResponse
+	63, // 64: v1.MiniBlog.DisableTOTP:output_type -> v1.DisableTOTPResponse
+	64, // 65: v1.MiniBlog.RevokeTokens:output_type -> v1.RevokeTokensResponse
+	65, // 66: v1.MiniBlog.Impersonate:output_type -> v1.ImpersonateResponse
+	66, // 67: v1.MiniBlog.UnlockUser:output_type -> v1.UnlockUserResponse
+	67, // 68: v1.MiniBlog.UpdateUserStatus:output_type -> v1.UpdateUserStatusResponse
+	68, // 69: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
+	69, // 70: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
+	70, // 71: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
+	71, // 72: v1.MiniBlog.RestoreUser:output_type -> v1.RestoreUserResponse
+	72, // 73: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
+	73, // 74: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
+	74, // 75: v1.MiniBlog.CreateAccessToken:output_type -> v1.CreateAccessTokenResponse
+	75, // 76: v1.MiniBlog.ListAccessToken:output_type -> v1.ListAccessTokenResponse
+	76, // 77: v1.MiniBlog.RevokeAccessToken:output_type -> v1.RevokeAccessTokenResponse
+	77, // 78: v1.MiniBlog.ListSessions:output_type -> v1.ListSessionsResponse
+	78, // 79: v1.MiniBlog.RevokeSession:output_type -> v1.RevokeSessionResponse
+	77, // 80: v1.MiniBlog.ListUserSessions:output_type -> v1.ListSessionsResponse
+	78, // 81: v1.MiniBlog.RevokeUserSession:output_type -> v1.RevokeSessionResponse
+	79, // 82: v1.MiniBlog.ExportMyData:output_type -> google.api.HttpBody
+	79, // 83: v1.MiniBlog.ExportUserData:output_type -> google.api.HttpBody
+	80, // 84: v1.MiniBlog.ListPolicies:output_type -> v1.ListPoliciesResponse
+	81, // 85: v1.MiniBlog.AddPolicy:output_type -> v1.AddPolicyResponse
+	82, // 86: v1.MiniBlog.RemovePolicy:output_type -> v1.RemovePolicyResponse
+	83, // 87: v1.MiniBlog.ListGroupingPolicies:output_type -> v1.ListGroupingPoliciesResponse
+	84, // 88: v1.MiniBlog.AddGroupingPolicy:output_type -> v1.AddGroupingPolicyResponse
+	85, // 89: v1.MiniBlog.RemoveGroupingPolicy:output_type -> v1.RemoveGroupingPolicyResponse
+	86, // 90: v1.MiniBlog.ListUserRoles:output_type -> v1.ListUserRolesResponse
+	87, // 91: v1.MiniBlog.AssignRole:output_type -> v1.AssignRoleResponse
+	88, // 92: v1.MiniBlog.RevokeRole:output_type -> v1.RevokeRoleResponse
+	89, // 93: v1.MiniBlog.ExplainAuthorization:output_type -> v1.ExplainAuthorizationResponse
+	90, // 94: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
+	91, // 95: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
+	92, // 96: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
+	93, // 97: v1.MiniBlog.RestorePost:output_type -> v1.RestorePostResponse
+	94, // 98: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
+	95, // 99: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
+	50, // [50:100] is the sub-list for method output_type
+	0,  // [0:50] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -153,6 +375,13 @@ func file_apiserver_v1_apiserver_proto_init() {
 	file_apiserver_v1_healthz_proto_init()
 	file_apiserver_v1_post_proto_init()
 	file_apiserver_v1_user_proto_init()
+	file_apiserver_v1_access_token_proto_init()
+	file_apiserver_v1_totp_proto_init()
+	file_apiserver_v1_session_proto_init()
+	file_apiserver_v1_data_export_proto_init()
+	file_apiserver_v1_oidc_proto_init()
+	file_apiserver_v1_policy_proto_init()
+	file_apiserver_v1_permission_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
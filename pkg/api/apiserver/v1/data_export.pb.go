@@ -0,0 +1,371 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// DataExport API 定义, 包含导出用户个人数据的请求消息和归档内容
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiserver/v1/data_export.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DataExport 表示导出的个人数据归档, 归档格式变化时递增 version
+type DataExport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version 表示归档格式的版本
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// exportedAt 表示导出时间
+	ExportedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=exportedAt,proto3" json:"exportedAt,omitempty"`
+	// user 表示用户资料
+	User *User `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// posts 表示用户的全部博客
+	Posts []*Post `protobuf:"bytes,4,rep,name=posts,proto3" json:"posts,omitempty"`
+	// roles 表示分配给用户的角色
+	Roles []*RoleAssignment `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	// sessions 表示用户的全部登录会话, 包括已失效的会话
+	Sessions      []*Session `protobuf:"bytes,6,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_data_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DataExport) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DataExport) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *DataExport) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *DataExport) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *DataExport) GetRoles() []*RoleAssignment {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *DataExport) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// RoleAssignment 表示在某个租户中分配给用户的角色
+type RoleAssignment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// role 表示角色名称
+	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// domain 表示角色生效的租户, * 表示全部租户
+	Domain        string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleAssignment) Reset() {
+	*x = RoleAssignment{}
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleAssignment) ProtoMessage() {}
+
+func (x *RoleAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleAssignment.ProtoReflect.Descriptor instead.
+func (*RoleAssignment) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_data_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RoleAssignment) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RoleAssignment) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// ExportMyDataRequest 表示导出当前用户个人数据的请求
+type ExportMyDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// format 表示归档格式, 可选值为 json 和 zip, 默认为 json
+	// @gotags: form:"format"
+	Format        *string `protobuf:"bytes,1,opt,name=format,proto3,oneof" json:"format,omitempty" form:"format"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_data_export_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportMyDataRequest) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+// ExportUserDataRequest 表示导出指定用户个人数据的请求
+type ExportUserDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示需要导出数据的用户 ID
+	// @gotags: uri:"userID"
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	// format 表示归档格式, 可选值为 json 和 zip, 默认为 json
+	// @gotags: form:"format"
+	Format        *string `protobuf:"bytes,2,opt,name=format,proto3,oneof" json:"format,omitempty" form:"format"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_data_export_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_data_export_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExportUserDataRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *ExportUserDataRequest) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+var File_apiserver_v1_data_export_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_data_export_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapiserver/v1/data_export.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17apiserver/v1/post.proto\x1a\x1aapiserver/v1/session.proto\x1a\x17apiserver/v1/user.proto\"\xf3\x01\n" +
+	"\n" +
+	"DataExport\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12:\n" +
+	"\n" +
+	"exportedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\x12\x1c\n" +
+	"\x04user\x18\x03 \x01(\v2\b.v1.UserR\x04user\x12\x1e\n" +
+	"\x05posts\x18\x04 \x03(\v2\b.v1.PostR\x05posts\x12(\n" +
+	"\x05roles\x18\x05 \x03(\v2\x12.v1.RoleAssignmentR\x05roles\x12'\n" +
+	"\bsessions\x18\x06 \x03(\v2\v.v1.SessionR\bsessions\"<\n" +
+	"\x0eRoleAssignment\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"=\n" +
+	"\x13ExportMyDataRequest\x12\x1b\n" +
+	"\x06format\x18\x01 \x01(\tH\x00R\x06format\x88\x01\x01B\t\n" +
+	"\a_format\"W\n" +
+	"\x15ExportUserDataRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1b\n" +
+	"\x06format\x18\x02 \x01(\tH\x00R\x06format\x88\x01\x01B\t\n" +
+	"\a_formatB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_data_export_proto_rawDescOnce sync.Once
+	file_apiserver_v1_data_export_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_data_export_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_data_export_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_data_export_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_data_export_proto_rawDesc), len(file_apiserver_v1_data_export_proto_rawDesc)))
+	})
+	return file_apiserver_v1_data_export_proto_rawDescData
+}
+
+var file_apiserver_v1_data_export_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_apiserver_v1_data_export_proto_goTypes = []any{
+	(*DataExport)(nil),            // 0: v1.DataExport
+	(*RoleAssignment)(nil),        // 1: v1.RoleAssignment
+	(*ExportMyDataRequest)(nil),   // 2: v1.ExportMyDataRequest
+	(*ExportUserDataRequest)(nil), // 3: v1.ExportUserDataRequest
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*User)(nil),                  // 5: v1.User
+	(*Post)(nil),                  // 6: v1.Post
+	(*Session)(nil),               // 7: v1.Session
+}
+var file_apiserver_v1_data_export_proto_depIdxs = []int32{
+	4, // 0: v1.DataExport.exportedAt:type_name -> google.protobuf.Timestamp
+	5, // 1: v1.DataExport.user:type_name -> v1.User
+	6, // 2: v1.DataExport.posts:type_name -> v1.Post
+	1, // 3: v1.DataExport.roles:type_name -> v1.RoleAssignment
+	7, // 4: v1.DataExport.sessions:type_name -> v1.Session
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_data_export_proto_init() }
+func file_apiserver_v1_data_export_proto_init() {
+	if File_apiserver_v1_data_export_proto != nil {
+		return
+	}
+	file_apiserver_v1_post_proto_init()
+	file_apiserver_v1_session_proto_init()
+	file_apiserver_v1_user_proto_init()
+	file_apiserver_v1_data_export_proto_msgTypes[2].OneofWrappers = []any{}
+	file_apiserver_v1_data_export_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_data_export_proto_rawDesc), len(file_apiserver_v1_data_export_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_data_export_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_data_export_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_data_export_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_data_export_proto = out.File
+	file_apiserver_v1_data_export_proto_goTypes = nil
+	file_apiserver_v1_data_export_proto_depIdxs = nil
+}
@@ -0,0 +1,1312 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Policy API 定义, 包含授权策略和角色管理的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiserver/v1/policy.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Policy 表示一条授权策略
+type Policy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subject 表示策略作用的主体, 可以是用户 ID 或角色
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// object 表示访问的资源, 即权限名称中冒号前的部分, 例如 user, 支持 keyMatch 通配符
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// action 表示对资源执行的操作, 即权限名称中冒号后的部分, 例如 delete, * 表示全部操作
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// effect 表示策略的效果, 取值为 allow 或 deny
+	Effect string `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	// domain 表示策略所属的租户, * 表示全部租户, 为空时为当前用户所在的租户
+	Domain        string `protobuf:"bytes,5,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Policy) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Policy) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *Policy) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Policy) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *Policy) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// GroupingPolicy 表示一条角色继承规则, 主体继承角色的全部策略
+type GroupingPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subject 表示继承角色的主体, 可以是用户 ID 或角色
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// role 表示被继承的角色
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// domain 表示规则所属的租户, * 表示全部租户, 为空时为当前用户所在的租户
+	Domain        string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupingPolicy) Reset() {
+	*x = GroupingPolicy{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupingPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupingPolicy) ProtoMessage() {}
+
+func (x *GroupingPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupingPolicy.ProtoReflect.Descriptor instead.
+func (*GroupingPolicy) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GroupingPolicy) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *GroupingPolicy) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GroupingPolicy) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// ListPoliciesRequest 表示列出授权策略的请求
+type ListPoliciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subject 表示按主体过滤, 为空时返回全部策略
+	// @gotags: form:"subject"
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty" form:"subject"`
+	// domain 表示按租户过滤, 为空时为当前用户所在的租户
+	// @gotags: form:"domain"
+	Domain        string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty" form:"domain"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoliciesRequest) Reset() {
+	*x = ListPoliciesRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliciesRequest) ProtoMessage() {}
+
+func (x *ListPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPoliciesRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListPoliciesRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// ListPoliciesResponse 表示列出授权策略的响应
+type ListPoliciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// total_count 表示策略总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// policies 表示策略列表
+	Policies      []*Policy `protobuf:"bytes,2,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoliciesResponse) Reset() {
+	*x = ListPoliciesResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliciesResponse) ProtoMessage() {}
+
+func (x *ListPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPoliciesResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListPoliciesResponse) GetPolicies() []*Policy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+// AddPolicyRequest 表示添加授权策略的请求
+type AddPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// policy 表示要添加的策略
+	Policy        *Policy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPolicyRequest) Reset() {
+	*x = AddPolicyRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPolicyRequest) ProtoMessage() {}
+
+func (x *AddPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPolicyRequest.ProtoReflect.Descriptor instead.
+func (*AddPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddPolicyRequest) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// AddPolicyResponse 表示添加授权策略的响应
+type AddPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPolicyResponse) Reset() {
+	*x = AddPolicyResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPolicyResponse) ProtoMessage() {}
+
+func (x *AddPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPolicyResponse.ProtoReflect.Descriptor instead.
+func (*AddPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{5}
+}
+
+// RemovePolicyRequest 表示删除授权策略的请求
+type RemovePolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// policy 表示要删除的策略
+	Policy        *Policy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePolicyRequest) Reset() {
+	*x = RemovePolicyRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePolicyRequest) ProtoMessage() {}
+
+func (x *RemovePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePolicyRequest.ProtoReflect.Descriptor instead.
+func (*RemovePolicyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemovePolicyRequest) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// RemovePolicyResponse 表示删除授权策略的响应
+type RemovePolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePolicyResponse) Reset() {
+	*x = RemovePolicyResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePolicyResponse) ProtoMessage() {}
+
+func (x *RemovePolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePolicyResponse.ProtoReflect.Descriptor instead.
+func (*RemovePolicyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{7}
+}
+
+// ListGroupingPoliciesRequest 表示列出角色继承规则的请求
+type ListGroupingPoliciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subject 表示按主体过滤
+	// @gotags: form:"subject"
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty" form:"subject"`
+	// role 表示按角色过滤
+	// @gotags: form:"role"
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty" form:"role"`
+	// domain 表示按租户过滤, 为空时为当前用户所在的租户
+	// @gotags: form:"domain"
+	Domain        string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty" form:"domain"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupingPoliciesRequest) Reset() {
+	*x = ListGroupingPoliciesRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupingPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupingPoliciesRequest) ProtoMessage() {}
+
+func (x *ListGroupingPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupingPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupingPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListGroupingPoliciesRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListGroupingPoliciesRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListGroupingPoliciesRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// ListGroupingPoliciesResponse 表示列出角色继承规则的响应
+type ListGroupingPoliciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// total_count 表示规则总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// groupingPolicies 表示规则列表
+	GroupingPolicies []*GroupingPolicy `protobuf:"bytes,2,rep,name=groupingPolicies,proto3" json:"groupingPolicies,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListGroupingPoliciesResponse) Reset() {
+	*x = ListGroupingPoliciesResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupingPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupingPoliciesResponse) ProtoMessage() {}
+
+func (x *ListGroupingPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupingPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupingPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListGroupingPoliciesResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListGroupingPoliciesResponse) GetGroupingPolicies() []*GroupingPolicy {
+	if x != nil {
+		return x.GroupingPolicies
+	}
+	return nil
+}
+
+// AddGroupingPolicyRequest 表示添加角色继承规则的请求
+type AddGroupingPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// groupingPolicy 表示要添加的规则
+	GroupingPolicy *GroupingPolicy `protobuf:"bytes,1,opt,name=groupingPolicy,proto3" json:"groupingPolicy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddGroupingPolicyRequest) Reset() {
+	*x = AddGroupingPolicyRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupingPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupingPolicyRequest) ProtoMessage() {}
+
+func (x *AddGroupingPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupingPolicyRequest.ProtoReflect.Descriptor instead.
+func (*AddGroupingPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddGroupingPolicyRequest) GetGroupingPolicy() *GroupingPolicy {
+	if x != nil {
+		return x.GroupingPolicy
+	}
+	return nil
+}
+
+// AddGroupingPolicyResponse 表示添加角色继承规则的响应
+type AddGroupingPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupingPolicyResponse) Reset() {
+	*x = AddGroupingPolicyResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupingPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupingPolicyResponse) ProtoMessage() {}
+
+func (x *AddGroupingPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupingPolicyResponse.ProtoReflect.Descriptor instead.
+func (*AddGroupingPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{11}
+}
+
+// RemoveGroupingPolicyRequest 表示删除角色继承规则的请求
+type RemoveGroupingPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// groupingPolicy 表示要删除的规则
+	GroupingPolicy *GroupingPolicy `protobuf:"bytes,1,opt,name=groupingPolicy,proto3" json:"groupingPolicy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveGroupingPolicyRequest) Reset() {
+	*x = RemoveGroupingPolicyRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupingPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupingPolicyRequest) ProtoMessage() {}
+
+func (x *RemoveGroupingPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupingPolicyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveGroupingPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveGroupingPolicyRequest) GetGroupingPolicy() *GroupingPolicy {
+	if x != nil {
+		return x.GroupingPolicy
+	}
+	return nil
+}
+
+// RemoveGroupingPolicyResponse 表示删除角色继承规则的响应
+type RemoveGroupingPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupingPolicyResponse) Reset() {
+	*x = RemoveGroupingPolicyResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupingPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupingPolicyResponse) ProtoMessage() {}
+
+func (x *RemoveGroupingPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupingPolicyResponse.ProtoReflect.Descriptor instead.
+func (*RemoveGroupingPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{13}
+}
+
+// ListUserRolesRequest 表示列出用户角色的请求
+type ListUserRolesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserRolesRequest) Reset() {
+	*x = ListUserRolesRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserRolesRequest) ProtoMessage() {}
+
+func (x *ListUserRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserRolesRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListUserRolesRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// ListUserRolesResponse 表示列出用户角色的响应, 只包含用户在其所属租户中生效的角色
+type ListUserRolesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// roles 表示直接分配给用户的角色
+	Roles []string `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	// implicitRoles 表示用户通过角色继承间接拥有的全部角色
+	ImplicitRoles []string `protobuf:"bytes,2,rep,name=implicitRoles,proto3" json:"implicitRoles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserRolesResponse) Reset() {
+	*x = ListUserRolesResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserRolesResponse) ProtoMessage() {}
+
+func (x *ListUserRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListUserRolesResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListUserRolesResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ListUserRolesResponse) GetImplicitRoles() []string {
+	if x != nil {
+		return x.ImplicitRoles
+	}
+	return nil
+}
+
+// AssignRoleRequest 表示为用户分配角色的请求
+type AssignRoleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示用户 ID
+	// @gotags: uri:"userID"
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	// role 表示要分配的角色
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRoleRequest) Reset() {
+	*x = AssignRoleRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoleRequest) ProtoMessage() {}
+
+func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoleRequest.ProtoReflect.Descriptor instead.
+func (*AssignRoleRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AssignRoleRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *AssignRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// AssignRoleResponse 表示为用户分配角色的响应
+type AssignRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRoleResponse) Reset() {
+	*x = AssignRoleResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRoleResponse) ProtoMessage() {}
+
+func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRoleResponse.ProtoReflect.Descriptor instead.
+func (*AssignRoleResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{17}
+}
+
+// RevokeRoleRequest 表示收回用户角色的请求
+type RevokeRoleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示用户 ID
+	// @gotags: uri:"userID"
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	// role 表示要收回的角色
+	// @gotags: uri:"role"
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty" uri:"role"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleRequest) Reset() {
+	*x = RevokeRoleRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleRequest) ProtoMessage() {}
+
+func (x *RevokeRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRoleRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevokeRoleRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *RevokeRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// RevokeRoleResponse 表示收回用户角色的响应
+type RevokeRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleResponse) Reset() {
+	*x = RevokeRoleResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleResponse) ProtoMessage() {}
+
+func (x *RevokeRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleResponse.ProtoReflect.Descriptor instead.
+func (*RevokeRoleResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{19}
+}
+
+// ExplainAuthorizationRequest 表示解释授权结果的请求
+type ExplainAuthorizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subject 表示访问的主体, 通常是用户 ID
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// object 表示访问的资源, 例如权限 user:list 中的 user
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// action 表示对资源执行的操作, 例如权限 user:list 中的 list
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// domain 表示访问的租户, 为空时为当前用户所在的租户
+	Domain        string `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainAuthorizationRequest) Reset() {
+	*x = ExplainAuthorizationRequest{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainAuthorizationRequest) ProtoMessage() {}
+
+func (x *ExplainAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*ExplainAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ExplainAuthorizationRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ExplainAuthorizationRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ExplainAuthorizationRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ExplainAuthorizationRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// ExplainAuthorizationResponse 表示解释授权结果的响应
+type ExplainAuthorizationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// allowed 表示是否允许访问
+	Allowed bool `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// matchedPolicies 表示决定授权结果的策略, 没有策略命中时为空
+	MatchedPolicies []*Policy `protobuf:"bytes,2,rep,name=matchedPolicies,proto3" json:"matchedPolicies,omitempty"`
+	// roles 表示主体直接和间接拥有的全部角色
+	Roles         []string `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainAuthorizationResponse) Reset() {
+	*x = ExplainAuthorizationResponse{}
+	mi := &file_apiserver_v1_policy_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainAuthorizationResponse) ProtoMessage() {}
+
+func (x *ExplainAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_policy_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*ExplainAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_policy_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ExplainAuthorizationResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *ExplainAuthorizationResponse) GetMatchedPolicies() []*Policy {
+	if x != nil {
+		return x.MatchedPolicies
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+var File_apiserver_v1_policy_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_policy_proto_rawDesc = "" +
+	"\n" +
+	"\x19apiserver/v1/policy.proto\x12\x02v1\"\x82\x01\n" +
+	"\x06Policy\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06effect\x18\x04 \x01(\tR\x06effect\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\"V\n" +
+	"\x0eGroupingPolicy\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\"G\n" +
+	"\x13ListPoliciesRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"_\n" +
+	"\x14ListPoliciesResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12&\n" +
+	"\bpolicies\x18\x02 \x03(\v2\n" +
+	".v1.PolicyR\bpolicies\"6\n" +
+	"\x10AddPolicyRequest\x12\"\n" +
+	"\x06policy\x18\x01 \x01(\v2\n" +
+	".v1.PolicyR\x06policy\"\x13\n" +
+	"\x11AddPolicyResponse\"9\n" +
+	"\x13RemovePolicyRequest\x12\"\n" +
+	"\x06policy\x18\x01 \x01(\v2\n" +
+	".v1.PolicyR\x06policy\"\x16\n" +
+	"\x14RemovePolicyResponse\"c\n" +
+	"\x1bListGroupingPoliciesRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\"\x7f\n" +
+	"\x1cListGroupingPoliciesResponse\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12>\n" +
+	"\x10groupingPolicies\x18\x02 \x03(\v2\x12.v1.GroupingPolicyR\x10groupingPolicies\"V\n" +
+	"\x18AddGroupingPolicyRequest\x12:\n" +
+	"\x0egroupingPolicy\x18\x01 \x01(\v2\x12.v1.GroupingPolicyR\x0egroupingPolicy\"\x1b\n" +
+	"\x19AddGroupingPolicyResponse\"Y\n" +
+	"\x1bRemoveGroupingPolicyRequest\x12:\n" +
+	"\x0egroupingPolicy\x18\x01 \x01(\v2\x12.v1.GroupingPolicyR\x0egroupingPolicy\"\x1e\n" +
+	"\x1cRemoveGroupingPolicyResponse\".\n" +
+	"\x14ListUserRolesRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"S\n" +
+	"\x15ListUserRolesResponse\x12\x14\n" +
+	"\x05roles\x18\x01 \x03(\tR\x05roles\x12$\n" +
+	"\rimplicitRoles\x18\x02 \x03(\tR\rimplicitRoles\"?\n" +
+	"\x11AssignRoleRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"\x14\n" +
+	"\x12AssignRoleResponse\"?\n" +
+	"\x11RevokeRoleRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"\x14\n" +
+	"\x12RevokeRoleResponse\"\x7f\n" +
+	"\x1bExplainAuthorizationRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\"\x84\x01\n" +
+	"\x1cExplainAuthorizationResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x124\n" +
+	"\x0fmatchedPolicies\x18\x02 \x03(\v2\n" +
+	".v1.PolicyR\x0fmatchedPolicies\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05rolesB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_policy_proto_rawDescOnce sync.Once
+	file_apiserver_v1_policy_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_policy_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_policy_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_policy_proto_rawDesc), len(file_apiserver_v1_policy_proto_rawDesc)))
+	})
+	return file_apiserver_v1_policy_proto_rawDescData
+}
+
+var file_apiserver_v1_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_apiserver_v1_policy_proto_goTypes = []any{
+	(*Policy)(nil),                       // 0: v1.Policy
+	(*GroupingPolicy)(nil),               // 1: v1.GroupingPolicy
+	(*ListPoliciesRequest)(nil),          // 2: v1.ListPoliciesRequest
+	(*ListPoliciesResponse)(nil),         // 3: v1.ListPoliciesResponse
+	(*AddPolicyRequest)(nil),             // 4: v1.AddPolicyRequest
+	(*AddPolicyResponse)(nil),            // 5: v1.AddPolicyResponse
+	(*RemovePolicyRequest)(nil),          // 6: v1.RemovePolicyRequest
+	(*RemovePolicyResponse)(nil),         // 7: v1.RemovePolicyResponse
+	(*ListGroupingPoliciesRequest)(nil),  // 8: v1.ListGroupingPoliciesRequest
+	(*ListGroupingPoliciesResponse)(nil), // 9: v1.ListGroupingPoliciesResponse
+	(*AddGroupingPolicyRequest)(nil),     // 10: v1.AddGroupingPolicyRequest
+	(*AddGroupingPolicyResponse)(nil),    // 11: v1.AddGroupingPolicyResponse
+	(*RemoveGroupingPolicyRequest)(nil),  // 12: v1.RemoveGroupingPolicyRequest
+	(*RemoveGroupingPolicyResponse)(nil), // 13: v1.RemoveGroupingPolicyResponse
+	(*ListUserRolesRequest)(nil),         // 14: v1.ListUserRolesRequest
+	(*ListUserRolesResponse)(nil),        // 15: v1.ListUserRolesResponse
+	(*AssignRoleRequest)(nil),            // 16: v1.AssignRoleRequest
+	(*AssignRoleResponse)(nil),           // 17: v1.AssignRoleResponse
+	(*RevokeRoleRequest)(nil),            // 18: v1.RevokeRoleRequest
+	(*RevokeRoleResponse)(nil),           // 19: v1.RevokeRoleResponse
+	(*ExplainAuthorizationRequest)(nil),  // 20: v1.ExplainAuthorizationRequest
+	(*ExplainAuthorizationResponse)(nil), // 21: v1.ExplainAuthorizationResponse
+}
+var file_apiserver_v1_policy_proto_depIdxs = []int32{
+	0, // 0: v1.ListPoliciesResponse.policies:type_name -> v1.Policy
+	0, // 1: v1.AddPolicyRequest.policy:type_name -> v1.Policy
+	0, // 2: v1.RemovePolicyRequest.policy:type_name -> v1.Policy
+	1, // 3: v1.ListGroupingPoliciesResponse.groupingPolicies:type_name -> v1.GroupingPolicy
+	1, // 4: v1.AddGroupingPolicyRequest.groupingPolicy:type_name -> v1.GroupingPolicy
+	1, // 5: v1.RemoveGroupingPolicyRequest.groupingPolicy:type_name -> v1.GroupingPolicy
+	0, // 6: v1.ExplainAuthorizationResponse.matchedPolicies:type_name -> v1.Policy
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_policy_proto_init() }
+func file_apiserver_v1_policy_proto_init() {
+	if File_apiserver_v1_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_policy_proto_rawDesc), len(file_apiserver_v1_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_policy_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_policy_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_policy_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_policy_proto = out.File
+	file_apiserver_v1_policy_proto_goTypes = nil
+	file_apiserver_v1_policy_proto_depIdxs = nil
+}
@@ -42,6 +42,12 @@ func (x *DeletePostRequest) Default() {
 func (x *DeletePostResponse) Default() {
 }
 
+func (x *RestorePostRequest) Default() {
+}
+
+func (x *RestorePostResponse) Default() {
+}
+
 func (x *GetPostRequest) Default() {
 }
 
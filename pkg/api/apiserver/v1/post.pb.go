@@ -394,6 +394,90 @@ func (*DeletePostResponse) Descriptor() ([]byte, []int) {
 	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{6}
 }
 
+// RestorePostRequest 表示恢复已删除文章的请求
+type RestorePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示需要恢复的文章 ID
+	// @gotags: uri:"postID"
+	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePostRequest) Reset() {
+	*x = RestorePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePostRequest) ProtoMessage() {}
+
+func (x *RestorePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePostRequest.ProtoReflect.Descriptor instead.
+func (*RestorePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RestorePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+// RestorePostResponse 表示恢复已删除文章的响应
+type RestorePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePostResponse) Reset() {
+	*x = RestorePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePostResponse) ProtoMessage() {}
+
+func (x *RestorePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePostResponse.ProtoReflect.Descriptor instead.
+func (*RestorePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{8}
+}
+
 // GetPostRequest 表示获取文章请求
 type GetPostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -406,7 +490,7 @@ type GetPostRequest struct {
 
 func (x *GetPostRequest) Reset() {
 	*x = GetPostRequest{}
-	mi := &file_apiserver_v1_post_proto_msgTypes[7]
+	mi := &file_apiserver_v1_post_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -418,7 +502,7 @@ func (x *GetPostRequest) String() string {
 func (*GetPostRequest) ProtoMessage() {}
 
 func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_post_proto_msgTypes[7]
+	mi := &file_apiserver_v1_post_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,7 +515,7 @@ func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
 func (*GetPostRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{7}
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPostRequest) GetPostID() string {
@@ -452,7 +536,7 @@ type GetPostResponse struct {
 
 func (x *GetPostResponse) Reset() {
 	*x = GetPostResponse{}
-	mi := &file_apiserver_v1_post_proto_msgTypes[8]
+	mi := &file_apiserver_v1_post_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -464,7 +548,7 @@ func (x *GetPostResponse) String() string {
 func (*GetPostResponse) ProtoMessage() {}
 
 func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_post_proto_msgTypes[8]
+	mi := &file_apiserver_v1_post_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +561,7 @@ func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostResponse.ProtoReflect.Descriptor instead.
 func (*GetPostResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{8}
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetPostResponse) GetPost() *Post {
@@ -504,7 +588,7 @@ type ListPostRequest struct {
 
 func (x *ListPostRequest) Reset() {
 	*x = ListPostRequest{}
-	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	mi := &file_apiserver_v1_post_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -516,7 +600,7 @@ func (x *ListPostRequest) String() string {
 func (*ListPostRequest) ProtoMessage() {}
 
 func (x *ListPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	mi := &file_apiserver_v1_post_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,7 +613,7 @@ func (x *ListPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPostRequest.ProtoReflect.Descriptor instead.
 func (*ListPostRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{9}
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListPostRequest) GetOffset() int64 {
@@ -566,7 +650,7 @@ type ListPostResponse struct {
 
 func (x *ListPostResponse) Reset() {
 	*x = ListPostResponse{}
-	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	mi := &file_apiserver_v1_post_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +662,7 @@ func (x *ListPostResponse) String() string {
 func (*ListPostResponse) ProtoMessage() {}
 
 func (x *ListPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	mi := &file_apiserver_v1_post_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +675,7 @@ func (x *ListPostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPostResponse.ProtoReflect.Descriptor instead.
 func (*ListPostResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{10}
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListPostResponse) GetTotalCount() int64 {
@@ -635,7 +719,10 @@ const file_apiserver_v1_post_proto_rawDesc = "" +
 	"\x12UpdatePostResponse\"-\n" +
 	"\x11DeletePostRequest\x12\x18\n" +
 	"\apostIDs\x18\x01 \x03(\tR\apostIDs\"\x14\n" +
-	"\x12DeletePostResponse\"(\n" +
+	"\x12DeletePostResponse\",\n" +
+	"\x12RestorePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\x15\n" +
+	"\x13RestorePostResponse\"(\n" +
 	"\x0eGetPostRequest\x12\x16\n" +
 	"\x06postID\x18\x01 \x01(\tR\x06postID\"/\n" +
 	"\x0fGetPostResponse\x12\x1c\n" +
@@ -662,7 +749,7 @@ func file_apiserver_v1_post_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_post_proto_rawDescData
 }
 
-var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_apiserver_v1_post_proto_goTypes = []any{
 	(*Post)(nil),                  // 0: v1.Post
 	(*CreatePostRequest)(nil),     // 1: v1.CreatePostRequest
@@ -671,15 +758,17 @@ var file_apiserver_v1_post_proto_goTypes = []any{
 	(*UpdatePostResponse)(nil),    // 4: v1.UpdatePostResponse
 	(*DeletePostRequest)(nil),     // 5: v1.DeletePostRequest
 	(*DeletePostResponse)(nil),    // 6: v1.DeletePostResponse
-	(*GetPostRequest)(nil),        // 7: v1.GetPostRequest
-	(*GetPostResponse)(nil),       // 8: v1.GetPostResponse
-	(*ListPostRequest)(nil),       // 9: v1.ListPostRequest
-	(*ListPostResponse)(nil),      // 10: v1.ListPostResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*RestorePostRequest)(nil),    // 7: v1.RestorePostRequest
+	(*RestorePostResponse)(nil),   // 8: v1.RestorePostResponse
+	(*GetPostRequest)(nil),        // 9: v1.GetPostRequest
+	(*GetPostResponse)(nil),       // 10: v1.GetPostResponse
+	(*ListPostRequest)(nil),       // 11: v1.ListPostRequest
+	(*ListPostResponse)(nil),      // 12: v1.ListPostResponse
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
 }
 var file_apiserver_v1_post_proto_depIdxs = []int32{
-	11, // 0: v1.Post.createdAt:type_name -> google.protobuf.Timestamp
-	11, // 1: v1.Post.updateAt:type_name -> google.protobuf.Timestamp
+	13, // 0: v1.Post.createdAt:type_name -> google.protobuf.Timestamp
+	13, // 1: v1.Post.updateAt:type_name -> google.protobuf.Timestamp
 	0,  // 2: v1.GetPostResponse.post:type_name -> v1.Post
 	0,  // 3: v1.ListPostResponse.posts:type_name -> v1.Post
 	4,  // [4:4] is the sub-list for method output_type
@@ -695,14 +784,14 @@ func file_apiserver_v1_post_proto_init() {
 		return
 	}
 	file_apiserver_v1_post_proto_msgTypes[3].OneofWrappers = []any{}
-	file_apiserver_v1_post_proto_msgTypes[9].OneofWrappers = []any{}
+	file_apiserver_v1_post_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_post_proto_rawDesc), len(file_apiserver_v1_post_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -34,18 +34,78 @@ func (x *LoginRequest) Default() {
 func (x *LoginResponse) Default() {
 }
 
+func (x *VerifyLoginRequest) Default() {
+}
+
+func (x *VerifyLoginResponse) Default() {
+}
+
 func (x *RefreshTokenRequest) Default() {
 }
 
 func (x *RefreshTokenResponse) Default() {
 }
 
+func (x *LogoutRequest) Default() {
+}
+
+func (x *LogoutResponse) Default() {
+}
+
+func (x *RevokeTokensRequest) Default() {
+}
+
+func (x *RevokeTokensResponse) Default() {
+}
+
+func (x *ImpersonateRequest) Default() {
+}
+
+func (x *ImpersonateResponse) Default() {
+}
+
+func (x *UnlockUserRequest) Default() {
+}
+
+func (x *UnlockUserResponse) Default() {
+}
+
+func (x *UpdateUserStatusRequest) Default() {
+}
+
+func (x *UpdateUserStatusResponse) Default() {
+}
+
 func (x *ChangePasswordRequest) Default() {
 }
 
 func (x *ChangePasswordResponse) Default() {
 }
 
+func (x *RequestPasswordResetRequest) Default() {
+}
+
+func (x *RequestPasswordResetResponse) Default() {
+}
+
+func (x *ResetPasswordRequest) Default() {
+}
+
+func (x *ResetPasswordResponse) Default() {
+}
+
+func (x *VerifyEmailRequest) Default() {
+}
+
+func (x *VerifyEmailResponse) Default() {
+}
+
+func (x *SendVerificationEmailRequest) Default() {
+}
+
+func (x *SendVerificationEmailResponse) Default() {
+}
+
 func (x *CreateUserRequest) Default() {
 	if x.Nickname == nil {
 		v := string("你好世界")
@@ -68,6 +128,12 @@ func (x *DeleteUserRequest) Default() {
 func (x *DeleteUserResponse) Default() {
 }
 
+func (x *RestoreUserRequest) Default() {
+}
+
+func (x *RestoreUserResponse) Default() {
+}
+
 func (x *GetUserRequest) Default() {
 }
 
@@ -48,7 +48,19 @@ type User struct {
 	// createdAt 表示用户注册时间
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	// updatedAt 表示用户最后更新时间
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// emailVerified 表示用户电子邮箱是否已验证
+	EmailVerified bool `protobuf:"varint,9,opt,name=emailVerified,proto3" json:"emailVerified,omitempty"`
+	// verifiedAt 表示用户电子邮箱验证时间, 未验证时为空
+	VerifiedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=verifiedAt,proto3" json:"verifiedAt,omitempty"`
+	// tenantID 表示用户所属的租户
+	TenantID string `protobuf:"bytes,11,opt,name=tenantID,proto3" json:"tenantID,omitempty"`
+	// status 表示用户状态, 可选值为 active(正常), disabled(已禁用) 和 banned(已封禁)
+	Status string `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
+	// statusReason 表示禁用或封禁用户的原因
+	StatusReason string `protobuf:"bytes,13,opt,name=statusReason,proto3" json:"statusReason,omitempty"`
+	// statusUntil 表示封禁的截止时间, 永久封禁或者用户没有被封禁时为空
+	StatusUntil   *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=statusUntil,proto3" json:"statusUntil,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -139,6 +151,48 @@ func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *User) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
+func (x *User) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *User) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *User) GetStatusUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusUntil
+	}
+	return nil
+}
+
 // LoginRequest 表示登录请求
 type LoginRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -200,9 +254,19 @@ type LoginResponse struct {
 	// token 表示返回的身份验证令牌
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// expireAt 表示该 token 的过期时间
-	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// refreshToken 表示用于换取新令牌的刷新令牌
+	RefreshToken string `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	// refreshExpireAt 表示刷新令牌的过期时间
+	RefreshExpireAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=refreshExpireAt,proto3" json:"refreshExpireAt,omitempty"`
+	// mfaRequired 表示用户开启了两步验证, 需要使用 challengeToken 调用 VerifyLogin 完成登录, 此时不返回令牌
+	MfaRequired bool `protobuf:"varint,5,opt,name=mfaRequired,proto3" json:"mfaRequired,omitempty"`
+	// challengeToken 表示两步登录的挑战令牌, 只能用于 VerifyLogin
+	ChallengeToken string `protobuf:"bytes,6,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	// challengeExpireAt 表示挑战令牌的过期时间
+	ChallengeExpireAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=challengeExpireAt,proto3" json:"challengeExpireAt,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -249,28 +313,1013 @@ func (x *LoginResponse) GetExpireAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshExpireAt
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetChallengeExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChallengeExpireAt
+	}
+	return nil
+}
+
+// VerifyLoginRequest 表示两步登录第二步的请求
+type VerifyLoginRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// challengeToken 表示 Login 返回的挑战令牌
+	ChallengeToken string `protobuf:"bytes,1,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	// code 表示身份验证器生成的一次性密码, 或者一个未使用的恢复码
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLoginRequest) Reset() {
+	*x = VerifyLoginRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginRequest) ProtoMessage() {}
+
+func (x *VerifyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginRequest.ProtoReflect.Descriptor instead.
+func (*VerifyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyLoginRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *VerifyLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// VerifyLoginResponse 表示两步登录第二步的响应
+type VerifyLoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示返回的身份验证令牌
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// expireAt 表示该 token 的过期时间
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// refreshToken 表示用于换取新令牌的刷新令牌
+	RefreshToken string `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	// refreshExpireAt 表示刷新令牌的过期时间
+	RefreshExpireAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=refreshExpireAt,proto3" json:"refreshExpireAt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyLoginResponse) Reset() {
+	*x = VerifyLoginResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginResponse) ProtoMessage() {}
+
+func (x *VerifyLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginResponse.ProtoReflect.Descriptor instead.
+func (*VerifyLoginResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VerifyLoginResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VerifyLoginResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *VerifyLoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *VerifyLoginResponse) GetRefreshExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshExpireAt
+	}
+	return nil
+}
+
 // RefreshTokenRequest 表示刷新令牌的请求
 type RefreshTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// refreshToken 表示登录或上一次刷新时返回的刷新令牌, 每个刷新令牌只能使用一次
+	RefreshToken  string `protobuf:"bytes,1,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// RefreshTokenResponse 表示刷新令牌的响应
+type RefreshTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示返回的身份验证令牌
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// expireAt 表示该 token 的过期时间
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// refreshToken 表示轮换后的新刷新令牌, 旧的刷新令牌随即失效
+	RefreshToken string `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	// refreshExpireAt 表示新刷新令牌的过期时间
+	RefreshExpireAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=refreshExpireAt,proto3" json:"refreshExpireAt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RefreshTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetRefreshExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshExpireAt
+	}
+	return nil
+}
+
+// LogoutRequest 表示登出请求
+type LogoutRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// refreshToken 表示需要一并吊销的刷新令牌, 为空时只吊销当前访问令牌
+	RefreshToken  string `protobuf:"bytes,1,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// LogoutResponse 表示登出响应
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{8}
+}
+
+// RevokeTokensRequest 表示吊销用户全部令牌的请求
+type RevokeTokensRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示需要吊销令牌的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokensRequest) Reset() {
+	*x = RevokeTokensRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokensRequest) ProtoMessage() {}
+
+func (x *RevokeTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokensRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokensRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RevokeTokensRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// RevokeTokensResponse 表示吊销用户全部令牌的响应
+type RevokeTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokensResponse) Reset() {
+	*x = RevokeTokensResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokensResponse) ProtoMessage() {}
+
+func (x *RevokeTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokensResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokensResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+// ImpersonateRequest 表示管理员模拟指定用户登录的请求
+type ImpersonateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示需要模拟的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateRequest) Reset() {
+	*x = ImpersonateRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateRequest) ProtoMessage() {}
+
+func (x *ImpersonateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImpersonateRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// ImpersonateResponse 表示模拟登录的响应
+type ImpersonateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示以被模拟用户身份访问的令牌, 令牌中同时记录了实际操作的管理员
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// expireAt 表示该 token 的过期时间
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateResponse) Reset() {
+	*x = ImpersonateResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateResponse) ProtoMessage() {}
+
+func (x *ImpersonateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ImpersonateResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+// UnlockUserRequest 表示解除用户登录锁定的请求
+type UnlockUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示需要解除登录锁定的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserRequest) Reset() {
+	*x = UnlockUserRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserRequest) ProtoMessage() {}
+
+func (x *UnlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnlockUserRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// UnlockUserResponse 表示解除用户登录锁定的响应
+type UnlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserResponse) Reset() {
+	*x = UnlockUserResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserResponse) ProtoMessage() {}
+
+func (x *UnlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{14}
+}
+
+// UpdateUserStatusRequest 表示修改用户状态的请求
+type UpdateUserStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示需要修改状态的用户 ID
+	// @gotags: uri:"userID"
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	// status 表示新的用户状态, 可选值为 active(重新启用), disabled(禁用) 和 banned(封禁)
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// reason 表示禁用或封禁用户的原因
+	Reason *string `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	// until 表示封禁的截止时间(Unix 时间戳, 单位秒), 只能在封禁用户时指定, 为空时表示永久封禁
+	Until         *int64 `protobuf:"varint,4,opt,name=until,proto3,oneof" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserStatusRequest) Reset() {
+	*x = UpdateUserStatusRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserStatusRequest) ProtoMessage() {}
+
+func (x *UpdateUserStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserStatusRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateUserStatusRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UpdateUserStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateUserStatusRequest) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateUserStatusRequest) GetUntil() int64 {
+	if x != nil && x.Until != nil {
+		return *x.Until
+	}
+	return 0
+}
+
+// UpdateUserStatusResponse 表示修改用户状态的响应
+type UpdateUserStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserStatusResponse) Reset() {
+	*x = UpdateUserStatusResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserStatusResponse) ProtoMessage() {}
+
+func (x *UpdateUserStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserStatusResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+// ChangePasswordRequest 表示修改密码请求
+type ChangePasswordRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示用户 ID
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// oldPassword 表示当前密码
+	OldPassword string `protobuf:"bytes,2,opt,name=oldPassword,proto3" json:"oldPassword,omitempty"`
+	// newPassword 表示准备修改的新密码
+	NewPassword   string `protobuf:"bytes,3,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChangePasswordRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// ChangePasswordResponse 表示修改密码响应
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+// RequestPasswordResetRequest 表示申请重置密码请求
+type RequestPasswordResetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// email 表示用户注册时使用的电子邮箱, 重置链接会发送到该邮箱
+	Email         string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// RequestPasswordResetResponse 表示申请重置密码响应, 无论邮箱是否存在都返回相同的响应
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+// ResetPasswordRequest 表示重置密码请求
+type ResetPasswordRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示邮件中的重置令牌
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// newPassword 表示新密码
+	NewPassword   string `protobuf:"bytes,2,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// ResetPasswordResponse 表示重置密码响应
+type ResetPasswordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[3]
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *ResetPasswordResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*ResetPasswordResponse) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[3]
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,37 +1330,35 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{22}
 }
 
-// RefreshTokenResponse 表示刷新令牌的响应
-type RefreshTokenResponse struct {
+// VerifyEmailRequest 表示验证电子邮箱请求
+type VerifyEmailRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// token 表示返回的身份验证令牌
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	// expireAt 表示该 token 的过期时间
-	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// token 表示验证邮件中的验证令牌
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenResponse) Reset() {
-	*x = RefreshTokenResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[4]
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenResponse) String() string {
+func (x *VerifyEmailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenResponse) ProtoMessage() {}
+func (*VerifyEmailRequest) ProtoMessage() {}
 
-func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[4]
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,53 +1369,40 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RefreshTokenResponse) GetToken() string {
+func (x *VerifyEmailRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *RefreshTokenResponse) GetExpireAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpireAt
-	}
-	return nil
-}
-
-// ChangePasswordRequest 表示修改密码请求
-type ChangePasswordRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// userID 表示用户 ID
-	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	// oldPassword 表示当前密码
-	OldPassword string `protobuf:"bytes,2,opt,name=oldPassword,proto3" json:"oldPassword,omitempty"`
-	// newPassword 表示准备修改的新密码
-	NewPassword   string `protobuf:"bytes,3,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
+// VerifyEmailResponse 表示验证电子邮箱响应
+type VerifyEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[5]
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePasswordRequest) String() string {
+func (x *VerifyEmailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordRequest) ProtoMessage() {}
+func (*VerifyEmailResponse) ProtoMessage() {}
 
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[5]
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,54 +1413,70 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ChangePasswordRequest) GetUserID() string {
-	if x != nil {
-		return x.UserID
-	}
-	return ""
+// SendVerificationEmailRequest 表示为当前用户重新发送验证邮件请求
+type SendVerificationEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePasswordRequest) GetOldPassword() string {
-	if x != nil {
-		return x.OldPassword
-	}
-	return ""
+func (x *SendVerificationEmailRequest) Reset() {
+	*x = SendVerificationEmailRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePasswordRequest) GetNewPassword() string {
+func (x *SendVerificationEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendVerificationEmailRequest) ProtoMessage() {}
+
+func (x *SendVerificationEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[25]
 	if x != nil {
-		return x.NewPassword
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-// ChangePasswordResponse 表示修改密码响应
-type ChangePasswordResponse struct {
+// Deprecated: Use SendVerificationEmailRequest.ProtoReflect.Descriptor instead.
+func (*SendVerificationEmailRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{25}
+}
+
+// SendVerificationEmailResponse 表示重新发送验证邮件响应
+type SendVerificationEmailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePasswordResponse) Reset() {
-	*x = ChangePasswordResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[6]
+func (x *SendVerificationEmailResponse) Reset() {
+	*x = SendVerificationEmailResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePasswordResponse) String() string {
+func (x *SendVerificationEmailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordResponse) ProtoMessage() {}
+func (*SendVerificationEmailResponse) ProtoMessage() {}
 
-func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[6]
+func (x *SendVerificationEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -437,9 +1487,9 @@ func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
-func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SendVerificationEmailResponse.ProtoReflect.Descriptor instead.
+func (*SendVerificationEmailResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{26}
 }
 
 // CreateUserRequest 表示创建用户请求
@@ -454,14 +1504,16 @@ type CreateUserRequest struct {
 	// email 表示用户电子邮箱
 	Email string `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
 	// phone 表示用户手机号
-	Phone         string `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	Phone string `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	// tenantID 表示用户所属的租户, 为空时属于默认租户, 只能选择服务端配置中开放注册的租户
+	TenantID      *string `protobuf:"bytes,6,opt,name=tenantID,proto3,oneof" json:"tenantID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[7]
+	mi := &file_apiserver_v1_user_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -473,7 +1525,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[7]
+	mi := &file_apiserver_v1_user_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,7 +1538,7 @@ func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
 func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{7}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CreateUserRequest) GetUsername() string {
@@ -524,6 +1576,13 @@ func (x *CreateUserRequest) GetPhone() string {
 	return ""
 }
 
+func (x *CreateUserRequest) GetTenantID() string {
+	if x != nil && x.TenantID != nil {
+		return *x.TenantID
+	}
+	return ""
+}
+
 // CreateUserResponse 表示创建用户响应
 type CreateUserResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -535,7 +1594,7 @@ type CreateUserResponse struct {
 
 func (x *CreateUserResponse) Reset() {
 	*x = CreateUserResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[8]
+	mi := &file_apiserver_v1_user_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +1606,7 @@ func (x *CreateUserResponse) String() string {
 func (*CreateUserResponse) ProtoMessage() {}
 
 func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[8]
+	mi := &file_apiserver_v1_user_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +1619,7 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{8}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CreateUserResponse) GetUserID() string {
@@ -589,7 +1648,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[9]
+	mi := &file_apiserver_v1_user_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -601,7 +1660,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[9]
+	mi := &file_apiserver_v1_user_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,7 +1673,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{9}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *UpdateUserRequest) GetUserID() string {
@@ -661,7 +1720,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[10]
+	mi := &file_apiserver_v1_user_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -673,7 +1732,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[10]
+	mi := &file_apiserver_v1_user_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,7 +1745,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{10}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{30}
 }
 
 // DeleteUserRequest 表示删除用户请求
@@ -701,7 +1760,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[11]
+	mi := &file_apiserver_v1_user_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -713,7 +1772,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[11]
+	mi := &file_apiserver_v1_user_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -726,7 +1785,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{11}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *DeleteUserRequest) GetUserID() string {
@@ -736,16 +1795,26 @@ func (x *DeleteUserRequest) GetUserID() string {
 	return ""
 }
 
-// DeleteUserResponse 表示删除用户响应
+// DeleteUserResponse 表示删除用户响应, 包含随用户一起删除的数据的数量
 type DeleteUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// deletedPosts 表示删除的博客数量
+	DeletedPosts int64 `protobuf:"varint,1,opt,name=deletedPosts,proto3" json:"deletedPosts,omitempty"`
+	// deletedPolicies 表示删除的授权策略和角色继承规则数量
+	DeletedPolicies int64 `protobuf:"varint,2,opt,name=deletedPolicies,proto3" json:"deletedPolicies,omitempty"`
+	// deletedAccessTokens 表示删除的个人访问令牌数量
+	DeletedAccessTokens int64 `protobuf:"varint,3,opt,name=deletedAccessTokens,proto3" json:"deletedAccessTokens,omitempty"`
+	// revokedRefreshTokens 表示吊销的刷新令牌数量
+	RevokedRefreshTokens int64 `protobuf:"varint,4,opt,name=revokedRefreshTokens,proto3" json:"revokedRefreshTokens,omitempty"`
+	// revokedSessions 表示吊销的登录会话数量
+	RevokedSessions int64 `protobuf:"varint,5,opt,name=revokedSessions,proto3" json:"revokedSessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[12]
+	mi := &file_apiserver_v1_user_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -757,7 +1826,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[12]
+	mi := &file_apiserver_v1_user_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,7 +1839,126 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{12}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteUserResponse) GetDeletedPosts() int64 {
+	if x != nil {
+		return x.DeletedPosts
+	}
+	return 0
+}
+
+func (x *DeleteUserResponse) GetDeletedPolicies() int64 {
+	if x != nil {
+		return x.DeletedPolicies
+	}
+	return 0
+}
+
+func (x *DeleteUserResponse) GetDeletedAccessTokens() int64 {
+	if x != nil {
+		return x.DeletedAccessTokens
+	}
+	return 0
+}
+
+func (x *DeleteUserResponse) GetRevokedRefreshTokens() int64 {
+	if x != nil {
+		return x.RevokedRefreshTokens
+	}
+	return 0
+}
+
+func (x *DeleteUserResponse) GetRevokedSessions() int64 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+// RestoreUserRequest 表示恢复已删除用户的请求
+type RestoreUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示需要恢复的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RestoreUserRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// RestoreUserResponse 表示恢复已删除用户的响应
+type RestoreUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserResponse) ProtoMessage() {}
+
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{34}
 }
 
 // GetUserRequest 表示获取用户请求
@@ -785,7 +1973,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[13]
+	mi := &file_apiserver_v1_user_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -797,7 +1985,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[13]
+	mi := &file_apiserver_v1_user_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -810,7 +1998,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{13}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetUserRequest) GetUserID() string {
@@ -831,7 +2019,7 @@ type GetUserResponse struct {
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[14]
+	mi := &file_apiserver_v1_user_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -843,7 +2031,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[14]
+	mi := &file_apiserver_v1_user_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,7 +2044,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{14}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetUserResponse) GetUser() *User {
@@ -874,14 +2062,38 @@ type ListUserRequest struct {
 	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty" form:"offset"`
 	// limit 表示每页数量
 	// @gotags: form:"limit"
-	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
+	// usernamePrefix 表示可选的用户名前缀过滤
+	// @gotags: form:"usernamePrefix"
+	UsernamePrefix *string `protobuf:"bytes,3,opt,name=usernamePrefix,proto3,oneof" json:"usernamePrefix,omitempty" form:"usernamePrefix"`
+	// emailDomain 表示可选的邮箱域名过滤, 例如 example.com
+	// @gotags: form:"emailDomain"
+	EmailDomain *string `protobuf:"bytes,4,opt,name=emailDomain,proto3,oneof" json:"emailDomain,omitempty" form:"emailDomain"`
+	// createdAfter 表示只返回在该时间(Unix 时间戳, 单位秒)及之后创建的用户
+	// @gotags: form:"createdAfter"
+	CreatedAfter *int64 `protobuf:"varint,5,opt,name=createdAfter,proto3,oneof" json:"createdAfter,omitempty" form:"createdAfter"`
+	// createdBefore 表示只返回在该时间(Unix 时间戳, 单位秒)之前创建的用户
+	// @gotags: form:"createdBefore"
+	CreatedBefore *int64 `protobuf:"varint,6,opt,name=createdBefore,proto3,oneof" json:"createdBefore,omitempty" form:"createdBefore"`
+	// emailVerified 表示可选的邮箱验证状态过滤
+	// @gotags: form:"emailVerified"
+	EmailVerified *bool `protobuf:"varint,7,opt,name=emailVerified,proto3,oneof" json:"emailVerified,omitempty" form:"emailVerified"`
+	// sortBy 表示排序字段, 可选值为 createdAt, username 和 postCount, 为空时按创建顺序倒序排列
+	// @gotags: form:"sortBy"
+	SortBy *string `protobuf:"bytes,8,opt,name=sortBy,proto3,oneof" json:"sortBy,omitempty" form:"sortBy"`
+	// sortOrder 表示排序方向, 可选值为 asc 和 desc, 默认为 asc
+	// @gotags: form:"sortOrder"
+	SortOrder *string `protobuf:"bytes,9,opt,name=sortOrder,proto3,oneof" json:"sortOrder,omitempty" form:"sortOrder"`
+	// status 表示可选的用户状态过滤, 可选值为 active, disabled 和 banned
+	// @gotags: form:"status"
+	Status        *string `protobuf:"bytes,10,opt,name=status,proto3,oneof" json:"status,omitempty" form:"status"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListUserRequest) Reset() {
 	*x = ListUserRequest{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[15]
+	mi := &file_apiserver_v1_user_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -893,7 +2105,7 @@ func (x *ListUserRequest) String() string {
 func (*ListUserRequest) ProtoMessage() {}
 
 func (x *ListUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[15]
+	mi := &file_apiserver_v1_user_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -906,7 +2118,7 @@ func (x *ListUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUserRequest.ProtoReflect.Descriptor instead.
 func (*ListUserRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{15}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ListUserRequest) GetOffset() int64 {
@@ -923,6 +2135,62 @@ func (x *ListUserRequest) GetLimit() int64 {
 	return 0
 }
 
+func (x *ListUserRequest) GetUsernamePrefix() string {
+	if x != nil && x.UsernamePrefix != nil {
+		return *x.UsernamePrefix
+	}
+	return ""
+}
+
+func (x *ListUserRequest) GetEmailDomain() string {
+	if x != nil && x.EmailDomain != nil {
+		return *x.EmailDomain
+	}
+	return ""
+}
+
+func (x *ListUserRequest) GetCreatedAfter() int64 {
+	if x != nil && x.CreatedAfter != nil {
+		return *x.CreatedAfter
+	}
+	return 0
+}
+
+func (x *ListUserRequest) GetCreatedBefore() int64 {
+	if x != nil && x.CreatedBefore != nil {
+		return *x.CreatedBefore
+	}
+	return 0
+}
+
+func (x *ListUserRequest) GetEmailVerified() bool {
+	if x != nil && x.EmailVerified != nil {
+		return *x.EmailVerified
+	}
+	return false
+}
+
+func (x *ListUserRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *ListUserRequest) GetSortOrder() string {
+	if x != nil && x.SortOrder != nil {
+		return *x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListUserRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
 // ListUserResponse 表示用户列表响应
 type ListUserResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -936,7 +2204,7 @@ type ListUserResponse struct {
 
 func (x *ListUserResponse) Reset() {
 	*x = ListUserResponse{}
-	mi := &file_apiserver_v1_user_proto_msgTypes[16]
+	mi := &file_apiserver_v1_user_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -948,7 +2216,7 @@ func (x *ListUserResponse) String() string {
 func (*ListUserResponse) ProtoMessage() {}
 
 func (x *ListUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_user_proto_msgTypes[16]
+	mi := &file_apiserver_v1_user_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,7 +2229,7 @@ func (x *ListUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUserResponse.ProtoReflect.Descriptor instead.
 func (*ListUserResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{16}
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ListUserResponse) GetTotalCount() int64 {
@@ -982,7 +2250,7 @@ var File_apiserver_v1_user_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x17apiserver/v1/user.proto\x12\x02v1\x1a,github.com/onexstack/defaults/defaults.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x02\n" +
+	"\x17apiserver/v1/user.proto\x12\x02v1\x1a,github.com/onexstack/defaults/defaults.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb0\x04\n" +
 	"\x04User\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x121\n" +
@@ -991,30 +2259,91 @@ const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x1c\n" +
 	"\tpostCount\x18\x06 \x01(\x03R\tpostCount\x128\n" +
 	"\tcreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\v\n" +
+	"\tupdatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\remailVerified\x18\t \x01(\bR\remailVerified\x12:\n" +
+	"\n" +
+	"verifiedAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\x12\x1a\n" +
+	"\btenantID\x18\v \x01(\tR\btenantID\x12\x16\n" +
+	"\x06status\x18\f \x01(\tR\x06status\x12\"\n" +
+	"\fstatusReason\x18\r \x01(\tR\fstatusReason\x12<\n" +
+	"\vstatusUntil\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vstatusUntilB\v\n" +
 	"\t_nickname\"F\n" +
 	"\fLoginRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"]\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xdb\x02\n" +
 	"\rLoginResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
-	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"\x15\n" +
-	"\x13RefreshTokenRequest\"d\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\x12D\n" +
+	"\x0frefreshExpireAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0frefreshExpireAt\x12 \n" +
+	"\vmfaRequired\x18\x05 \x01(\bR\vmfaRequired\x12&\n" +
+	"\x0echallengeToken\x18\x06 \x01(\tR\x0echallengeToken\x12H\n" +
+	"\x11challengeExpireAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x11challengeExpireAt\"P\n" +
+	"\x12VerifyLoginRequest\x12&\n" +
+	"\x0echallengeToken\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xcd\x01\n" +
+	"\x13VerifyLoginResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\x12D\n" +
+	"\x0frefreshExpireAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0frefreshExpireAt\"9\n" +
+	"\x13RefreshTokenRequest\x12\"\n" +
+	"\frefreshToken\x18\x01 \x01(\tR\frefreshToken\"\xce\x01\n" +
 	"\x14RefreshTokenResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
-	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"s\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\x12D\n" +
+	"\x0frefreshExpireAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0frefreshExpireAt\"3\n" +
+	"\rLogoutRequest\x12\"\n" +
+	"\frefreshToken\x18\x01 \x01(\tR\frefreshToken\"\x10\n" +
+	"\x0eLogoutResponse\"-\n" +
+	"\x13RevokeTokensRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x16\n" +
+	"\x14RevokeTokensResponse\",\n" +
+	"\x12ImpersonateRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"c\n" +
+	"\x13ImpersonateResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"+\n" +
+	"\x11UnlockUserRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x14\n" +
+	"\x12UnlockUserResponse\"\x96\x01\n" +
+	"\x17UpdateUserStatusRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\x06reason\x18\x03 \x01(\tH\x00R\x06reason\x88\x01\x01\x12\x19\n" +
+	"\x05until\x18\x04 \x01(\x03H\x01R\x05until\x88\x01\x01B\t\n" +
+	"\a_reasonB\b\n" +
+	"\x06_until\"\x1a\n" +
+	"\x18UpdateUserStatusResponse\"s\n" +
 	"\x15ChangePasswordRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12 \n" +
 	"\voldPassword\x18\x02 \x01(\tR\voldPassword\x12 \n" +
 	"\vnewPassword\x18\x03 \x01(\tR\vnewPassword\"\x18\n" +
-	"\x16ChangePasswordResponse\"\xb8\x01\n" +
+	"\x16ChangePasswordResponse\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1e\n" +
+	"\x1cRequestPasswordResetResponse\"N\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12 \n" +
+	"\vnewPassword\x18\x02 \x01(\tR\vnewPassword\"\x17\n" +
+	"\x15ResetPasswordResponse\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x15\n" +
+	"\x13VerifyEmailResponse\"\x1e\n" +
+	"\x1cSendVerificationEmailRequest\"\x1f\n" +
+	"\x1dSendVerificationEmailResponse\"\xe6\x01\n" +
 	"\x11CreateUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x122\n" +
 	"\bnickname\x18\x03 \x01(\tB\x11\x9aI\x0er\f你好世界H\x00R\bnickname\x88\x01\x01\x12\x14\n" +
 	"\x05email\x18\x04 \x01(\tR\x05email\x12\x14\n" +
-	"\x05phone\x18\x05 \x01(\tR\x05phoneB\v\n" +
-	"\t_nickname\",\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x1f\n" +
+	"\btenantID\x18\x06 \x01(\tH\x01R\btenantID\x88\x01\x01B\v\n" +
+	"\t_nicknameB\v\n" +
+	"\t_tenantID\",\n" +
 	"\x12CreateUserResponse\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\"\xd1\x01\n" +
 	"\x11UpdateUserRequest\x12\x16\n" +
@@ -1029,15 +2358,41 @@ const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\x06_phone\"\x14\n" +
 	"\x12UpdateUserResponse\"+\n" +
 	"\x11DeleteUserRequest\x12\x16\n" +
-	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x14\n" +
-	"\x12DeleteUserResponse\"(\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\xf2\x01\n" +
+	"\x12DeleteUserResponse\x12\"\n" +
+	"\fdeletedPosts\x18\x01 \x01(\x03R\fdeletedPosts\x12(\n" +
+	"\x0fdeletedPolicies\x18\x02 \x01(\x03R\x0fdeletedPolicies\x120\n" +
+	"\x13deletedAccessTokens\x18\x03 \x01(\x03R\x13deletedAccessTokens\x122\n" +
+	"\x14revokedRefreshTokens\x18\x04 \x01(\x03R\x14revokedRefreshTokens\x12(\n" +
+	"\x0frevokedSessions\x18\x05 \x01(\x03R\x0frevokedSessions\",\n" +
+	"\x12RestoreUserRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x15\n" +
+	"\x13RestoreUserResponse\"(\n" +
 	"\x0eGetUserRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\"/\n" +
 	"\x0fGetUserResponse\x12\x1c\n" +
-	"\x04user\x18\x01 \x01(\v2\b.v1.UserR\x04user\"?\n" +
+	"\x04user\x18\x01 \x01(\v2\b.v1.UserR\x04user\"\xeb\x03\n" +
 	"\x0fListUserRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x03R\x05limit\"R\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12+\n" +
+	"\x0eusernamePrefix\x18\x03 \x01(\tH\x00R\x0eusernamePrefix\x88\x01\x01\x12%\n" +
+	"\vemailDomain\x18\x04 \x01(\tH\x01R\vemailDomain\x88\x01\x01\x12'\n" +
+	"\fcreatedAfter\x18\x05 \x01(\x03H\x02R\fcreatedAfter\x88\x01\x01\x12)\n" +
+	"\rcreatedBefore\x18\x06 \x01(\x03H\x03R\rcreatedBefore\x88\x01\x01\x12)\n" +
+	"\remailVerified\x18\a \x01(\bH\x04R\remailVerified\x88\x01\x01\x12\x1b\n" +
+	"\x06sortBy\x18\b \x01(\tH\x05R\x06sortBy\x88\x01\x01\x12!\n" +
+	"\tsortOrder\x18\t \x01(\tH\x06R\tsortOrder\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\n" +
+	" \x01(\tH\aR\x06status\x88\x01\x01B\x11\n" +
+	"\x0f_usernamePrefixB\x0e\n" +
+	"\f_emailDomainB\x0f\n" +
+	"\r_createdAfterB\x10\n" +
+	"\x0e_createdBeforeB\x10\n" +
+	"\x0e_emailVerifiedB\t\n" +
+	"\a_sortByB\f\n" +
+	"\n" +
+	"_sortOrderB\t\n" +
+	"\a_status\"R\n" +
 	"\x10ListUserResponse\x12\x1e\n" +
 	"\n" +
 	"totalCount\x18\x01 \x01(\x03R\n" +
@@ -1056,39 +2411,69 @@ func file_apiserver_v1_user_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_user_proto_rawDescData
 }
 
-var file_apiserver_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_apiserver_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_apiserver_v1_user_proto_goTypes = []any{
-	(*User)(nil),                   // 0: v1.User
-	(*LoginRequest)(nil),           // 1: v1.LoginRequest
-	(*LoginResponse)(nil),          // 2: v1.LoginResponse
-	(*RefreshTokenRequest)(nil),    // 3: v1.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),   // 4: v1.RefreshTokenResponse
-	(*ChangePasswordRequest)(nil),  // 5: v1.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil), // 6: v1.ChangePasswordResponse
-	(*CreateUserRequest)(nil),      // 7: v1.CreateUserRequest
-	(*CreateUserResponse)(nil),     // 8: v1.CreateUserResponse
-	(*UpdateUserRequest)(nil),      // 9: v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),     // 10: v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),      // 11: v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil),     // 12: v1.DeleteUserResponse
-	(*GetUserRequest)(nil),         // 13: v1.GetUserRequest
-	(*GetUserResponse)(nil),        // 14: v1.GetUserResponse
-	(*ListUserRequest)(nil),        // 15: v1.ListUserRequest
-	(*ListUserResponse)(nil),       // 16: v1.ListUserResponse
-	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+	(*User)(nil),                          // 0: v1.User
+	(*LoginRequest)(nil),                  // 1: v1.LoginRequest
+	(*LoginResponse)(nil),                 // 2: v1.LoginResponse
+	(*VerifyLoginRequest)(nil),            // 3: v1.VerifyLoginRequest
+	(*VerifyLoginResponse)(nil),           // 4: v1.VerifyLoginResponse
+	(*RefreshTokenRequest)(nil),           // 5: v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),          // 6: v1.RefreshTokenResponse
+	(*LogoutRequest)(nil),                 // 7: v1.LogoutRequest
+	(*LogoutResponse)(nil),                // 8: v1.LogoutResponse
+	(*RevokeTokensRequest)(nil),           // 9: v1.RevokeTokensRequest
+	(*RevokeTokensResponse)(nil),          // 10: v1.RevokeTokensResponse
+	(*ImpersonateRequest)(nil),            // 11: v1.ImpersonateRequest
+	(*ImpersonateResponse)(nil),           // 12: v1.ImpersonateResponse
+	(*UnlockUserRequest)(nil),             // 13: v1.UnlockUserRequest
+	(*UnlockUserResponse)(nil),            // 14: v1.UnlockUserResponse
+	(*UpdateUserStatusRequest)(nil),       // 15: v1.UpdateUserStatusRequest
+	(*UpdateUserStatusResponse)(nil),      // 16: v1.UpdateUserStatusResponse
+	(*ChangePasswordRequest)(nil),         // 17: v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),        // 18: v1.ChangePasswordResponse
+	(*RequestPasswordResetRequest)(nil),   // 19: v1.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),  // 20: v1.RequestPasswordResetResponse
+	(*ResetPasswordRequest)(nil),          // 21: v1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),         // 22: v1.ResetPasswordResponse
+	(*VerifyEmailRequest)(nil),            // 23: v1.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),           // 24: v1.VerifyEmailResponse
+	(*SendVerificationEmailRequest)(nil),  // 25: v1.SendVerificationEmailRequest
+	(*SendVerificationEmailResponse)(nil), // 26: v1.SendVerificationEmailResponse
+	(*CreateUserRequest)(nil),             // 27: v1.CreateUserRequest
+	(*CreateUserResponse)(nil),            // 28: v1.CreateUserResponse
+	(*UpdateUserRequest)(nil),             // 29: v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),            // 30: v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),             // 31: v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),            // 32: v1.DeleteUserResponse
+	(*RestoreUserRequest)(nil),            // 33: v1.RestoreUserRequest
+	(*RestoreUserResponse)(nil),           // 34: v1.RestoreUserResponse
+	(*GetUserRequest)(nil),                // 35: v1.GetUserRequest
+	(*GetUserResponse)(nil),               // 36: v1.GetUserResponse
+	(*ListUserRequest)(nil),               // 37: v1.ListUserRequest
+	(*ListUserResponse)(nil),              // 38: v1.ListUserResponse
+	(*timestamppb.Timestamp)(nil),         // 39: google.protobuf.Timestamp
 }
 var file_apiserver_v1_user_proto_depIdxs = []int32{
-	17, // 0: v1.User.createdAt:type_name -> google.protobuf.Timestamp
-	17, // 1: v1.User.updatedAt:type_name -> google.protobuf.Timestamp
-	17, // 2: v1.LoginResponse.expireAt:type_name -> google.protobuf.Timestamp
-	17, // 3: v1.RefreshTokenResponse.expireAt:type_name -> google.protobuf.Timestamp
-	0,  // 4: v1.GetUserResponse.user:type_name -> v1.User
-	0,  // 5: v1.ListUserResponse.users:type_name -> v1.User
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	39, // 0: v1.User.createdAt:type_name -> google.protobuf.Timestamp
+	39, // 1: v1.User.updatedAt:type_name -> google.protobuf.Timestamp
+	39, // 2: v1.User.verifiedAt:type_name -> google.protobuf.Timestamp
+	39, // 3: v1.User.statusUntil:type_name -> google.protobuf.Timestamp
+	39, // 4: v1.LoginResponse.expireAt:type_name -> google.protobuf.Timestamp
+	39, // 5: v1.LoginResponse.refreshExpireAt:type_name -> google.protobuf.Timestamp
+	39, // 6: v1.LoginResponse.challengeExpireAt:type_name -> google.protobuf.Timestamp
+	39, // 7: v1.VerifyLoginResponse.expireAt:type_name -> google.protobuf.Timestamp
+	39, // 8: v1.VerifyLoginResponse.refreshExpireAt:type_name -> google.protobuf.Timestamp
+	39, // 9: v1.RefreshTokenResponse.expireAt:type_name -> google.protobuf.Timestamp
+	39, // 10: v1.RefreshTokenResponse.refreshExpireAt:type_name -> google.protobuf.Timestamp
+	39, // 11: v1.ImpersonateResponse.expireAt:type_name -> google.protobuf.Timestamp
+	0,  // 12: v1.GetUserResponse.user:type_name -> v1.User
+	0,  // 13: v1.ListUserResponse.users:type_name -> v1.User
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_user_proto_init() }
@@ -1097,15 +2482,17 @@ func file_apiserver_v1_user_proto_init() {
 		return
 	}
 	file_apiserver_v1_user_proto_msgTypes[0].OneofWrappers = []any{}
-	file_apiserver_v1_user_proto_msgTypes[7].OneofWrappers = []any{}
-	file_apiserver_v1_user_proto_msgTypes[9].OneofWrappers = []any{}
+	file_apiserver_v1_user_proto_msgTypes[15].OneofWrappers = []any{}
+	file_apiserver_v1_user_proto_msgTypes[27].OneofWrappers = []any{}
+	file_apiserver_v1_user_proto_msgTypes[29].OneofWrappers = []any{}
+	file_apiserver_v1_user_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_user_proto_rawDesc), len(file_apiserver_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
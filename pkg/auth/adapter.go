@@ -0,0 +1,136 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"errors"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	adapter "github.com/casbin/gorm-adapter/v3"
+	"gorm.io/gorm"
+)
+
+// changeRecorder 在修改策略的事务中记录策略变更, 例如 DBWatcher.
+type changeRecorder interface {
+	record(tx *gorm.DB, change *PolicyChange) error
+}
+
+// txAdapter 在同一个事务中写入策略和策略变更记录, 任意一步失败时全部回滚.
+// 避免策略已经写入数据库, 但其他实例因为缺少变更记录而一直使用旧策略.
+// 只实现了 casbin 使用的同步接口, 不能直接使用gorm适配器中不在事务里写入的方法.
+type txAdapter struct {
+	adapter  *adapter.Adapter
+	db       *gorm.DB
+	recorder changeRecorder
+}
+
+// 确保 txAdapter 实现了 casbin 写入策略时使用的接口.
+var (
+	_ persist.BatchAdapter     = (*txAdapter)(nil)
+	_ persist.UpdatableAdapter = (*txAdapter)(nil)
+)
+
+// 创建在事务中写入策略的适配器, 读取策略仍然使用a.
+func newTxAdapter(db *gorm.DB, a *adapter.Adapter, recorder changeRecorder) *txAdapter {
+	return &txAdapter{adapter: a, db: db, recorder: recorder}
+}
+
+// LoadPolicy 从数据库加载全部策略.
+func (a *txAdapter) LoadPolicy(m model.Model) error {
+	return a.adapter.LoadPolicy(m)
+}
+
+// 在事务中执行fn写入策略, 并记录策略变更.
+func (a *txAdapter) write(change *PolicyChange, fn func(a *adapter.Adapter) error) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		// 使用事务创建的适配器不会自动迁移数据表, 数据表已经由a创建
+		txa, err := adapter.NewFilteredAdapterByDB(tx, "", casbinRuleTable)
+		if err != nil {
+			return err
+		}
+		if err := fn(txa); err != nil {
+			return err
+		}
+
+		return a.recorder.record(tx, change)
+	})
+}
+
+// SavePolicy 使用新的策略覆盖数据库中的全部策略, 其他实例需要重新加载全部策略.
+// gorm适配器保存全部策略时会自己开启事务, 因此变更记录在保存之后单独写入.
+func (a *txAdapter) SavePolicy(m model.Model) error {
+	if err := a.adapter.SavePolicy(m); err != nil {
+		return err
+	}
+
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		return a.recorder.record(tx, &PolicyChange{Op: OpReload})
+	})
+}
+
+func (a *txAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	change := &PolicyChange{Op: OpAddPolicies, Sec: sec, PType: ptype, Rules: cloneRules([][]string{rule})}
+	return a.write(change, func(txa *adapter.Adapter) error {
+		return txa.AddPolicy(sec, ptype, rule)
+	})
+}
+
+func (a *txAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	change := &PolicyChange{Op: OpRemovePolicies, Sec: sec, PType: ptype, Rules: cloneRules([][]string{rule})}
+	return a.write(change, func(txa *adapter.Adapter) error {
+		return txa.RemovePolicy(sec, ptype, rule)
+	})
+}
+
+func (a *txAdapter) AddPolicies(sec string, ptype string, rules [][]string) error {
+	change := &PolicyChange{Op: OpAddPolicies, Sec: sec, PType: ptype, Rules: cloneRules(rules)}
+	return a.write(change, func(txa *adapter.Adapter) error {
+		return txa.AddPolicies(sec, ptype, rules)
+	})
+}
+
+func (a *txAdapter) RemovePolicies(sec string, ptype string, rules [][]string) error {
+	change := &PolicyChange{Op: OpRemovePolicies, Sec: sec, PType: ptype, Rules: cloneRules(rules)}
+	return a.write(change, func(txa *adapter.Adapter) error {
+		return txa.RemovePolicies(sec, ptype, rules)
+	})
+}
+
+func (a *txAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	change := &PolicyChange{
+		Op:          OpRemoveFilteredPolicy,
+		Sec:         sec,
+		PType:       ptype,
+		FieldIndex:  fieldIndex,
+		FieldValues: append([]string(nil), fieldValues...),
+	}
+	return a.write(change, func(txa *adapter.Adapter) error {
+		return txa.RemoveFilteredPolicy(sec, ptype, fieldIndex, fieldValues...)
+	})
+}
+
+func (a *txAdapter) UpdatePolicy(sec string, ptype string, oldRule, newRule []string) error {
+	return a.UpdatePolicies(sec, ptype, [][]string{oldRule}, [][]string{newRule})
+}
+
+// UpdatePolicies 逐条修改策略, gorm适配器批量修改时会自己开启事务, 不能在已有的事务中使用.
+func (a *txAdapter) UpdatePolicies(sec string, ptype string, oldRules, newRules [][]string) error {
+	change := &PolicyChange{Op: OpUpdatePolicies, Sec: sec, PType: ptype, Rules: cloneRules(oldRules), NewRules: cloneRules(newRules)}
+	return a.write(change, func(txa *adapter.Adapter) error {
+		for i := range oldRules {
+			if err := txa.UpdatePolicy(sec, ptype, oldRules[i], newRules[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateFilteredPolicies 不支持按字段修改策略, 无法在同一个事务中得到被修改的策略.
+func (a *txAdapter) UpdateFilteredPolicies(sec string, ptype string, newRules [][]string, fieldIndex int, fieldValues ...string) ([][]string, error) {
+	return nil, errors.New("updating filtered policies is not supported")
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casbin/casbin/v2/util"
 	adapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -17,20 +19,26 @@ import (
 
 const (
 	// 默认的cabin 访问控制模型.
+	// 所有策略和角色都属于某个域(租户), 域为*的策略和角色适用于全部租户.
+	// r, e, m 用于基于角色的授权, 没有被deny策略拒绝的请求都允许访问.
+	// r2, e2, m2 用于基于资源归属的授权, 只有被allow策略显式允许的主体才能访问其他用户的资源.
 	defaultAclModel = `[request_definition]
-r = sub, obj, act
+r = sub, dom, obj, act
+r2 = sub, dom, obj, act
 
 [policy_definition]
-p = sub, obj, act, eft
+p = sub, dom, obj, act, eft
 
 [role_definition]
-g = _, _
+g = _, _, _
 
 [policy_effect]
 e = !some(where (p.eft == deny))
+e2 = some(where (p.eft == allow))
 
 [matchers]
-m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && r.act == p.act`
+m = g(r.sub, p.sub, r.dom) && keyMatch(r.dom, p.dom) && keyMatch(r.obj, p.obj) && (r.act == p.act || p.act == "*")
+m2 = g(r2.sub, p.sub, r2.dom) && keyMatch(r2.dom, p.dom) && keyMatch(r2.obj, p.obj) && (r2.act == p.act || p.act == "*")`
 )
 
 // 授权器, 提供授权功能.
@@ -44,20 +52,19 @@ type Option func(*authzConfig)
 // 授权器的配置结构.
 type authzConfig struct {
 	aclModel           string        // casbin的模型字符串
-	autoLoadPolicyTime time.Duration // 自动加载策略的时间间隔
+	autoLoadPolicyTime time.Duration // 自动加载策略的时间间隔, 为0时不自动加载
+	watcher            Watcher       // 用于在多个实例之间广播策略变更
 }
 
 // ProviderSet 是一个 Wire 的 Provider 集合, 用于声明依赖注入的规则
 // 包含 NewAuthz 构造函数，用于生成 Authz 实例。
-var ProviderSet = wire.NewSet(NewAuthz, DefaultOptions)
+var ProviderSet = wire.NewSet(NewAuthz)
 
 // 返回一个默认配置.
 func defaultAuthzConfig() *authzConfig {
 	return &authzConfig{
 		// 默认使用内置的acl模型
 		aclModel: defaultAclModel,
-		// 自动加载更新策略时间间隔为5s
-		autoLoadPolicyTime: 5 * time.Second,
 	}
 }
 
@@ -66,8 +73,6 @@ func DefaultOptions() []Option {
 	return []Option{
 		// 使用默认的ACL模型
 		WithAclModel(defaultAclModel),
-		// 设置自动加载策略的时间间隔
-		WithAutoLoadPolicyTime(10 * time.Second),
 	}
 }
 
@@ -79,12 +84,21 @@ func WithAclModel(model string) Option {
 }
 
 // 允许通过选项自定义自动加载策略的时间间隔.
+// 定期重新加载全部策略的开销随策略数增长, 多实例部署时应优先使用广播器同步策略变更.
 func WithAutoLoadPolicyTime(interval time.Duration) Option {
 	return func(ac *authzConfig) {
 		ac.autoLoadPolicyTime = interval
 	}
 }
 
+// 允许通过选项设置策略变更的广播器.
+// 通过授权器修改策略时会自动通知广播器, 收到其他实例的变更时增量应用变更.
+func WithWatcher(watcher Watcher) Option {
+	return func(ac *authzConfig) {
+		ac.watcher = watcher
+	}
+}
+
 // 创建一个使用casbin完成授权的授权器, 通过函数选项模式支持自定义配置.
 func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 	// 初始化默认配置
@@ -104,26 +118,51 @@ func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 	// 从配置中加载casbin模型
 	m, _ := model.NewModelFromString(cfg.aclModel)
 
+	// 使用数据库广播器时, 策略和变更记录在同一个事务中写入, 广播器需要与授权器使用同一个数据库
+	var policyAdapter persist.Adapter = adapter
+	if recorder, ok := cfg.watcher.(changeRecorder); ok {
+		policyAdapter = newTxAdapter(db, adapter, recorder)
+	}
+
 	// 初始化授权器
-	enforcer, err := casbin.NewSyncedEnforcer(m, adapter)
+	enforcer, err := casbin.NewSyncedEnforcer(m, policyAdapter)
 	if err != nil {
 		return nil, err
 	}
 
+	a := newAuthz(enforcer)
+
 	// 从数据库加载策略
-	if err := enforcer.LoadPolicy(); err != nil {
+	if err := a.reload(); err != nil {
 		return nil, err
 	}
 
+	if cfg.watcher != nil {
+		if err := enforcer.SetWatcher(cfg.watcher); err != nil {
+			return nil, err
+		}
+		cfg.watcher.SetChangeHandler(a.applyChange)
+	}
+
 	// 启动自动加载策略, 使用配置的时间间隔
-	enforcer.StartAutoLoadPolicy(cfg.autoLoadPolicyTime)
+	if cfg.autoLoadPolicyTime > 0 {
+		enforcer.StartAutoLoadPolicy(cfg.autoLoadPolicyTime)
+	}
+
+	policyCount.authz.Store(a)
 
 	// 返回新的授权器实例
-	return &Authz{enforcer}, nil
+	return a, nil
+}
+
+// 创建授权器, 角色继承规则中的域支持keyMatch通配符, 域为*的角色在全部租户中生效.
+func newAuthz(enforcer *casbin.SyncedEnforcer) *Authz {
+	enforcer.AddNamedDomainMatchingFunc("g", "keyMatch", util.KeyMatch)
+	return &Authz{enforcer}
 }
 
 // 用于进行授权.
-func (a *Authz) Authorize(sub, obj, act string) (bool, error) {
-	// 调用 Enforce 方法进行授权检查某个主体sub是否可以对某个资源obj进行指定操作act
-	return a.Enforce(sub, obj, act)
+func (a *Authz) Authorize(sub, dom, obj, act string) (bool, error) {
+	// 调用 Enforce 方法进行授权检查某个主体sub在租户dom中是否可以对某个资源obj进行指定操作act
+	return a.Enforce(sub, dom, obj, act)
 }
@@ -0,0 +1,36 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"gorm.io/gorm"
+)
+
+// AllDomains 是适用于全部域的策略和角色使用的域.
+const AllDomains = "*"
+
+// 保存策略的数据表, 与gorm适配器的默认表名一致.
+const casbinRuleTable = "casbin_rule"
+
+// MigrateDomains 将不带域的旧策略迁移为适用于全部域的策略, 迁移后原有策略的效果不变.
+// 旧的策略"sub, obj, act, eft"迁移为"sub, *, obj, act, eft", 旧的角色继承规则"user, role"迁移为"user, role, *".
+// 字段个数与模型不一致的策略会导致授权器加载失败, 因此需要在创建授权器之前调用.
+func MigrateDomains(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// 各字段依次后移一位, 赋值顺序保证MySQL按从左到右的顺序赋值时同样使用旧值
+		if err := tx.Exec(
+			"UPDATE "+casbinRuleTable+" SET v4 = v3, v3 = v2, v2 = v1, v1 = ? WHERE ptype = ? AND (v4 IS NULL OR v4 = '')",
+			AllDomains, "p",
+		).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			"UPDATE "+casbinRuleTable+" SET v2 = ? WHERE ptype = ? AND (v2 IS NULL OR v2 = '')",
+			AllDomains, "g",
+		).Error
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+)
+
+// Explanation 表示一次授权检查的详细结果, 用于排查请求被拒绝的原因.
+type Explanation struct {
+	// 是否允许访问
+	Allowed bool
+	// 决定授权结果的策略, 没有策略命中时为空
+	MatchedPolicies [][]string
+	// 主体直接和间接拥有的全部角色
+	Roles []string
+}
+
+// 创建一个从策略文件加载策略的授权器, 用于离线检查策略, 不会自动重新加载策略.
+// 策略文件使用casbin的CSV格式, 例如"p, role::user, *, user, list, deny".
+func NewFileAuthz(policyFile string, opts ...Option) (*Authz, error) {
+	cfg := defaultAuthzConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	m, err := model.NewModelFromString(cfg.aclModel)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcer, err := casbin.NewSyncedEnforcer(m, fileadapter.NewAdapter(policyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuthz(enforcer), nil
+}
+
+// Explain 检查主体在租户dom中能否对资源执行操作, 同时返回决定结果的策略和主体在该租户中拥有的角色.
+// 在当前的模型中, 只有deny策略会决定结果, 没有命中任何策略表示允许访问.
+func (a *Authz) Explain(sub, dom, obj, act string) (*Explanation, error) {
+	allowed, explain, err := a.EnforceEx(sub, dom, obj, act)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := a.GetImplicitRolesForUser(sub, dom)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{Allowed: allowed, Roles: roles}
+	if len(explain) > 0 {
+		explanation.MatchedPolicies = append(explanation.MatchedPolicies, explain)
+	}
+
+	return explanation, nil
+}
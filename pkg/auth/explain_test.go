@@ -0,0 +1,50 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.csv")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`p, role::admin, *, *, *, allow
+p, role::user, *, user, list, deny
+g, user-000000, role::admin, *
+g, user-000001, role::user, default
+`), 0o600))
+
+	a, err := NewFileAuthz(policyFile)
+	require.NoError(t, err)
+
+	explanation, err := a.Explain("user-000001", "default", "user", "list")
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+	assert.Equal(t, [][]string{{"role::user", "*", "user", "list", "deny"}}, explanation.MatchedPolicies)
+	assert.Equal(t, []string{"role::user"}, explanation.Roles)
+
+	// 用户只在所属的租户中拥有角色
+	explanation, err = a.Explain("user-000001", "blog-a", "user", "list")
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.Empty(t, explanation.Roles)
+
+	explanation, err = a.Explain("user-000000", "blog-a", "user", "list")
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.Empty(t, explanation.MatchedPolicies)
+	assert.Equal(t, []string{"role::admin"}, explanation.Roles)
+}
+
+func TestNewFileAuthzMissingFile(t *testing.T) {
+	_, err := NewFileAuthz(filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 加载策略的方式.
+const (
+	// 重新加载全部策略
+	loadModeFull = "full"
+	// 增量应用其他授权器的策略变更
+	loadModeIncremental = "incremental"
+)
+
+var (
+	// 加载策略的耗时.
+	policyLoadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "miniblog",
+		Subsystem: "authz",
+		Name:      "policy_load_duration_seconds",
+		Help:      "Time spent loading casbin policies, by mode (full or incremental).",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"mode"})
+
+	// 收到的其他授权器的策略变更数.
+	policyChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "miniblog",
+		Subsystem: "authz",
+		Name:      "policy_changes_received_total",
+		Help:      "Number of casbin policy changes received from other instances, by op.",
+	}, []string{"op"})
+
+	// 同步策略失败的次数.
+	policySyncErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "miniblog",
+		Subsystem: "authz",
+		Name:      "policy_sync_errors_total",
+		Help:      "Number of failures while synchronizing casbin policies.",
+	})
+
+	// 当前的策略数.
+	policyCount = &policyCollector{
+		desc: prometheus.NewDesc(
+			"miniblog_authz_policies",
+			"Number of casbin policies currently loaded, by ptype.",
+			[]string{"ptype"}, nil,
+		),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(policyLoadDuration, policyChanges, policySyncErrors, policyCount)
+}
+
+// policyCollector 在采集指标时统计授权器中的策略数, 通过授权器直接修改的策略也能被统计到.
+type policyCollector struct {
+	desc *prometheus.Desc
+	// 最近创建的授权器
+	authz atomic.Pointer[Authz]
+}
+
+func (c *policyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *policyCollector) Collect(ch chan<- prometheus.Metric) {
+	a := c.authz.Load()
+	if a == nil {
+		return
+	}
+
+	if policies, err := a.GetPolicy(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(len(policies)), "p")
+	}
+	if policies, err := a.GetGroupingPolicy(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(len(policies)), "g")
+	}
+}
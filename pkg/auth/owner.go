@@ -0,0 +1,29 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"github.com/casbin/casbin/v2"
+)
+
+// 基于资源归属授权时使用的模型定义, 与基于角色的授权共用同一组策略.
+var ownerEnforceContext = casbin.EnforceContext{RType: "r2", PType: "p", EType: "e2", MType: "m2"}
+
+// AuthorizeAny 判断主体能否对租户dom中任意用户的资源执行操作.
+// 与Authorize不同, 只有被allow策略显式允许的主体才能通过, 例如拥有"p, role::admin, *, *, *, allow"的管理员.
+func (a *Authz) AuthorizeAny(sub, dom, obj, act string) (bool, error) {
+	return a.Enforce(ownerEnforceContext, sub, dom, obj, act)
+}
+
+// AuthorizeOwner 判断主体能否对租户dom中属于owner的资源执行操作.
+// 资源属于主体本人时直接允许, 否则主体需要能够对该租户中任意用户的资源执行该操作.
+func (a *Authz) AuthorizeOwner(sub, dom, owner, obj, act string) (bool, error) {
+	if sub != "" && sub == owner {
+		return true, nil
+	}
+
+	return a.AuthorizeAny(sub, dom, obj, act)
+}
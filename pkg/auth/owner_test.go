@@ -0,0 +1,59 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeOwner(t *testing.T) {
+	a := newTestAuthz(t)
+
+	_, err := a.AddPolicy("role::admin", "*", "*", "*", "allow")
+	require.NoError(t, err)
+	_, err = a.AddPolicy("role::moderator", "*", "post", "delete", "allow")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000000", "role::admin", "*")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000001", "role::user", "default")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000002", "role::moderator", "default")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000003", "role::admin", "blog-a")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		sub     string
+		dom     string
+		owner   string
+		obj     string
+		act     string
+		allowed bool
+	}{
+		{name: "owner", sub: "user-000001", dom: "default", owner: "user-000001", obj: "post", act: "update", allowed: true},
+		{name: "other user", sub: "user-000001", dom: "default", owner: "user-000002", obj: "post", act: "update", allowed: false},
+		{name: "admin", sub: "user-000000", dom: "default", owner: "user-000001", obj: "post", act: "update", allowed: true},
+		{name: "admin of all tenants", sub: "user-000000", dom: "blog-a", owner: "user-000004", obj: "post", act: "update", allowed: true},
+		{name: "tenant admin", sub: "user-000003", dom: "blog-a", owner: "user-000004", obj: "post", act: "update", allowed: true},
+		{name: "tenant admin in other tenant", sub: "user-000003", dom: "default", owner: "user-000001", obj: "post", act: "update", allowed: false},
+		{name: "granted action", sub: "user-000002", dom: "default", owner: "user-000001", obj: "post", act: "delete", allowed: true},
+		{name: "granted action in other tenant", sub: "user-000002", dom: "blog-a", owner: "user-000004", obj: "post", act: "delete", allowed: false},
+		{name: "not granted action", sub: "user-000002", dom: "default", owner: "user-000001", obj: "post", act: "update", allowed: false},
+		{name: "anonymous", sub: "", dom: "default", owner: "", obj: "post", act: "get", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := a.AuthorizeOwner(tt.sub, tt.dom, tt.owner, tt.obj, tt.act)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+)
+
+// 策略规则中eft字段的可选值.
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// ValidatePolicy 校验策略规则是否符合模型中p的定义, 字段个数必须与模型一致且不能为空.
+func (a *Authz) ValidatePolicy(rule []string) error {
+	ast, ok := a.GetModel()["p"]["p"]
+	if !ok {
+		return fmt.Errorf("model has no policy definition")
+	}
+	if err := validateRule(rule, len(ast.Tokens)); err != nil {
+		return err
+	}
+
+	// 模型中定义了eft字段时, 只允许allow和deny两种效果
+	for i, token := range ast.Tokens {
+		if token == "p_eft" && rule[i] != EffectAllow && rule[i] != EffectDeny {
+			return fmt.Errorf("invalid policy effect %q, must be %q or %q", rule[i], EffectAllow, EffectDeny)
+		}
+	}
+
+	return nil
+}
+
+// ValidateGroupingPolicy 校验角色继承规则是否符合模型中g的定义, 字段个数必须与模型一致且不能为空.
+func (a *Authz) ValidateGroupingPolicy(rule []string) error {
+	ast, ok := a.GetModel()["g"]["g"]
+	if !ok {
+		return fmt.Errorf("model has no role definition")
+	}
+
+	// 角色定义形如"_, _, _", 每个下划线对应一个字段
+	return validateRule(rule, strings.Count(ast.Value, "_"))
+}
+
+// HasRoleWithout 判断不考虑角色继承规则excluded时, 主体在租户dom中是否直接或通过角色继承间接拥有角色role.
+// 用于在删除规则之前判断主体是否会因此失去角色, excluded为nil时与主体当前拥有的角色一致.
+func (a *Authz) HasRoleWithout(sub, role, dom string, excluded []string) (bool, error) {
+	visited := map[string]bool{sub: true}
+	queue := []string{sub}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		rules, err := a.GetFilteredGroupingPolicy(0, current)
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range rules {
+			// 与授权时一致, 角色继承规则中的域支持keyMatch通配符
+			if len(rule) < 3 || !util.KeyMatch(dom, rule[2]) || slices.Equal(rule, excluded) {
+				continue
+			}
+			if rule[1] == role {
+				return true, nil
+			}
+			if !visited[rule[1]] {
+				visited[rule[1]] = true
+				queue = append(queue, rule[1])
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// 校验规则的字段个数, 并且所有字段都不能为空.
+func validateRule(rule []string, fields int) error {
+	if len(rule) != fields {
+		return fmt.Errorf("rule must have %d fields, got %d", fields, len(rule))
+	}
+	for i, v := range rule {
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("field %d of rule cannot be empty", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 创建使用内存数据库的授权器.
+func newTestAuthz(t *testing.T, opts ...Option) *Authz {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	a, err := NewAuthz(db, opts...)
+	require.NoError(t, err)
+	t.Cleanup(a.StopAutoLoadPolicy)
+
+	return a
+}
+
+func TestValidatePolicy(t *testing.T) {
+	a := newTestAuthz(t)
+
+	assert.NoError(t, a.ValidatePolicy([]string{"role::user", "default", "user", "list", "deny"}))
+	assert.NoError(t, a.ValidatePolicy([]string{"role::admin", "*", "*", "*", "allow"}))
+
+	assert.Error(t, a.ValidatePolicy([]string{"role::user", "user", "list", "deny"}))
+	assert.Error(t, a.ValidatePolicy([]string{"role::user", "default", "user", "list", "deny", "extra"}))
+	assert.Error(t, a.ValidatePolicy([]string{"role::user", "default", " ", "list", "deny"}))
+	assert.Error(t, a.ValidatePolicy([]string{"role::user", "default", "user", "list", "maybe"}))
+}
+
+func TestValidateGroupingPolicy(t *testing.T) {
+	a := newTestAuthz(t)
+
+	assert.NoError(t, a.ValidateGroupingPolicy([]string{"user-000000", "role::admin", "*"}))
+
+	assert.Error(t, a.ValidateGroupingPolicy([]string{"user-000000", "role::admin"}))
+	assert.Error(t, a.ValidateGroupingPolicy([]string{"user-000000", "role::admin", "*", "extra"}))
+	assert.Error(t, a.ValidateGroupingPolicy([]string{"", "role::admin", "*"}))
+}
+
+func TestPolicyChangesTakeEffectImmediately(t *testing.T) {
+	a := newTestAuthz(t)
+
+	_, err := a.AddPolicy("role::user", "*", "user", "list", "deny")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000001", "role::user", "default")
+	require.NoError(t, err)
+
+	allowed, err := a.Authorize("user-000001", "default", "user", "list")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// 修改后的策略不需要等待自动加载即可生效
+	_, err = a.RemoveGroupingPolicy("user-000001", "role::user", "default")
+	require.NoError(t, err)
+
+	allowed, err = a.Authorize("user-000001", "default", "user", "list")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestWildcardAction(t *testing.T) {
+	a := newTestAuthz(t)
+
+	_, err := a.AddPolicy("role::user", "*", "user-session", "*", "deny")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000001", "role::user", "default")
+	require.NoError(t, err)
+
+	allowed, err := a.Authorize("user-000001", "default", "user-session", "revoke")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = a.Authorize("user-000001", "default", "session", "revoke")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestDomainPolicy(t *testing.T) {
+	a := newTestAuthz(t)
+
+	_, err := a.AddPolicy("role::user", "blog-a", "post", "create", "deny")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000001", "role::user", "blog-a")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("user-000002", "role::user", "blog-b")
+	require.NoError(t, err)
+
+	// 策略只在所属的租户中生效
+	allowed, err := a.Authorize("user-000001", "blog-a", "post", "create")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = a.Authorize("user-000002", "blog-b", "post", "create")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestMigrateDomains(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	// 使用不带域的旧模型写入策略
+	legacy, err := NewAuthz(db, WithAclModel(`[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act, eft
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = !some(where (p.eft == deny))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && r.act == p.act`))
+	require.NoError(t, err)
+	_, err = legacy.AddPolicy("role::user", "user", "list", "deny")
+	require.NoError(t, err)
+	_, err = legacy.AddGroupingPolicy("user-000001", "role::user")
+	require.NoError(t, err)
+
+	require.NoError(t, MigrateDomains(db))
+	// 重复迁移不会修改已经迁移的策略
+	require.NoError(t, MigrateDomains(db))
+
+	a, err := NewAuthz(db)
+	require.NoError(t, err)
+
+	has, err := a.HasPolicy("role::user", "*", "user", "list", "deny")
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = a.HasGroupingPolicy("user-000001", "role::user", "*")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	allowed, err := a.Authorize("user-000001", "blog-a", "user", "list")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestHasRoleWithout(t *testing.T) {
+	a := newTestAuthz(t)
+	_, err := a.AddGroupingPolicies([][]string{
+		{"user-000001", "role::ops", "default"},
+		{"role::ops", "role::admin", "default"},
+		{"user-000002", "role::admin", "*"},
+	})
+	require.NoError(t, err)
+
+	has := func(sub, dom string, excluded []string) bool {
+		ok, err := a.HasRoleWithout(sub, "role::admin", dom, excluded)
+		require.NoError(t, err)
+		return ok
+	}
+
+	// 通过角色继承间接拥有的角色, 去掉继承链上的任意一条规则后都不再拥有
+	assert.True(t, has("user-000001", "default", nil))
+	assert.False(t, has("user-000001", "default", []string{"user-000001", "role::ops", "default"}))
+	assert.False(t, has("user-000001", "default", []string{"role::ops", "role::admin", "default"}))
+	assert.True(t, has("user-000001", "default", []string{"role::ops", "role::admin", "blog-a"}))
+	assert.False(t, has("user-000001", "blog-a", nil))
+
+	// 域为*的角色在全部租户中生效
+	assert.True(t, has("user-000002", "blog-a", nil))
+	assert.False(t, has("user-000002", "blog-a", []string{"user-000002", "role::admin", "*"}))
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// 策略变更的类型.
+const (
+	// OpAddPolicies 表示添加策略.
+	OpAddPolicies = "add"
+	// OpRemovePolicies 表示删除策略.
+	OpRemovePolicies = "remove"
+	// OpRemoveFilteredPolicy 表示按字段删除策略.
+	OpRemoveFilteredPolicy = "remove-filtered"
+	// OpUpdatePolicies 表示修改策略.
+	OpUpdatePolicies = "update"
+	// OpReload 表示无法增量同步, 需要重新加载全部策略.
+	OpReload = "reload"
+)
+
+// PolicyChange 描述一次策略变更, 用于在多个实例之间增量同步策略.
+type PolicyChange struct {
+	// 变更类型
+	Op string `json:"op"`
+	// 策略所在的段, p表示策略, g表示角色
+	Sec string `json:"sec,omitempty"`
+	// 策略类型, 例如p和g
+	PType string `json:"ptype,omitempty"`
+	// 添加或删除的策略, 修改策略时表示修改前的策略
+	Rules [][]string `json:"rules,omitempty"`
+	// 修改后的策略
+	NewRules [][]string `json:"newRules,omitempty"`
+	// 按字段删除策略时的起始字段
+	FieldIndex int `json:"fieldIndex,omitempty"`
+	// 按字段删除策略时的字段值
+	FieldValues []string `json:"fieldValues,omitempty"`
+}
+
+// Watcher 在多个授权器之间广播策略变更.
+// 通过授权器修改策略时, 授权器会将变更交给广播器, 广播器再将其他授权器的变更交给处理函数增量应用.
+type Watcher interface {
+	persist.WatcherEx
+	persist.UpdatableWatcher
+
+	// SetChangeHandler 设置收到其他授权器的策略变更时的处理函数.
+	SetChangeHandler(handler func(change *PolicyChange))
+}
+
+// watcherBase 将casbin的变更通知转换为PolicyChange, 并将收到的变更交给处理函数.
+// 具体的广播器只需要实现发布变更和接收变更.
+type watcherBase struct {
+	// 发布当前授权器的策略变更
+	publish func(change *PolicyChange) error
+	// 第一次设置处理函数时调用, 用于启动接收变更
+	subscribe func()
+
+	once     sync.Once
+	mu       sync.RWMutex
+	handler  func(change *PolicyChange)
+	callback func(string)
+}
+
+// SetChangeHandler 设置收到其他授权器的策略变更时的处理函数.
+func (w *watcherBase) SetChangeHandler(handler func(change *PolicyChange)) {
+	w.mu.Lock()
+	w.handler = handler
+	w.mu.Unlock()
+
+	w.once.Do(w.subscribe)
+}
+
+// SetUpdateCallback 设置收到其他授权器的策略变更时的回调, 只在没有设置处理函数时使用.
+func (w *watcherBase) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	w.callback = callback
+	w.mu.Unlock()
+
+	w.once.Do(w.subscribe)
+	return nil
+}
+
+// 将收到的策略变更交给处理函数.
+func (w *watcherBase) dispatch(change *PolicyChange) {
+	w.mu.RLock()
+	handler, callback := w.handler, w.callback
+	w.mu.RUnlock()
+
+	switch {
+	case handler != nil:
+		handler(change)
+	case callback != nil:
+		callback(change.Op)
+	}
+}
+
+func (w *watcherBase) Update() error {
+	return w.publish(&PolicyChange{Op: OpReload})
+}
+
+func (w *watcherBase) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return w.publish(&PolicyChange{Op: OpAddPolicies, Sec: sec, PType: ptype, Rules: cloneRules([][]string{params})})
+}
+
+func (w *watcherBase) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return w.publish(&PolicyChange{Op: OpRemovePolicies, Sec: sec, PType: ptype, Rules: cloneRules([][]string{params})})
+}
+
+func (w *watcherBase) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return w.publish(&PolicyChange{
+		Op:          OpRemoveFilteredPolicy,
+		Sec:         sec,
+		PType:       ptype,
+		FieldIndex:  fieldIndex,
+		FieldValues: append([]string(nil), fieldValues...),
+	})
+}
+
+func (w *watcherBase) UpdateForSavePolicy(model model.Model) error {
+	return w.publish(&PolicyChange{Op: OpReload})
+}
+
+func (w *watcherBase) UpdateForAddPolicies(sec string, ptype string, rules ...[]string) error {
+	return w.publish(&PolicyChange{Op: OpAddPolicies, Sec: sec, PType: ptype, Rules: cloneRules(rules)})
+}
+
+func (w *watcherBase) UpdateForRemovePolicies(sec string, ptype string, rules ...[]string) error {
+	return w.publish(&PolicyChange{Op: OpRemovePolicies, Sec: sec, PType: ptype, Rules: cloneRules(rules)})
+}
+
+func (w *watcherBase) UpdateForUpdatePolicy(sec string, ptype string, oldRule, newRule []string) error {
+	return w.UpdateForUpdatePolicies(sec, ptype, [][]string{oldRule}, [][]string{newRule})
+}
+
+func (w *watcherBase) UpdateForUpdatePolicies(sec string, ptype string, oldRules, newRules [][]string) error {
+	return w.publish(&PolicyChange{Op: OpUpdatePolicies, Sec: sec, PType: ptype, Rules: cloneRules(oldRules), NewRules: cloneRules(newRules)})
+}
+
+// 复制策略, casbin之后可能会修改传入的切片.
+func cloneRules(rules [][]string) [][]string {
+	cloned := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		cloned = append(cloned, append([]string(nil), rule...))
+	}
+	return cloned
+}
+
+// 应用其他授权器的策略变更, 增量应用失败时重新加载全部策略.
+func (a *Authz) applyChange(change *PolicyChange) {
+	policyChanges.WithLabelValues(change.Op).Inc()
+
+	if change.Op != OpReload {
+		start := time.Now()
+		if err := a.applyIncremental(change); err == nil {
+			policyLoadDuration.WithLabelValues(loadModeIncremental).Observe(time.Since(start).Seconds())
+			return
+		}
+	}
+
+	if err := a.reload(); err != nil {
+		policySyncErrors.Inc()
+	}
+}
+
+// 将策略变更直接应用到内存中的模型.
+// 变更已经由发布变更的授权器写入数据库, 因此不能使用会写入数据库的SelfAddPolicy等方法.
+func (a *Authz) applyIncremental(change *PolicyChange) error {
+	lock := a.GetLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	m := a.Enforcer.GetModel()
+
+	var removed, added [][]string
+	var err error
+	switch change.Op {
+	case OpAddPolicies:
+		added, err = m.AddPoliciesWithAffected(change.Sec, change.PType, change.Rules)
+	case OpRemovePolicies:
+		removed, err = m.RemovePoliciesWithAffected(change.Sec, change.PType, change.Rules)
+	case OpRemoveFilteredPolicy:
+		_, removed, err = m.RemoveFilteredPolicy(change.Sec, change.PType, change.FieldIndex, change.FieldValues...)
+	case OpUpdatePolicies:
+		if removed, err = m.RemovePoliciesWithAffected(change.Sec, change.PType, change.Rules); err == nil {
+			added, err = m.AddPoliciesWithAffected(change.Sec, change.PType, change.NewRules)
+		}
+	default:
+		err = fmt.Errorf("unknown policy change op %q", change.Op)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 角色的继承关系需要同步到角色管理器
+	if change.Sec != "g" {
+		return nil
+	}
+	if len(removed) > 0 {
+		if err := a.Enforcer.BuildIncrementalRoleLinks(model.PolicyRemove, change.PType, removed); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		if err := a.Enforcer.BuildIncrementalRoleLinks(model.PolicyAdd, change.PType, added); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 重新加载全部策略.
+func (a *Authz) reload() error {
+	start := time.Now()
+	if err := a.LoadPolicy(); err != nil {
+		return err
+	}
+	policyLoadDuration.WithLabelValues(loadModeFull).Observe(time.Since(start).Seconds())
+
+	return nil
+}
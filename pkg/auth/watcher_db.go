@@ -0,0 +1,221 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 策略版本记录的主键, 版本表中只有这一行记录.
+const policyVersionID = 1
+
+// policyVersionM 记录策略的最新版本, 每次策略变更时版本号加一.
+type policyVersionM struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:false"`
+	Version   int64     `gorm:"column:version;not null"`
+	UpdatedAt time.Time `gorm:"column:updatedAt"`
+}
+
+func (*policyVersionM) TableName() string {
+	return "casbin_policy_version"
+}
+
+// policyChangeM 记录一次策略变更, 版本号为变更后的策略版本.
+type policyChangeM struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Instance  string    `gorm:"column:instance;type:varchar(36);not null"`
+	Payload   string    `gorm:"column:payload;type:text;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt;index:idx_casbin_policy_change_createdAt"`
+}
+
+func (*policyChangeM) TableName() string {
+	return "casbin_policy_change"
+}
+
+// DBWatcherOptions 定义了基于数据库轮询的广播器的配置.
+type DBWatcherOptions struct {
+	// 轮询策略版本的时间间隔
+	PollInterval time.Duration
+	// 策略变更记录的保留时间, 落后超过该时间的授权器会重新加载全部策略
+	Retention time.Duration
+	// 轮询失败时的处理函数, 例如记录日志
+	OnError func(err error)
+}
+
+// DBWatcher 通过数据库在多个实例之间广播策略变更.
+// 发布变更时将策略版本号加一并写入变更记录, 其他实例轮询到新版本后按版本顺序增量应用变更.
+type DBWatcher struct {
+	watcherBase
+	db   *gorm.DB
+	opts *DBWatcherOptions
+	// 当前实例的唯一标识, 轮询时跳过当前实例自己发布的变更
+	instance string
+
+	mu sync.Mutex
+	// 已经处理过的策略版本
+	version   int64
+	lastPrune time.Time
+
+	stop   chan struct{}
+	closed sync.Once
+}
+
+// 确保 DBWatcher 实现了 Watcher 接口.
+var _ Watcher = (*DBWatcher)(nil)
+
+// NewDBWatcher 创建基于数据库轮询的广播器.
+// 需要在授权器加载策略之前创建, 加载期间发生的变更会在之后重新应用一次.
+func NewDBWatcher(db *gorm.DB, opts *DBWatcherOptions) (*DBWatcher, error) {
+	if opts.PollInterval <= 0 {
+		return nil, errors.New("poll interval must be greater than 0")
+	}
+
+	if err := db.AutoMigrate(&policyVersionM{}, &policyChangeM{}); err != nil {
+		return nil, err
+	}
+	// 第一个启动的实例负责创建版本记录
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&policyVersionM{ID: policyVersionID, UpdatedAt: time.Now()}).Error; err != nil {
+		return nil, err
+	}
+
+	var current policyVersionM
+	if err := db.Take(&current, policyVersionID).Error; err != nil {
+		return nil, err
+	}
+
+	w := &DBWatcher{
+		db:        db,
+		opts:      opts,
+		instance:  uuid.NewString(),
+		version:   current.Version,
+		lastPrune: time.Now(),
+		stop:      make(chan struct{}),
+	}
+	// 变更由授权器的适配器在写入策略的事务中记录, 见 txAdapter
+	w.publish = func(*PolicyChange) error { return nil }
+	w.subscribe = func() { go w.run() }
+
+	return w, nil
+}
+
+// 在写入策略的事务tx中将策略版本号加一并写入变更记录.
+// 版本号的更新和变更记录与策略在同一个事务中写入, 轮询到新版本时一定能读到对应的变更和策略.
+func (w *DBWatcher) record(tx *gorm.DB, change *PolicyChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := tx.Model(&policyVersionM{}).
+		Where("id = ?", policyVersionID).
+		Updates(map[string]any{"version": gorm.Expr("version + 1"), "updatedAt": now}).Error; err != nil {
+		return err
+	}
+
+	var current policyVersionM
+	if err := tx.Take(&current, policyVersionID).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&policyChangeM{Version: current.Version, Instance: w.instance, Payload: string(payload), CreatedAt: now}).Error
+}
+
+// Sync 读取其他实例发布的策略变更并交给处理函数.
+// 需要的变更记录已经被清理时, 通知处理函数重新加载全部策略.
+func (w *DBWatcher) Sync(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var current policyVersionM
+	if err := w.db.WithContext(ctx).Take(&current, policyVersionID).Error; err != nil {
+		return err
+	}
+	if current.Version <= w.version {
+		return nil
+	}
+
+	var changes []*policyChangeM
+	if err := w.db.WithContext(ctx).
+		Where("version > ? AND version <= ?", w.version, current.Version).
+		Order("version").
+		Find(&changes).Error; err != nil {
+		return err
+	}
+
+	if int64(len(changes)) != current.Version-w.version {
+		w.dispatch(&PolicyChange{Op: OpReload})
+	} else {
+		for _, changeM := range changes {
+			if changeM.Instance == w.instance {
+				continue
+			}
+
+			var change PolicyChange
+			if err := json.Unmarshal([]byte(changeM.Payload), &change); err != nil {
+				change = PolicyChange{Op: OpReload}
+			}
+			w.dispatch(&change)
+		}
+	}
+	w.version = current.Version
+
+	return nil
+}
+
+// 清理超过保留时间的变更记录.
+func (w *DBWatcher) prune(ctx context.Context) error {
+	if w.opts.Retention <= 0 || time.Since(w.lastPrune) < w.opts.Retention/2 {
+		return nil
+	}
+	w.lastPrune = time.Now()
+
+	return w.db.WithContext(ctx).Where("createdAt < ?", time.Now().Add(-w.opts.Retention)).Delete(&policyChangeM{}).Error
+}
+
+// 每隔PollInterval轮询一次策略版本, 直到广播器关闭.
+func (w *DBWatcher) run() {
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx := context.Background()
+			if err := w.Sync(ctx); err != nil {
+				w.fail(err)
+			}
+			if err := w.prune(ctx); err != nil {
+				w.fail(err)
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// 记录轮询失败, 下一次轮询时会重试.
+func (w *DBWatcher) fail(err error) {
+	policySyncErrors.Inc()
+	if w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
+
+// Close 停止轮询, 之后不再收到其他实例的变更.
+func (w *DBWatcher) Close() {
+	w.closed.Do(func() {
+		close(w.stop)
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"sync"
+)
+
+// LocalHub 在同一进程内的多个授权器之间广播策略变更, 适用于单实例部署和测试.
+type LocalHub struct {
+	mu       sync.RWMutex
+	watchers map[*LocalWatcher]struct{}
+}
+
+// LocalWatcher 是连接到LocalHub的广播器.
+// 收到的变更先放入队列, 再由后台协程依次处理, 避免在发布变更的授权器持有锁时同步修改其他授权器.
+type LocalWatcher struct {
+	watcherBase
+	hub *LocalHub
+
+	mu     sync.Mutex
+	queue  []*PolicyChange
+	notify chan struct{}
+	stop   chan struct{}
+	closed sync.Once
+}
+
+// 确保 LocalWatcher 实现了 Watcher 接口.
+var _ Watcher = (*LocalWatcher)(nil)
+
+// NewLocalHub 创建进程内的策略变更广播中心.
+func NewLocalHub() *LocalHub {
+	return &LocalHub{watchers: make(map[*LocalWatcher]struct{})}
+}
+
+// NewWatcher 创建一个连接到广播中心的广播器, 每个授权器使用各自的广播器.
+func (h *LocalHub) NewWatcher() *LocalWatcher {
+	w := &LocalWatcher{
+		hub:    h,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+	w.publish = w.broadcast
+	w.subscribe = func() { go w.run() }
+
+	h.mu.Lock()
+	h.watchers[w] = struct{}{}
+	h.mu.Unlock()
+
+	return w
+}
+
+// 将变更发送给广播中心中的其他广播器.
+func (w *LocalWatcher) broadcast(change *PolicyChange) error {
+	w.hub.mu.RLock()
+	defer w.hub.mu.RUnlock()
+
+	// 每个授权器使用各自的副本, 应用变更时策略会被保存到授权器的模型中
+	for other := range w.hub.watchers {
+		if other != w {
+			cloned := *change
+			cloned.Rules, cloned.NewRules = cloneRules(change.Rules), cloneRules(change.NewRules)
+			other.enqueue(&cloned)
+		}
+	}
+
+	return nil
+}
+
+// 将变更放入队列并唤醒后台协程.
+func (w *LocalWatcher) enqueue(change *PolicyChange) {
+	w.mu.Lock()
+	w.queue = append(w.queue, change)
+	w.mu.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// 依次处理队列中的变更, 直到广播器关闭.
+func (w *LocalWatcher) run() {
+	for {
+		select {
+		case <-w.notify:
+			w.mu.Lock()
+			changes := w.queue
+			w.queue = nil
+			w.mu.Unlock()
+
+			for _, change := range changes {
+				w.dispatch(change)
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Close 断开与广播中心的连接, 之后不再收到其他授权器的变更.
+func (w *LocalWatcher) Close() {
+	w.closed.Do(func() {
+		w.hub.mu.Lock()
+		delete(w.hub.watchers, w)
+		w.hub.mu.Unlock()
+
+		close(w.stop)
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 等待其他授权器应用变更的超时时间.
+const syncTimeout = 2 * time.Second
+
+// 创建一个多个连接共享的内存数据库, 模拟多个实例使用同一个数据库.
+func newSharedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	return db
+}
+
+func newDBWatcher(t *testing.T, db *gorm.DB) *DBWatcher {
+	t.Helper()
+
+	w, err := NewDBWatcher(db, &DBWatcherOptions{PollInterval: 10 * time.Millisecond, Retention: time.Hour})
+	require.NoError(t, err)
+	t.Cleanup(w.Close)
+
+	return w
+}
+
+// 检查授权器之间能否同步添加, 删除和按字段删除策略.
+func testPolicySync(t *testing.T, a1, a2 *Authz) {
+	t.Helper()
+
+	_, err := a1.AddPolicy("role::user", "*", "post", "delete", "deny")
+	require.NoError(t, err)
+	_, err = a1.AddGroupingPolicy("user-000001", "role::user", "default")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		allowed, _ := a2.Authorize("user-000001", "default", "post", "delete")
+		return !allowed
+	}, syncTimeout, 10*time.Millisecond)
+
+	_, err = a2.RemoveGroupingPolicy("user-000001", "role::user", "default")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		allowed, _ := a1.Authorize("user-000001", "default", "post", "delete")
+		return allowed
+	}, syncTimeout, 10*time.Millisecond)
+
+	_, err = a1.RemoveFilteredPolicy(0, "role::user")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		policies, _ := a2.GetPolicy()
+		return len(policies) == 0
+	}, syncTimeout, 10*time.Millisecond)
+}
+
+func TestLocalWatcher(t *testing.T) {
+	hub := NewLocalHub()
+	w1, w2 := hub.NewWatcher(), hub.NewWatcher()
+	t.Cleanup(w1.Close)
+	t.Cleanup(w2.Close)
+
+	// 使用不同的数据库, 确保策略是通过广播器同步的
+	a1 := newTestAuthz(t, WithWatcher(w1))
+	a2 := newTestAuthz(t, WithWatcher(w2))
+
+	testPolicySync(t, a1, a2)
+}
+
+func TestDBWatcher(t *testing.T) {
+	db := newSharedDB(t)
+
+	a1, err := NewAuthz(db, WithWatcher(newDBWatcher(t, db)))
+	require.NoError(t, err)
+	a2, err := NewAuthz(db, WithWatcher(newDBWatcher(t, db)))
+	require.NoError(t, err)
+
+	testPolicySync(t, a1, a2)
+}
+
+func TestDBWatcherReloadAfterPrune(t *testing.T) {
+	db := newSharedDB(t)
+
+	w1 := newDBWatcher(t, db)
+	a1, err := NewAuthz(db, WithWatcher(w1))
+	require.NoError(t, err)
+
+	// 不启动轮询, 手动同步
+	w2, err := NewDBWatcher(db, &DBWatcherOptions{PollInterval: time.Hour})
+	require.NoError(t, err)
+	t.Cleanup(w2.Close)
+	a2, err := NewAuthz(db, WithWatcher(w2))
+	require.NoError(t, err)
+
+	_, err = a1.AddPolicy("role::user", "*", "post", "delete", "deny")
+	require.NoError(t, err)
+
+	// 清理变更记录后, 落后的授权器只能重新加载全部策略
+	require.NoError(t, db.Where("1 = 1").Delete(&policyChangeM{}).Error)
+	require.NoError(t, w2.Sync(context.Background()))
+
+	has, err := a2.HasPolicy("role::user", "*", "post", "delete", "deny")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestDBWatcherTransaction(t *testing.T) {
+	db := newSharedDB(t)
+	a, err := NewAuthz(db, WithWatcher(newDBWatcher(t, db)))
+	require.NoError(t, err)
+
+	countRules := func() int64 {
+		var count int64
+		require.NoError(t, db.Table(casbinRuleTable).Count(&count).Error)
+		return count
+	}
+
+	// 策略和变更记录一起写入
+	_, err = a.AddPolicy("role::user", "*", "post", "delete", "deny")
+	require.NoError(t, err)
+	var changes int64
+	require.NoError(t, db.Model(&policyChangeM{}).Count(&changes).Error)
+	assert.EqualValues(t, 1, changes)
+	assert.EqualValues(t, 1, countRules())
+
+	// 变更记录写入失败时策略同样不会写入
+	require.NoError(t, db.Migrator().DropTable(&policyChangeM{}))
+	_, err = a.AddPolicy("role::user", "*", "post", "update", "deny")
+	assert.Error(t, err)
+	_, err = a.RemovePolicy("role::user", "*", "post", "delete", "deny")
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, countRules())
+
+	var current policyVersionM
+	require.NoError(t, db.Take(&current, policyVersionID).Error)
+	assert.EqualValues(t, 1, current.Version)
+}
+
+func TestNewDBWatcherInvalidInterval(t *testing.T) {
+	_, err := NewDBWatcher(newSharedDB(t), &DBWatcherOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package mail 定义了发送邮件的Mailer接口, 并提供了写入文件或标准输出以及通过SMTP发送的实现.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message 表示一封纯文本邮件.
+type Message struct {
+	// 收件人地址
+	To []string
+	// 邮件主题
+	Subject string
+	// 邮件正文
+	Body string
+}
+
+// Mailer 定义了发送邮件的方法.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// writerMailer 将邮件按RFC 5322格式写入io.Writer, 用于开发和测试环境.
+type writerMailer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+// 确保 writerMailer 实现了 Mailer 接口.
+var _ Mailer = (*writerMailer)(nil)
+
+// NewWriter 创建将邮件写入w的Mailer, 多封邮件之间以空行分隔.
+func NewWriter(w io.Writer, from string) Mailer {
+	return &writerMailer{w: w, from: from}
+}
+
+// NewStdout 创建将邮件写入标准输出的Mailer.
+func NewStdout(from string) Mailer {
+	return NewWriter(os.Stdout, from)
+}
+
+// NewFile 创建将邮件追加写入文件的Mailer, 文件不存在时自动创建.
+func NewFile(path string, from string) (Mailer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open mail file %q: %w", path, err)
+	}
+
+	return NewWriter(f, from), nil
+}
+
+// Send 将邮件写入io.Writer.
+func (m *writerMailer) Send(ctx context.Context, msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := msg.bytes(m.from, time.Now())
+	data = append(data, "\r\n"...)
+	_, err := m.w.Write(data)
+	return err
+}
+
+// 按RFC 5322格式编码邮件, 行尾统一使用CRLF.
+func (msg *Message) bytes(from string, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		buf.WriteString("\r\n")
+	}
+
+	return buf.Bytes()
+}
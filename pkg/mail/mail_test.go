@@ -0,0 +1,196 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer 是一个只实现了最小命令集的SMTP服务器, 记录收到的邮件.
+type fakeSMTPServer struct {
+	ln net.Listener
+	// 服务器要求的PLAIN认证凭据, 为空时不支持认证
+	auth string
+	// 每收到一封邮件发送一次会话记录
+	sessions chan fakeSession
+}
+
+// fakeSession 记录一次SMTP会话的内容.
+type fakeSession struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// 启动监听本机随机端口的SMTP服务器, 测试结束后关闭.
+func newFakeSMTPServer(t *testing.T, username string, password string) *fakeSMTPServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTPServer{ln: ln, sessions: make(chan fakeSession, 8)}
+	if username != "" {
+		s.auth = "\x00" + username + "\x00" + password
+	}
+	go s.serve()
+
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var session fakeSession
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost fake smtp")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			if s.auth != "" {
+				_ = tp.PrintfLine("250-localhost")
+				_ = tp.PrintfLine("250 AUTH PLAIN")
+			} else {
+				_ = tp.PrintfLine("250 localhost")
+			}
+		case "AUTH":
+			_, resp, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(resp)
+			if string(decoded) != s.auth {
+				_ = tp.PrintfLine("535 authentication failed")
+				continue
+			}
+			session.auth = string(decoded)
+			_ = tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			if s.auth != "" && session.auth == "" {
+				_ = tp.PrintfLine("530 authentication required")
+				continue
+			}
+			session.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			session.to = append(session.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			session.data = string(data)
+			_ = tp.PrintfLine("250 queued")
+			s.sessions <- session
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// 等待服务器收到一封邮件.
+func (s *fakeSMTPServer) wait(t *testing.T) fakeSession {
+	t.Helper()
+
+	select {
+	case session := <-s.sessions:
+		return session
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for smtp session")
+		return fakeSession{}
+	}
+}
+
+func TestSMTPMailer(t *testing.T) {
+	server := newFakeSMTPServer(t, "", "")
+	mailer, err := NewSMTP(SMTPConfig{Addr: server.ln.Addr().String(), From: "miniblog <noreply@miniblog.io>", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), &Message{
+		To:      []string{"alice@example.com", "bob@example.com"},
+		Subject: "重置密码",
+		Body:    "line 1\nline 2",
+	})
+	require.NoError(t, err)
+
+	session := server.wait(t)
+	assert.Equal(t, "noreply@miniblog.io", session.from)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, session.to)
+	assert.Contains(t, session.data, "From: miniblog <noreply@miniblog.io>\n")
+	assert.Contains(t, session.data, "To: alice@example.com, bob@example.com\n")
+	assert.Contains(t, session.data, "Subject: =?utf-8?q?")
+	assert.True(t, strings.HasSuffix(session.data, "\nline 1\nline 2\n"))
+}
+
+func TestSMTPMailerAuth(t *testing.T) {
+	server := newFakeSMTPServer(t, "miniblog", "secret")
+
+	// 凭据错误时发送失败
+	mailer, err := NewSMTP(SMTPConfig{Addr: server.ln.Addr().String(), Username: "miniblog", Password: "wrong", From: "noreply@miniblog.io"})
+	require.NoError(t, err)
+	assert.Error(t, mailer.Send(context.Background(), &Message{To: []string{"alice@example.com"}, Subject: "hi", Body: "hi"}))
+
+	mailer, err = NewSMTP(SMTPConfig{Addr: server.ln.Addr().String(), Username: "miniblog", Password: "secret", From: "noreply@miniblog.io"})
+	require.NoError(t, err)
+	require.NoError(t, mailer.Send(context.Background(), &Message{To: []string{"alice@example.com"}, Subject: "hi", Body: "hi"}))
+
+	session := server.wait(t)
+	assert.Equal(t, "\x00miniblog\x00secret", session.auth)
+	assert.Equal(t, []string{"alice@example.com"}, session.to)
+}
+
+func TestNewSMTP(t *testing.T) {
+	_, err := NewSMTP(SMTPConfig{Addr: "localhost", From: "noreply@miniblog.io"})
+	assert.Error(t, err)
+
+	_, err = NewSMTP(SMTPConfig{Addr: "localhost:25"})
+	assert.Error(t, err)
+
+	_, err = NewSMTP(SMTPConfig{Addr: "localhost:25", From: "not an address"})
+	assert.Error(t, err)
+}
+
+func TestWriterMailer(t *testing.T) {
+	var buf bytes.Buffer
+	mailer := NewWriter(&buf, "noreply@miniblog.io")
+
+	require.NoError(t, mailer.Send(context.Background(), &Message{To: []string{"alice@example.com"}, Subject: "first", Body: "hello\n"}))
+	require.NoError(t, mailer.Send(context.Background(), &Message{To: []string{"bob@example.com"}, Subject: "second", Body: "world"}))
+
+	out := buf.String()
+	assert.Contains(t, out, "From: noreply@miniblog.io\r\nTo: alice@example.com\r\n")
+	assert.Contains(t, out, "\r\n\r\nhello\r\n\r\nFrom: noreply@miniblog.io\r\nTo: bob@example.com\r\n")
+	assert.True(t, strings.HasSuffix(out, "\r\n\r\nworld\r\n\r\n"))
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPConfig 定义了连接SMTP服务器的配置.
+type SMTPConfig struct {
+	// SMTP服务器地址, 格式为host:port
+	Addr string
+	// 认证用户名, 为空时不进行认证
+	Username string
+	// 认证密码
+	Password string
+	// 发件人地址
+	From string
+	// 连接和发送的超时时间, 为0时只受ctx控制
+	Timeout time.Duration
+}
+
+// smtpMailer 通过SMTP服务器发送邮件.
+type smtpMailer struct {
+	cfg  SMTPConfig
+	host string
+	// 信封发件人, 即From中不带显示名称的邮箱地址
+	sender string
+}
+
+// 确保 smtpMailer 实现了 Mailer 接口.
+var _ Mailer = (*smtpMailer)(nil)
+
+// NewSMTP 创建通过SMTP服务器发送邮件的Mailer.
+// 服务器支持STARTTLS时自动升级为加密连接, net/smtp只允许在加密连接或本机地址上使用PLAIN认证.
+func NewSMTP(cfg SMTPConfig) (Mailer, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp address %q: %w", cfg.Addr, err)
+	}
+	if cfg.From == "" {
+		return nil, errors.New("smtp sender address is required")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp sender address %q: %w", cfg.From, err)
+	}
+
+	return &smtpMailer{cfg: cfg, host: host, sender: from.Address}, nil
+}
+
+// Send 通过SMTP服务器发送邮件, 每封邮件使用一个新连接.
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	if m.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.Timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support authentication")
+		}
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(m.sender); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.bytes(m.cfg.From, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
@@ -0,0 +1,375 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package oidc 实现了OpenID Connect授权码流程的客户端, 授权请求使用PKCE防止授权码被截获后冒用.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+
+	"miniblog/pkg/token"
+)
+
+// 身份提供方响应的最大长度.
+const maxResponseSize = 1 << 20
+
+// 遇到未知kid时重新获取JWKS的最小间隔, 防止伪造的kid导致频繁请求身份提供方.
+const jwksRefreshInterval = time.Minute
+
+// 身份提供方签发ID Token支持的签名算法.
+var supportedAlgorithms = []string{token.AlgorithmRS256, token.AlgorithmES256, token.AlgorithmEdDSA}
+
+// Config 定义了一个身份提供方的客户端配置.
+type Config struct {
+	// 身份提供方的issuer地址, 用于服务发现和校验ID Token的iss声明
+	Issuer string
+	// 在身份提供方注册的客户端ID
+	ClientID string
+	// 客户端密钥, 为空时作为公共客户端只依赖PKCE
+	ClientSecret string
+	// 授权完成后身份提供方重定向的地址
+	RedirectURL string
+	// 申请的scope, 为空时使用openid, profile和email
+	Scopes []string
+	// ID Token声明到用户字段的映射
+	Claims ClaimMapping
+	// 请求身份提供方使用的HTTP客户端, 为空时使用带超时的默认客户端
+	HTTPClient *http.Client
+}
+
+// ClaimMapping 定义了用户字段取自ID Token中的哪个声明, 为空时使用标准声明.
+type ClaimMapping struct {
+	Username      string
+	Email         string
+	EmailVerified string
+	Nickname      string
+	Phone         string
+}
+
+// Identity 表示身份提供方认证的外部用户.
+type Identity struct {
+	// 外部用户在身份提供方中的唯一标识, 即sub声明
+	Subject       string
+	Username      string
+	Email         string
+	EmailVerified bool
+	Nickname      string
+	Phone         string
+}
+
+// 服务发现文档中用到的字段.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider 是一个身份提供方的客户端, 服务发现文档和公钥在首次使用时获取并缓存.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *metadata
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// NewProvider 创建身份提供方客户端, 不会立即请求身份提供方.
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("oidc issuer, client id and redirect url are required")
+	}
+	if _, err := url.ParseRequestURI(cfg.RedirectURL); err != nil {
+		return nil, fmt.Errorf("invalid oidc redirect url %q: %w", cfg.RedirectURL, err)
+	}
+
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	cfg.Claims = cfg.Claims.withDefaults()
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{cfg: cfg, client: client}, nil
+}
+
+// 为空的映射使用标准声明.
+func (m ClaimMapping) withDefaults() ClaimMapping {
+	if m.Username == "" {
+		m.Username = "preferred_username"
+	}
+	if m.Email == "" {
+		m.Email = "email"
+	}
+	if m.EmailVerified == "" {
+		m.EmailVerified = "email_verified"
+	}
+	if m.Nickname == "" {
+		m.Nickname = "name"
+	}
+	if m.Phone == "" {
+		m.Phone = "phone_number"
+	}
+	return m
+}
+
+// AuthCodeURL 返回身份提供方的授权地址, 授权请求携带state, nonce和由codeVerifier计算出的PKCE挑战.
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeVerifier string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(md.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", S256Challenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// Authenticate 使用授权码和PKCE校验码换取ID Token, 校验后返回外部用户.
+func (p *Provider) Authenticate(ctx context.Context, code string, codeVerifier string, nonce string) (*Identity, error) {
+	rawIDToken, err := p.exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := p.verifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.identity(claims)
+}
+
+// 获取并缓存服务发现文档.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var md metadata
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &md); err != nil {
+		return nil, fmt.Errorf("fetch oidc discovery document: %w", err)
+	}
+	// 发现文档中的issuer必须与配置一致, 防止被其他身份提供方冒充
+	if strings.TrimSuffix(md.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc issuer mismatch: expected %q, got %q", p.cfg.Issuer, md.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is missing required endpoints")
+	}
+
+	p.metadata = &md
+	return p.metadata, nil
+}
+
+// 在令牌端点使用授权码换取ID Token, 配置了客户端密钥时使用HTTP Basic认证.
+func (p *Provider) exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode oidc token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc token request failed with status %d: %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", errors.New("oidc token response does not contain an id token")
+	}
+
+	return body.IDToken, nil
+}
+
+// 校验ID Token的签名, 有效期, iss, aud和nonce声明.
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken string, nonce string) (jwt.MapClaims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	parser := jwt.Parser{ValidMethods: supportedAlgorithms}
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.publicKey(ctx, md, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if !claims.VerifyIssuer(md.Issuer, true) {
+		return nil, errors.New("invalid id token: issuer mismatch")
+	}
+	if !claims.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, errors.New("invalid id token: audience mismatch")
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.New("invalid id token: missing exp claim")
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+
+	return claims, nil
+}
+
+// 返回kid对应的公钥, 缓存中没有时重新获取JWKS, 以支持身份提供方轮换密钥.
+func (p *Provider) publicKey(ctx context.Context, md *metadata, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	var set token.JSONWebKeySet
+	if err := p.getJSON(ctx, md.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("fetch oidc jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// 无法识别的密钥不影响其他密钥的使用
+		if key, err := jwk.PublicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// 在缓存的公钥中查找kid, ID Token没有kid时只有一个公钥才能确定使用哪个.
+func (p *Provider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// 按声明映射从ID Token中提取外部用户.
+func (p *Provider) identity(claims jwt.MapClaims) (*Identity, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("invalid id token: missing sub claim")
+	}
+
+	str := func(name string) string {
+		value, _ := claims[name].(string)
+		return value
+	}
+	// 部分身份提供方以字符串形式返回布尔声明
+	verified := false
+	switch v := claims[p.cfg.Claims.EmailVerified].(type) {
+	case bool:
+		verified = v
+	case string:
+		verified = v == "true"
+	}
+
+	return &Identity{
+		Subject:       subject,
+		Username:      str(p.cfg.Claims.Username),
+		Email:         str(p.cfg.Claims.Email),
+		EmailVerified: verified,
+		Nickname:      str(p.cfg.Claims.Nickname),
+		Phone:         str(p.cfg.Claims.Phone),
+	}, nil
+}
+
+// 请求身份提供方并解码JSON响应.
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
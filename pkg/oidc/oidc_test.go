@@ -0,0 +1,134 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniblog/pkg/oidc"
+	"miniblog/pkg/oidc/oidctest"
+)
+
+const redirectURL = "http://127.0.0.1:5555/oidc/callback"
+
+// 按浏览器的行为访问授权地址, 返回身份提供方重定向回来的授权码和state.
+func authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func newProvider(t *testing.T, server *oidctest.Server, claims oidc.ClaimMapping) *oidc.Provider {
+	t.Helper()
+
+	provider, err := oidc.NewProvider(oidc.Config{
+		Issuer:       server.URL,
+		ClientID:     server.ClientID,
+		ClientSecret: server.ClientSecret,
+		RedirectURL:  redirectURL,
+		Claims:       claims,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	server := oidctest.NewServer("miniblog", "secret")
+	defer server.Close()
+	server.SetClaims(map[string]any{
+		"sub":            "10001",
+		"login":          "alice",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"name":           "Alice",
+	})
+
+	ctx := context.Background()
+	provider := newProvider(t, server, oidc.ClaimMapping{Username: "login"})
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	require.NoError(t, err)
+	query, _ := url.Parse(authURL)
+	assert.Equal(t, oidc.S256Challenge(verifier), query.Query().Get("code_challenge"))
+	assert.Equal(t, "openid profile email", query.Query().Get("scope"))
+
+	code, state := authorize(t, authURL)
+	assert.Equal(t, "state-1", state)
+
+	identity, err := provider.Authenticate(ctx, code, verifier, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, &oidc.Identity{
+		Subject:       "10001",
+		Username:      "alice",
+		Email:         "alice@example.com",
+		EmailVerified: true,
+		Nickname:      "Alice",
+	}, identity)
+
+	// 授权码只能使用一次
+	_, err = provider.Authenticate(ctx, code, verifier, "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestAuthenticateRejectsMismatch(t *testing.T) {
+	server := oidctest.NewServer("miniblog", "secret")
+	defer server.Close()
+
+	ctx := context.Background()
+	provider := newProvider(t, server, oidc.ClaimMapping{})
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+
+	// PKCE校验码不匹配时身份提供方拒绝兑换授权码
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", verifier)
+	require.NoError(t, err)
+	code, _ := authorize(t, authURL)
+	_, err = provider.Authenticate(ctx, code, "another-verifier-another-verifier-another-ve", "nonce")
+	assert.Error(t, err)
+
+	// nonce不匹配时拒绝ID Token
+	code, _ = authorize(t, authURL)
+	_, err = provider.Authenticate(ctx, code, verifier, "another-nonce")
+	assert.Error(t, err)
+
+	// 客户端密钥错误
+	wrongSecret, err := oidc.NewProvider(oidc.Config{Issuer: server.URL, ClientID: "miniblog", ClientSecret: "wrong", RedirectURL: redirectURL})
+	require.NoError(t, err)
+	code, _ = authorize(t, authURL)
+	_, err = wrongSecret.Authenticate(ctx, code, verifier, "nonce")
+	assert.Error(t, err)
+
+	// 发现文档中的issuer与配置不一致
+	impostor, err := oidc.NewProvider(oidc.Config{Issuer: strings.Replace(server.URL, "127.0.0.1", "localhost", 1), ClientID: "miniblog", RedirectURL: redirectURL})
+	require.NoError(t, err)
+	_, err = impostor.AuthCodeURL(ctx, "state", "nonce", verifier)
+	assert.Error(t, err)
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := oidc.NewProvider(oidc.Config{ClientID: "miniblog", RedirectURL: redirectURL})
+	assert.Error(t, err)
+
+	_, err = oidc.NewProvider(oidc.Config{Issuer: "https://idp.example.com", ClientID: "miniblog", RedirectURL: "callback"})
+	assert.Error(t, err)
+}
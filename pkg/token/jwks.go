@@ -0,0 +1,221 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// JSONWebKey 表示JWKS中的一个公钥, 字段定义见RFC 7517和RFC 8037.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA公钥
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC和OKP公钥
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet 表示/.well-known/jwks.json返回的公钥集合.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewKeyFromPEM 从PEM编码的私钥和公钥创建非对称签名密钥.
+// 私钥和公钥至少提供一个, 只提供私钥时从私钥中导出公钥, 只提供公钥时该密钥只能用于校验.
+func NewKeyFromPEM(id string, algorithm string, privatePEM []byte, publicPEM []byte) (Key, error) {
+	key := Key{ID: id, Algorithm: algorithm}
+	if len(privatePEM) == 0 && len(publicPEM) == 0 {
+		return Key{}, fmt.Errorf("signing key %q has neither a private nor a public key", id)
+	}
+
+	var err error
+	if len(privatePEM) != 0 {
+		if key.PrivateKey, err = parsePrivateKey(algorithm, privatePEM); err != nil {
+			return Key{}, fmt.Errorf("parse private key of signing key %q: %w", id, err)
+		}
+		key.PublicKey = key.PrivateKey.(crypto.Signer).Public()
+	}
+	if len(publicPEM) != 0 {
+		publicKey, err := parsePublicKey(algorithm, publicPEM)
+		if err != nil {
+			return Key{}, fmt.Errorf("parse public key of signing key %q: %w", id, err)
+		}
+		if key.PublicKey != nil && !publicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(key.PublicKey) {
+			return Key{}, fmt.Errorf("public key of signing key %q does not match its private key", id)
+		}
+		key.PublicKey = publicKey
+	}
+
+	if err := key.validate(); err != nil {
+		return Key{}, err
+	}
+
+	return key, nil
+}
+
+// 按算法解析PEM编码的私钥.
+func parsePrivateKey(algorithm string, data []byte) (crypto.PrivateKey, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.ParseRSAPrivateKeyFromPEM(data)
+	case AlgorithmES256:
+		return jwt.ParseECPrivateKeyFromPEM(data)
+	case AlgorithmEdDSA:
+		return jwt.ParseEdPrivateKeyFromPEM(data)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+}
+
+// 按算法解析PEM编码的公钥.
+func parsePublicKey(algorithm string, data []byte) (crypto.PublicKey, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.ParseRSAPublicKeyFromPEM(data)
+	case AlgorithmES256:
+		return jwt.ParseECPublicKeyFromPEM(data)
+	case AlgorithmEdDSA:
+		return jwt.ParseEdPublicKeyFromPEM(data)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+}
+
+// 判断公钥的类型是否与签名算法匹配, ES256只支持P-256曲线.
+func publicKeyMatches(algorithm string, publicKey crypto.PublicKey) bool {
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		return algorithm == AlgorithmRS256
+	case *ecdsa.PublicKey:
+		return algorithm == AlgorithmES256 && pub.Curve == elliptic.P256()
+	case ed25519.PublicKey:
+		return algorithm == AlgorithmEdDSA
+	default:
+		return false
+	}
+}
+
+// JWKS 返回当前密钥环中未退役的非对称密钥的公钥集合, HMAC密钥不会公开.
+func JWKS() *JSONWebKeySet {
+	return currentKeyring().JWKS()
+}
+
+// JWKS 返回密钥环中未退役的非对称密钥的公钥集合, 按kid排序.
+func (kr *Keyring) JWKS() *JSONWebKeySet {
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	current := now()
+	for _, key := range kr.keys {
+		if key.retired(current) {
+			continue
+		}
+		if jwk, err := key.jwk(); err == nil {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+
+	return set
+}
+
+// 将公钥编码为JWK, HMAC密钥返回错误.
+func (k Key) jwk() (JSONWebKey, error) {
+	jwk := JSONWebKey{Kid: k.ID, Use: "sig", Alg: k.method().Alg()}
+
+	switch pub := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeSegment(pub.N.Bytes())
+		jwk.E = encodeSegment(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = encodeSegment(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = encodeSegment(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeSegment(pub)
+	default:
+		return JSONWebKey{}, errors.New("signing key has no public key")
+	}
+
+	return jwk, nil
+}
+
+// 使用不带填充的base64url编码.
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// PublicKey 将JWK解码为公钥, 支持RSA, P-256曲线的EC和Ed25519公钥.
+func (jwk JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeSegment(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeSegment(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA key %q", jwk.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if jwk.Crv != elliptic.P256().Params().Name {
+			return nil, fmt.Errorf("unsupported curve %q of EC key %q", jwk.Crv, jwk.Kid)
+		}
+		x, err := decodeSegment(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeSegment(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if _, err := pub.ECDH(); err != nil {
+			return nil, fmt.Errorf("invalid EC key %q", jwk.Kid)
+		}
+		return pub, nil
+	case "OKP":
+		x, err := decodeSegment(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid OKP key %q", jwk.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q of key %q", jwk.Kty, jwk.Kid)
+	}
+}
+
+// 解码不带填充的base64url编码.
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(segment)
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 生成指定算法的密钥对, 返回PEM编码的私钥和公钥.
+func generatePEM(t *testing.T, algorithm string) ([]byte, []byte) {
+	t.Helper()
+
+	var (
+		privateKey crypto.Signer
+		err        error
+	)
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func TestAsymmetricSigning(t *testing.T) {
+	for _, tc := range []struct {
+		algorithm string
+		kty       string
+		crv       string
+	}{
+		{algorithm: AlgorithmRS256, kty: "RSA"},
+		{algorithm: AlgorithmES256, kty: "EC", crv: "P-256"},
+		{algorithm: AlgorithmEdDSA, kty: "OKP", crv: "Ed25519"},
+	} {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			privatePEM, publicPEM := generatePEM(t, tc.algorithm)
+			key, err := NewKeyFromPEM("k1", tc.algorithm, privatePEM, nil)
+			require.NoError(t, err)
+			kr, err := NewKeyring("k1", key)
+			require.NoError(t, err)
+			useKeyring(t, kr)
+
+			tokenString, _, err := Sign("user-000001")
+			require.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.algorithm, parsed.Method.Alg())
+
+			claims, err := Verify(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, "user-000001", claims.Identity)
+
+			// 只持有公钥的一方同样可以校验token
+			verifier, err := NewKeyFromPEM("k1", tc.algorithm, nil, publicPEM)
+			require.NoError(t, err)
+			vkr := &Keyring{active: "k1", keys: map[string]Key{"k1": verifier}}
+			_, err = parse(tokenString, vkr.keyFunc, "")
+			assert.NoError(t, err)
+
+			jwks := JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, "k1", jwks.Keys[0].Kid)
+			assert.Equal(t, tc.kty, jwks.Keys[0].Kty)
+			assert.Equal(t, tc.crv, jwks.Keys[0].Crv)
+			assert.Equal(t, tc.algorithm, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func TestNewKeyFromPEM(t *testing.T) {
+	privatePEM, _ := generatePEM(t, AlgorithmRS256)
+	_, otherPublicPEM := generatePEM(t, AlgorithmRS256)
+
+	_, err := NewKeyFromPEM("k1", AlgorithmRS256, nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewKeyFromPEM("k1", AlgorithmES256, privatePEM, nil)
+	assert.Error(t, err)
+
+	_, err = NewKeyFromPEM("k1", AlgorithmRS256, privatePEM, otherPublicPEM)
+	assert.Error(t, err)
+
+	// 只有公钥的密钥不能作为活动密钥
+	_, publicPEM := generatePEM(t, AlgorithmEdDSA)
+	key, err := NewKeyFromPEM("k1", AlgorithmEdDSA, nil, publicPEM)
+	require.NoError(t, err)
+	_, err = NewKeyring("k1", key)
+	assert.Error(t, err)
+}
+
+func TestJWKSExcludesSecretAndRetiredKeys(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, base)
+
+	privatePEM, _ := generatePEM(t, AlgorithmES256)
+	k1, err := NewKeyFromPEM("k1", AlgorithmES256, privatePEM, nil)
+	require.NoError(t, err)
+	k1.RetireAt = base.Add(time.Hour)
+	privatePEM, _ = generatePEM(t, AlgorithmEdDSA)
+	k2, err := NewKeyFromPEM("k2", AlgorithmEdDSA, privatePEM, nil)
+	require.NoError(t, err)
+	hmac := Key{ID: "k0", Secret: []byte("secret-0")}
+
+	kr, err := NewKeyring("k2", hmac, k1, k2)
+	require.NoError(t, err)
+
+	jwks := kr.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "k1", jwks.Keys[0].Kid)
+	assert.Equal(t, "k2", jwks.Keys[1].Kid)
+
+	setNow(t, base.Add(time.Hour))
+	jwks = kr.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "k2", jwks.Keys[0].Kid)
+}
+
+func TestKeyFuncRejectsAlgorithmMismatch(t *testing.T) {
+	privatePEM, publicPEM := generatePEM(t, AlgorithmRS256)
+	key, err := NewKeyFromPEM("k1", AlgorithmRS256, privatePEM, nil)
+	require.NoError(t, err)
+	kr, err := NewKeyring("k1", key)
+	require.NoError(t, err)
+	useKeyring(t, kr)
+
+	// 使用公钥作为HMAC密钥伪造的token不被接受
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		config.identityKey: "user-000001",
+		"iss":              config.issuer,
+		"aud":              config.audience,
+		"exp":              time.Now().Add(time.Hour).Unix(),
+	})
+	forged.Header["kid"] = "k1"
+	tokenString, err := forged.SignedString(publicPEM)
+	require.NoError(t, err)
+
+	_, err = Verify(tokenString)
+	assert.ErrorIs(t, err, jwt.ErrSignatureInvalid)
+}
+
+func TestJSONWebKeyPublicKey(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			privatePEM, _ := generatePEM(t, algorithm)
+			key, err := NewKeyFromPEM("key-1", algorithm, privatePEM, nil)
+			require.NoError(t, err)
+
+			jwk, err := key.jwk()
+			require.NoError(t, err)
+
+			// 解码后的公钥与原公钥一致
+			publicKey, err := jwk.PublicKey()
+			require.NoError(t, err)
+			assert.True(t, publicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(key.PublicKey))
+		})
+	}
+
+	_, err := JSONWebKey{Kty: "oct", Kid: "key-1"}.PublicKey()
+	assert.Error(t, err)
+
+	// 不在曲线上的点被拒绝
+	_, err = JSONWebKey{Kty: "EC", Crv: "P-256", X: "AQ", Y: "AQ"}.PublicKey()
+	assert.Error(t, err)
+}
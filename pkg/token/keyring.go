@@ -0,0 +1,192 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// 只配置了单个密钥时使用的密钥ID.
+const defaultKeyID = "default"
+
+// 支持的签名算法.
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	// ErrKeyNotFound 表示token头中的kid在密钥环中不存在.
+	ErrKeyNotFound = errors.New("signing key not found")
+	// ErrKeyRetired 表示签发token的密钥已经退役.
+	ErrKeyRetired = errors.New("signing key has been retired")
+)
+
+// Key 表示密钥环中的一个签名密钥.
+type Key struct {
+	// 密钥ID, 签发token时写入kid头
+	ID string
+	// 签名算法, 为空时使用HS256
+	Algorithm string
+	// HMAC密钥, 仅用于HS256
+	Secret []byte
+	// 非对称算法的私钥, 只用于校验的密钥可以为空
+	PrivateKey crypto.PrivateKey
+	// 非对称算法的公钥, 通过JWKS公开给其他服务
+	PublicKey crypto.PublicKey
+	// 密钥退役时间, 零值表示不退役. 退役后由该密钥签发的token不再被接受
+	RetireAt time.Time
+}
+
+// Keyring 保存一组签名密钥, 其中一个是当前用于签发的活动密钥, 其余密钥只用于校验.
+type Keyring struct {
+	active string
+	keys   map[string]Key
+}
+
+// 当前使用的密钥环, 支持在运行时原子替换.
+var keyring atomic.Pointer[Keyring]
+
+// NewKeyring 创建密钥环, activeID指定用于签发token的密钥.
+func NewKeyring(activeID string, keys ...Key) (*Keyring, error) {
+	kr := &Keyring{active: activeID, keys: make(map[string]Key, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("signing key id cannot be empty")
+		}
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := kr.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key %q", key.ID)
+		}
+		kr.keys[key.ID] = key
+	}
+
+	active, ok := kr.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q: %w", activeID, ErrKeyNotFound)
+	}
+	if active.retired(now()) {
+		return nil, fmt.Errorf("active signing key %q: %w", activeID, ErrKeyRetired)
+	}
+	if active.signingKey() == nil {
+		return nil, fmt.Errorf("active signing key %q has no private key", activeID)
+	}
+
+	return kr, nil
+}
+
+// SetKeyring 替换当前使用的密钥环, 用于在不重启服务的情况下轮换密钥.
+func SetKeyring(kr *Keyring) {
+	keyring.Store(kr)
+}
+
+// 返回当前使用的密钥环, 未设置时使用config中的单个密钥.
+func currentKeyring() *Keyring {
+	if kr := keyring.Load(); kr != nil {
+		return kr
+	}
+
+	return &Keyring{active: defaultKeyID, keys: map[string]Key{defaultKeyID: {ID: defaultKeyID, Secret: []byte(config.key)}}}
+}
+
+// ActiveKey 返回当前用于签发token的密钥.
+func (kr *Keyring) ActiveKey() Key {
+	return kr.keys[kr.active]
+}
+
+// 根据token头中的kid查找校验密钥, 没有kid的token是密钥轮换之前签发的, 使用活动密钥校验.
+func (kr *Keyring) keyFunc(token *jwt.Token) (interface{}, error) {
+	key := kr.ActiveKey()
+	if kid, _ := token.Header["kid"].(string); kid != "" {
+		var ok bool
+		if key, ok = kr.keys[kid]; !ok {
+			return nil, ErrKeyNotFound
+		}
+		if key.retired(now()) {
+			return nil, ErrKeyRetired
+		}
+	}
+
+	// 确保token加密算法与密钥的算法一致, 防止使用公钥作为HMAC密钥伪造token
+	if token.Method.Alg() != key.method().Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	return key.verifyingKey(), nil
+}
+
+// 校验密钥的算法与密钥材料是否匹配.
+func (k Key) validate() error {
+	switch k.Algorithm {
+	case "", AlgorithmHS256:
+		if len(k.Secret) == 0 {
+			return fmt.Errorf("signing key %q has an empty secret", k.ID)
+		}
+		return nil
+	case AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA:
+	default:
+		return fmt.Errorf("signing key %q has an unsupported algorithm %q", k.ID, k.Algorithm)
+	}
+
+	if k.PublicKey == nil {
+		return fmt.Errorf("signing key %q has no public key", k.ID)
+	}
+	if !publicKeyMatches(k.Algorithm, k.PublicKey) {
+		return fmt.Errorf("public key of signing key %q does not match algorithm %s", k.ID, k.Algorithm)
+	}
+
+	return nil
+}
+
+// 返回密钥对应的jwt签名方法.
+func (k Key) method() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case AlgorithmES256:
+		return jwt.SigningMethodES256
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+// 返回签发token使用的密钥, 没有私钥时返回nil.
+func (k Key) signingKey() interface{} {
+	if k.method() == jwt.SigningMethodHS256 {
+		if len(k.Secret) == 0 {
+			return nil
+		}
+		return k.Secret
+	}
+
+	return k.PrivateKey
+}
+
+// 返回校验token签名使用的密钥.
+func (k Key) verifyingKey() interface{} {
+	if k.method() == jwt.SigningMethodHS256 {
+		return k.Secret
+	}
+
+	return k.PublicKey
+}
+
+// 判断密钥在t时刻是否已经退役.
+func (k Key) retired(t time.Time) bool {
+	return !k.RetireAt.IsZero() && !t.Before(k.RetireAt)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 临时替换当前密钥环, 测试结束后恢复.
+func useKeyring(tb testing.TB, kr *Keyring) {
+	tb.Helper()
+	orig := keyring.Load()
+	SetKeyring(kr)
+	tb.Cleanup(func() { keyring.Store(orig) })
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring("k2", Key{ID: "k1", Secret: []byte("secret-1")})
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = NewKeyring("k1", Key{ID: "k1", Secret: []byte("secret-1")}, Key{ID: "k1", Secret: []byte("secret-2")})
+	assert.Error(t, err)
+
+	_, err = NewKeyring("k1", Key{ID: "k1", Secret: []byte("secret-1"), RetireAt: time.Now().Add(-time.Minute)})
+	assert.ErrorIs(t, err, ErrKeyRetired)
+}
+
+func TestKeyringRotation(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, base)
+
+	k1 := Key{ID: "k1", Secret: []byte("secret-1")}
+	kr, err := NewKeyring("k1", k1)
+	require.NoError(t, err)
+	useKeyring(t, kr)
+
+	oldToken, _, err := Sign("user-000001")
+	require.NoError(t, err)
+
+	// 轮换到k2, k1保留到退役时间之前用于校验
+	k1.RetireAt = base.Add(time.Hour)
+	kr, err = NewKeyring("k2", k1, Key{ID: "k2", Secret: []byte("secret-2")})
+	require.NoError(t, err)
+	SetKeyring(kr)
+
+	newToken, _, err := Sign("user-000002")
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "k2", parsed.Header["kid"])
+
+	claims, err := Verify(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-000001", claims.Identity)
+
+	claims, err = Verify(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-000002", claims.Identity)
+
+	// k1退役后, 由k1签发的token不再被接受
+	setNow(t, base.Add(time.Hour))
+	_, err = Verify(oldToken)
+	assert.ErrorIs(t, err, ErrKeyRetired)
+
+	// 从密钥环中移除k1后, 由k1签发的token同样不再被接受
+	kr, err = NewKeyring("k2", Key{ID: "k2", Secret: []byte("secret-2")})
+	require.NoError(t, err)
+	SetKeyring(kr)
+	_, err = Verify(oldToken)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
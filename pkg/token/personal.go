@@ -0,0 +1,39 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+)
+
+// PersonalPrefix 是个人访问令牌的前缀, 用于和JWT区分, 也便于密钥扫描工具识别泄露的令牌.
+const PersonalPrefix = "mbpat_"
+
+// 个人访问令牌包含的随机字节数.
+const personalTokenBytes = 32
+
+// SignPersonal 签发一个不透明的随机个人访问令牌.
+// 个人访问令牌不是JWT, 其有效期和权限完全由服务端保存的记录决定.
+func SignPersonal() (string, error) {
+	buf := make([]byte, personalTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return PersonalPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// IsPersonal 判断令牌是否为个人访问令牌.
+func IsPersonal(tokenString string) bool {
+	return strings.HasPrefix(tokenString, PersonalPrefix)
+}
+
+// HashPersonal 计算个人访问令牌的SHA-256摘要, 服务端只保存摘要.
+func HashPersonal(tokenString string) string {
+	return HashRefresh(tokenString)
+}
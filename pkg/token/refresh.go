@@ -0,0 +1,34 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// 刷新令牌包含的随机字节数.
+const refreshTokenBytes = 32
+
+// SignRefresh 签发一个不透明的随机刷新令牌, 返回令牌明文和过期时间.
+// 刷新令牌不是JWT, 其有效性完全由服务端保存的记录决定.
+func SignRefresh() (string, time.Time, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), now().Add(config.refreshExpiration), nil
+}
+
+// HashRefresh 计算刷新令牌的SHA-256摘要, 服务端只保存摘要, 避免数据库泄露后令牌被直接使用.
+func HashRefresh(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
@@ -7,6 +7,7 @@ package token
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -28,16 +30,122 @@ type Config struct {
 	identityKey string
 	// 签发的token过期时间
 	expiration time.Duration
+	// 签发的刷新令牌过期时间
+	refreshExpiration time.Duration
+	// 签发的两步登录挑战令牌过期时间
+	challengeExpiration time.Duration
+	// 签发的模拟登录令牌过期时间
+	impersonationExpiration time.Duration
+	// token的签发者, 对应iss声明
+	issuer string
+	// token的接收方, 对应aud声明
+	audience string
+	// 校验exp, nbf和iat时允许的时钟偏差
+	leeway time.Duration
 }
 
+// 函数选项类型, 用于自定义Init的行为.
+type Option func(*Config)
+
 var (
 	// 默认值.
-	config = Config{"Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5", "identityKey", 2 * time.Hour}
-	once   sync.Once
+	config = Config{
+		key:                     "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		identityKey:             "identityKey",
+		expiration:              2 * time.Hour,
+		refreshExpiration:       7 * 24 * time.Hour,
+		challengeExpiration:     5 * time.Minute,
+		impersonationExpiration: 15 * time.Minute,
+		issuer:                  "miniblog",
+		audience:                "miniblog",
+	}
+	once sync.Once
+
+	// 获取当前时间的函数, 测试时可以替换.
+	now = time.Now
 )
 
+var (
+	// ErrTokenExpired 表示token已过期.
+	ErrTokenExpired = jwt.ErrTokenExpired
+	// ErrTokenNotValidYet 表示token尚未生效(nbf或iat晚于当前时间).
+	ErrTokenNotValidYet = jwt.ErrTokenNotValidYet
+	// ErrTokenInvalidAudience 表示token的接收方不是本服务.
+	ErrTokenInvalidAudience = jwt.ErrTokenInvalidAudience
+	// ErrTokenInvalidIssuer 表示token不是由预期的签发者签发.
+	ErrTokenInvalidIssuer = jwt.ErrTokenInvalidIssuer
+	// ErrTokenInvalidPurpose 表示token的用途与预期不符, 例如使用挑战令牌访问接口.
+	ErrTokenInvalidPurpose = errors.New("token purpose is invalid")
+)
+
+// 挑战令牌的用途, 写入pur声明. 访问令牌没有pur声明.
+const challengePurpose = "mfa"
+
+// 允许通过选项自定义刷新令牌的过期时间.
+func WithRefreshExpiration(expiration time.Duration) Option {
+	return func(c *Config) {
+		if expiration != 0 {
+			c.refreshExpiration = expiration
+		}
+	}
+}
+
+// 允许通过选项自定义两步登录挑战令牌的过期时间.
+func WithChallengeExpiration(expiration time.Duration) Option {
+	return func(c *Config) {
+		if expiration != 0 {
+			c.challengeExpiration = expiration
+		}
+	}
+}
+
+// 允许通过选项自定义模拟登录令牌的过期时间.
+func WithImpersonationExpiration(expiration time.Duration) Option {
+	return func(c *Config) {
+		if expiration != 0 {
+			c.impersonationExpiration = expiration
+		}
+	}
+}
+
+// 允许通过选项自定义token的签发者.
+func WithIssuer(issuer string) Option {
+	return func(c *Config) {
+		if issuer != "" {
+			c.issuer = issuer
+		}
+	}
+}
+
+// 允许通过选项自定义token的接收方.
+func WithAudience(audience string) Option {
+	return func(c *Config) {
+		if audience != "" {
+			c.audience = audience
+		}
+	}
+}
+
+// 允许通过选项设置校验时间类声明时的时钟偏差.
+func WithLeeway(leeway time.Duration) Option {
+	return func(c *Config) {
+		if leeway >= 0 {
+			c.leeway = leeway
+		}
+	}
+}
+
+// 允许通过选项使用密钥环签发和校验token, 不设置时使用key作为唯一的密钥.
+func WithKeyring(kr *Keyring) Option {
+	return func(c *Config) {
+		if kr != nil {
+			SetKeyring(kr)
+		}
+	}
+}
+
 // 设置包级别的配置config, config会用于本包后面的token签发和解析.
-func Init(key string, identityKey string, expiration time.Duration) {
+func Init(key string, identityKey string, expiration time.Duration, opts ...Option) {
 	once.Do(func() {
 		if key != "" {
 			config.key = key // 设置密钥
@@ -48,42 +156,172 @@ func Init(key string, identityKey string, expiration time.Duration) {
 		if expiration != 0 {
 			config.expiration = expiration
 		}
+		for _, opt := range opts {
+			opt(&config)
+		}
+		// 没有配置密钥环时, 使用key作为唯一的签名密钥
+		if keyring.Load() == nil {
+			SetKeyring(currentKeyring())
+		}
 	})
 }
 
+// 解析后的token声明.
+type Claims struct {
+	// 用户身份, 对应identityKey声明
+	Identity string
+	// token唯一标识, 对应jti声明
+	ID string
+	// 签发时间, 对应iat声明
+	IssuedAt time.Time
+	// 过期时间, 对应exp声明
+	ExpiresAt time.Time
+	// 会话标识, 对应sid声明, 只有通过登录或刷新签发的token才有
+	SessionID string
+	// 实际操作者的用户身份, 对应act声明中的sub, 只有模拟登录签发的token才有
+	ActorID string
+	// 用户所属的租户, 对应tid声明
+	TenantID string
+}
+
 // 使用指定密钥key解析token, 解析成功返回token上下文, 否则报错.
 func Parse(tokenString string, key string) (string, error) {
-	// 解析token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	claims, err := ParseClaims(tokenString, key)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.Identity, nil
+}
+
+// 使用指定密钥key解析token, 解析成功返回token中的声明, 否则报错.
+func ParseClaims(tokenString string, key string) (*Claims, error) {
+	return parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// 确保token加密算法是预期加密算法
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
 		return []byte(key), nil
-	})
+	}, "")
+}
+
+// 使用当前密钥环中与token的kid头对应的密钥解析token.
+func Verify(tokenString string) (*Claims, error) {
+	return parse(tokenString, currentKeyring().keyFunc, "")
+}
+
+// VerifyChallenge 解析两步登录的挑战令牌, 挑战令牌只能用于完成登录, 不能作为访问令牌使用.
+func VerifyChallenge(tokenString string) (*Claims, error) {
+	return parse(tokenString, currentKeyring().keyFunc, challengePurpose)
+}
+
+// 使用keyFunc返回的密钥校验签名, 并校验和提取token中的声明, purpose为token预期的用途.
+func parse(tokenString string, keyFunc jwt.Keyfunc, purpose string) (*Claims, error) {
+	// 解析token, 时间类声明由validateClaims统一校验, 以便支持时钟偏差
+	token, err := jwt.Parse(tokenString, keyFunc, jwt.WithoutClaimsValidation())
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	// 这里的claims是The second segment of the token, 即payload
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
 	}
 
-	var identityKey string
-	// 如果解析成功, 从token中取出token的主题, 这里的claims是The second segment of the token, 即payload
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if key, exists := claims[config.identityKey]; exists {
-			if identity, valid := key.(string); valid {
-				identityKey = identity // 获取身份键
-			}
-		}
+	if err := validateClaims(mapClaims); err != nil {
+		return nil, err
+	}
+	if pur, _ := mapClaims["pur"].(string); pur != purpose {
+		return nil, ErrTokenInvalidPurpose
 	}
 
-	if identityKey == "" {
-		return "", jwt.ErrSignatureInvalid
+	claims := &Claims{
+		IssuedAt:  numericDate(mapClaims, "iat"),
+		ExpiresAt: numericDate(mapClaims, "exp"),
+	}
+	// 如果解析成功, 从token中取出token的主题和唯一标识
+	if identity, valid := mapClaims[config.identityKey].(string); valid {
+		claims.Identity = identity // 获取身份键
+	}
+	if jti, valid := mapClaims["jti"].(string); valid {
+		claims.ID = jti
+	}
+	if sid, valid := mapClaims["sid"].(string); valid {
+		claims.SessionID = sid
+	}
+	if tid, valid := mapClaims["tid"].(string); valid {
+		claims.TenantID = tid
+	}
+	if act, valid := mapClaims["act"].(map[string]any); valid {
+		claims.ActorID, _ = act["sub"].(string)
+	}
+
+	if claims.Identity == "" {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	return claims, nil
+}
+
+// 读取时间类声明, 声明不存在时返回零值.
+func numericDate(claims jwt.MapClaims, name string) time.Time {
+	switch v := claims[name].(type) {
+	case float64:
+		return time.Unix(int64(v), 0)
+	case json.Number:
+		sec, _ := v.Int64()
+		return time.Unix(sec, 0)
+	}
+
+	return time.Time{}
+}
+
+// 校验token中的注册声明, 时间类声明的比较会考虑配置的时钟偏差.
+func validateClaims(claims jwt.MapClaims) error {
+	current := now()
+
+	// exp为必需声明, 没有过期时间的token不被接受
+	if !claims.VerifyExpiresAt(current.Add(-config.leeway).Unix(), true) {
+		return ErrTokenExpired
+	}
+	if !claims.VerifyNotBefore(current.Add(config.leeway).Unix(), false) {
+		return ErrTokenNotValidYet
+	}
+	if !claims.VerifyIssuedAt(current.Add(config.leeway).Unix(), false) {
+		return ErrTokenNotValidYet
+	}
+	if !claims.VerifyIssuer(config.issuer, true) {
+		return ErrTokenInvalidIssuer
+	}
+	if !claims.VerifyAudience(config.audience, true) {
+		return ErrTokenInvalidAudience
 	}
 
-	return identityKey, nil
+	return nil
 }
 
 // 从请求中获取JWT, 将其传递给Parse函数来解析.
 func ParseRequest(ctx context.Context) (string, error) {
+	claims, err := ParseRequestClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.Identity, nil
+}
+
+// 从请求中获取JWT, 将其传递给ParseClaims函数来解析.
+func ParseRequestClaims(ctx context.Context) (*Claims, error) {
+	token, err := RequestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return Verify(token) // 解析token
+}
+
+// RequestToken 从请求的Authorization头中提取Bearer令牌, 不做任何校验.
+func RequestToken(ctx context.Context) (string, error) {
 	var (
 		token string
 		err   error
@@ -108,24 +346,54 @@ func ParseRequest(ctx context.Context) (string, error) {
 		}
 	}
 
-	return Parse(token, config.key) // 解析token
+	return token, nil
 }
 
-// Sign 使用 jwtSecret 签发 token, token 的 claims 中会存放传入的 subject.
+// Sign 使用密钥环中的活动密钥签发 token, token 的 claims 中会存放传入的 subject.
 func Sign(identityKey string) (string, time.Time, error) {
-	// 计算过期时间
-	expireAt := time.Now().Add(config.expiration)
+	return sign(identityKey, config.expiration, nil)
+}
+
+// SignSession 签发属于指定会话的 token, 用户所属的租户和会话标识分别保存在 tid 和 sid 声明中.
+func SignSession(identityKey string, tenantID string, sessionID string) (string, time.Time, error) {
+	return sign(identityKey, config.expiration, jwt.MapClaims{"tid": tenantID, "sid": sessionID})
+}
+
+// SignChallenge 签发两步登录的挑战令牌, 用户通过二次验证后使用挑战令牌换取访问令牌.
+func SignChallenge(identityKey string) (string, time.Time, error) {
+	return sign(identityKey, config.challengeExpiration, jwt.MapClaims{"pur": challengePurpose})
+}
+
+// SignImpersonation 签发模拟登录的 token, token 的主体为被模拟的用户, 被模拟用户所属的租户保存在 tid 声明中, 实际操作者保存在 act 声明中.
+func SignImpersonation(identityKey string, tenantID string, actorID string) (string, time.Time, error) {
+	return sign(identityKey, config.impersonationExpiration, jwt.MapClaims{"tid": tenantID, "act": map[string]any{"sub": actorID}})
+}
+
+// 签发有效期为expiration的token, extra中的声明会一并写入token.
+func sign(identityKey string, expiration time.Duration, extra jwt.MapClaims) (string, time.Time, error) {
+	// 计算签发时间和过期时间
+	issuedAt := now()
+	expireAt := issuedAt.Add(expiration)
 
 	// token内容
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		config.identityKey: identityKey,       // 用户身份
-		"nbf":              time.Now().Unix(), // token生效时间
-		"iat":              time.Now().Unix(), // 签发时间
-		"exp":              expireAt.Unix(),   // 过期时间
-	})
+	claims := jwt.MapClaims{
+		config.identityKey: identityKey,         // 用户身份
+		"iss":              config.issuer,       // 签发者
+		"aud":              config.audience,     // 接收方
+		"jti":              uuid.New().String(), // token唯一标识
+		"nbf":              issuedAt.Unix(),     // token生效时间
+		"iat":              issuedAt.Unix(),     // 签发时间
+		"exp":              expireAt.Unix(),     // 过期时间
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	key := currentKeyring().ActiveKey()
+	token := jwt.NewWithClaims(key.method(), claims)
 
-	// 签发token
-	tokenString, err := token.SignedString([]byte(config.key))
+	// 使用密钥环中的活动密钥签发token, 并在头部写入kid, 以便密钥轮换后仍能找到校验密钥
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.signingKey())
 	if err != nil {
 		return "", time.Time{}, err
 	}
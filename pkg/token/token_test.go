@@ -0,0 +1,160 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 将当前时间固定为t, 测试结束后恢复.
+func setNow(tb testing.TB, t time.Time) {
+	tb.Helper()
+	orig := now
+	now = func() time.Time { return t }
+	tb.Cleanup(func() { now = orig })
+}
+
+// 临时修改包级别配置, 测试结束后恢复.
+func setConfig(tb testing.TB, fn func(c *Config)) {
+	tb.Helper()
+	orig := config
+	fn(&config)
+	tb.Cleanup(func() { config = orig })
+}
+
+func TestSignAndParse(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, base)
+
+	tokenString, expireAt, err := Sign("user-000001")
+	require.NoError(t, err)
+	assert.Equal(t, base.Add(config.expiration), expireAt)
+
+	identity, err := Parse(tokenString, config.key)
+	require.NoError(t, err)
+	assert.Equal(t, "user-000001", identity)
+}
+
+func TestParseExpired(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setConfig(t, func(c *Config) { c.leeway = 30 * time.Second })
+	setNow(t, base)
+
+	tokenString, expireAt, err := Sign("user-000001")
+	require.NoError(t, err)
+
+	// 在时钟偏差范围内仍然有效
+	setNow(t, expireAt.Add(10*time.Second))
+	_, err = Parse(tokenString, config.key)
+	assert.NoError(t, err)
+
+	// 超出时钟偏差后返回过期错误
+	setNow(t, expireAt.Add(time.Minute))
+	_, err = Parse(tokenString, config.key)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestParseNotValidYet(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setConfig(t, func(c *Config) { c.leeway = 30 * time.Second })
+	setNow(t, base)
+
+	tokenString, _, err := Sign("user-000001")
+	require.NoError(t, err)
+
+	// 验证方的时钟稍慢于签发方时仍然有效
+	setNow(t, base.Add(-10*time.Second))
+	_, err = Parse(tokenString, config.key)
+	assert.NoError(t, err)
+
+	setNow(t, base.Add(-time.Minute))
+	_, err = Parse(tokenString, config.key)
+	assert.ErrorIs(t, err, ErrTokenNotValidYet)
+}
+
+func TestParseInvalidAudience(t *testing.T) {
+	tokenString, _, err := Sign("user-000001")
+	require.NoError(t, err)
+
+	setConfig(t, func(c *Config) { c.audience = "another-service" })
+	_, err = Parse(tokenString, config.key)
+	assert.ErrorIs(t, err, ErrTokenInvalidAudience)
+}
+
+func TestParseInvalidIssuer(t *testing.T) {
+	tokenString, _, err := Sign("user-000001")
+	require.NoError(t, err)
+
+	setConfig(t, func(c *Config) { c.issuer = "another-issuer" })
+	_, err = Parse(tokenString, config.key)
+	assert.ErrorIs(t, err, ErrTokenInvalidIssuer)
+}
+
+func TestChallengeToken(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, base)
+
+	challenge, expireAt, err := SignChallenge("user-000001")
+	require.NoError(t, err)
+	assert.Equal(t, base.Add(config.challengeExpiration), expireAt)
+
+	claims, err := VerifyChallenge(challenge)
+	require.NoError(t, err)
+	assert.Equal(t, "user-000001", claims.Identity)
+
+	// 挑战令牌不能作为访问令牌使用, 访问令牌也不能作为挑战令牌使用
+	_, err = Verify(challenge)
+	assert.ErrorIs(t, err, ErrTokenInvalidPurpose)
+
+	tokenString, _, err := Sign("user-000001")
+	require.NoError(t, err)
+	_, err = VerifyChallenge(tokenString)
+	assert.ErrorIs(t, err, ErrTokenInvalidPurpose)
+}
+
+func TestSessionToken(t *testing.T) {
+	tokenString, _, err := SignSession("user-000001", "blog-a", "session-1")
+	require.NoError(t, err)
+
+	claims, err := Verify(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user-000001", claims.Identity)
+	assert.Equal(t, "blog-a", claims.TenantID)
+	assert.Equal(t, "session-1", claims.SessionID)
+
+	tokenString, _, err = Sign("user-000001")
+	require.NoError(t, err)
+	claims, err = Verify(tokenString)
+	require.NoError(t, err)
+	assert.Empty(t, claims.TenantID)
+	assert.Empty(t, claims.SessionID)
+}
+
+func TestImpersonationToken(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, base)
+
+	tokenString, expireAt, err := SignImpersonation("user-000001", "blog-a", "user-000000")
+	require.NoError(t, err)
+	assert.Equal(t, base.Add(config.impersonationExpiration), expireAt)
+
+	claims, err := Verify(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user-000001", claims.Identity)
+	assert.Equal(t, "blog-a", claims.TenantID)
+	assert.Equal(t, "user-000000", claims.ActorID)
+	assert.Empty(t, claims.SessionID)
+
+	tokenString, _, err = Sign("user-000001")
+	require.NoError(t, err)
+	claims, err = Verify(tokenString)
+	require.NoError(t, err)
+	assert.Empty(t, claims.ActorID)
+}
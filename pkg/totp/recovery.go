@@ -0,0 +1,51 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package totp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// 恢复码使用的字符集, 去掉了容易混淆的0, 1, o和l.
+const recoveryAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+// 每个恢复码包含的字符数, 以短横线分为两组.
+const recoveryCodeLength = 10
+
+// GenerateRecoveryCodes 生成n个随机恢复码, 格式形如abcde-fghij.
+// 恢复码用于在无法使用身份验证器应用时完成登录, 每个恢复码只能使用一次.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	buf := make([]byte, recoveryCodeLength)
+	for range n {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+
+		var sb strings.Builder
+		for i, b := range buf {
+			if i == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+			// 字符集长度为32, 取模不会引入偏差
+			sb.WriteByte(recoveryAlphabet[int(b)%len(recoveryAlphabet)])
+		}
+		codes = append(codes, sb.String())
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode 计算恢复码的SHA-256摘要, 服务端只保存摘要.
+// 计算前会忽略大小写, 空格和短横线, 方便用户输入.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
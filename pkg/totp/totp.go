@@ -0,0 +1,189 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+// Package totp 实现了RFC 6238定义的基于时间的一次性密码(TOTP), 以及用于找回账号的恢复码.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint: gosec
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 密钥包含的随机字节数, RFC 4226建议至少160位.
+const secretBytes = 20
+
+// 不带填充的base32编码, 与主流身份验证器应用兼容.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ErrInvalidSecret 表示密钥不是合法的base32字符串.
+var ErrInvalidSecret = errors.New("invalid totp secret")
+
+// TOTP 根据密钥和当前时间生成和校验一次性密码.
+type TOTP struct {
+	// 一次性密码的位数
+	digits int
+	// 时间步长
+	period time.Duration
+	// 校验时允许前后偏移的时间步数, 用于容忍客户端和服务端的时钟偏差
+	skew int64
+	// 获取当前时间的函数, 测试时可以替换
+	now func() time.Time
+}
+
+// 函数选项类型, 用于自定义New的行为.
+type Option func(*TOTP)
+
+// 允许通过选项自定义一次性密码的位数, RFC 4226要求为6到8位.
+func WithDigits(digits int) Option {
+	return func(t *TOTP) {
+		if digits >= 6 && digits <= 8 {
+			t.digits = digits
+		}
+	}
+}
+
+// 允许通过选项自定义时间步长.
+func WithPeriod(period time.Duration) Option {
+	return func(t *TOTP) {
+		if period > 0 {
+			t.period = period
+		}
+	}
+}
+
+// 允许通过选项自定义校验时允许偏移的时间步数.
+func WithSkew(skew int64) Option {
+	return func(t *TOTP) {
+		if skew >= 0 {
+			t.skew = skew
+		}
+	}
+}
+
+// 允许通过选项注入时钟, 便于测试.
+func WithClock(now func() time.Time) Option {
+	return func(t *TOTP) {
+		if now != nil {
+			t.now = now
+		}
+	}
+}
+
+// New 创建TOTP实例, 默认使用6位密码, 30秒步长, 前后各容忍1个时间步.
+func New(opts ...Option) *TOTP {
+	t := &TOTP{digits: 6, period: 30 * time.Second, skew: 1, now: time.Now}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// GenerateSecret 生成一个随机的base32编码密钥.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(buf), nil
+}
+
+// URL 返回用于生成二维码的otpauth地址, 身份验证器应用扫描后即可添加账号.
+func (t *TOTP) URL(issuer string, account string, secret string) string {
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(t.digits))
+	values.Set("period", fmt.Sprint(int64(t.period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: values.Encode(),
+	}
+	return u.String()
+}
+
+// Step 返回时刻at所在的时间步.
+func (t *TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.period/time.Second)
+}
+
+// Generate 生成当前时间的一次性密码.
+func (t *TOTP) Generate(secret string) (string, error) {
+	return t.GenerateAt(secret, t.now())
+}
+
+// GenerateAt 生成时刻at的一次性密码.
+func (t *TOTP) GenerateAt(secret string, at time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return t.hotp(key, t.Step(at)), nil
+}
+
+// Validate 校验一次性密码, 成功时返回密码对应的时间步.
+// 调用方应当保存最近一次使用的时间步, 并拒绝不大于该时间步的密码, 防止同一个密码被重放.
+func (t *TOTP) Validate(secret string, code string) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != t.digits {
+		return 0, false
+	}
+
+	current := t.Step(t.now())
+	for i := -t.skew; i <= t.skew; i++ {
+		step := current + i
+		if subtle.ConstantTimeCompare([]byte(t.hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// 按照RFC 4226计算计数器counter对应的一次性密码.
+func (t *TOTP) hotp(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// 动态截断
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range t.digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.digits, value%mod)
+}
+
+// 解码base32密钥, 兼容小写和带空格的输入.
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := encoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return key, nil
+}
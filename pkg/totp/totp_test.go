@@ -0,0 +1,89 @@
+// Copyright 2024 alainyan <alainyan@yahoo.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Alainyan1/miniblog.
+
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 附录B中SHA1算法使用的密钥.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateAtRFC6238(t *testing.T) {
+	otp := New(WithDigits(8))
+	for unix, want := range map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	} {
+		got, err := otp.GenerateAt(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	base := time.Unix(1111111111, 0)
+	now := base
+	otp := New(WithClock(func() time.Time { return now }))
+
+	code, err := otp.Generate(rfcSecret)
+	require.NoError(t, err)
+	step, ok := otp.Validate(rfcSecret, code)
+	assert.True(t, ok)
+	assert.Equal(t, otp.Step(base), step)
+
+	// 相邻时间步内的密码仍然有效
+	now = base.Add(30 * time.Second)
+	step, ok = otp.Validate(rfcSecret, code)
+	assert.True(t, ok)
+	assert.Equal(t, otp.Step(base), step)
+
+	// 超出允许的偏移后密码失效
+	now = base.Add(90 * time.Second)
+	_, ok = otp.Validate(rfcSecret, code)
+	assert.False(t, ok)
+
+	_, ok = otp.Validate(rfcSecret, "12345")
+	assert.False(t, ok)
+	_, ok = otp.Validate("not-base32!", code)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	key, err := decodeSecret(secret)
+	require.NoError(t, err)
+	assert.Len(t, key, secretBytes)
+
+	u, err := url.Parse(New().URL("miniblog", "root", secret))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/miniblog:root", u.Path)
+	assert.Equal(t, secret, u.Query().Get("secret"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, codes[0])
+	assert.NotEqual(t, codes[0], codes[1])
+
+	// 输入时忽略大小写和分隔符
+	assert.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+string([]byte(codes[0])[:5])+string([]byte(codes[0])[6:])))
+}